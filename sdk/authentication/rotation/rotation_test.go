@@ -0,0 +1,218 @@
+package rotation
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model/rbac"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeServer struct {
+	mutex       sync.Mutex
+	keys        map[string]string
+	status      map[string]string
+	calls       []string
+	failNewKeys bool
+	tokens      []string
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{
+		keys:   map[string]string{"old": "old-secret"},
+		status: map[string]string{"old": "ACTIVE"},
+	}
+}
+
+func (server *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.URL.Path == "/token":
+		var body map[string]string
+		json.NewDecoder(r.Body).Decode(&body)
+		secret, found := server.keys[body["keyId"]]
+		if !found || secret != body["keySecret"] || server.status[body["keyId"]] != "ACTIVE" || (server.failNewKeys && body["keyId"] != "old") {
+			w.WriteHeader(http.StatusUnauthorized)
+			w.Write([]byte(`{"message":"invalid credentials"}`))
+			return
+		}
+		json.NewEncoder(w).Encode(map[string]string{"token": "token-" + body["keyId"]})
+		return
+	case r.URL.Path == "/applications/app/accessKeys" && r.Method == http.MethodGet:
+		keys := []rbac.AccessKeyResponse{}
+		for id := range server.keys {
+			keys = append(keys, rbac.AccessKeyResponse{Id: id, Status: server.status[id]})
+		}
+		json.NewEncoder(w).Encode(keys)
+		return
+	case r.URL.Path == "/applications/app/accessKeys" && r.Method == http.MethodPost:
+		server.keys["new"] = "new-secret"
+		server.status["new"] = "ACTIVE"
+		json.NewEncoder(w).Encode(rbac.AccessKey{Id: "new", Secret: "new-secret"})
+	case strings.HasSuffix(r.URL.Path, "/status"):
+		id := strings.TrimSuffix(strings.TrimPrefix(r.URL.Path, "/applications/app/accessKeys/"), "/status")
+		if server.status[id] == "ACTIVE" {
+			server.status[id] = "INACTIVE"
+		} else {
+			server.status[id] = "ACTIVE"
+		}
+		w.Write([]byte("{}"))
+	case r.Method == http.MethodDelete:
+		id := strings.TrimPrefix(r.URL.Path, "/applications/app/accessKeys/")
+		delete(server.keys, id)
+		delete(server.status, id)
+		w.Write([]byte("{}"))
+	default:
+		server.tokens = append(server.tokens, r.Header.Get("X-Authorization"))
+		w.Write([]byte("[]"))
+		return
+	}
+	server.calls = append(server.calls, r.Method+" "+r.URL.Path)
+}
+
+func TestRotate(t *testing.T) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	httpSettings := settings.NewHttpSettings(server.URL)
+	workerClient := client.NewAPIClient(settings.NewAuthenticationSettings("old", "old-secret"), httpSettings)
+	adminClient := client.NewAPIClient(nil, httpSettings)
+	metadataClient := client.NewMetadataClient(workerClient)
+
+	_, _, err := metadataClient.GetAll(context.Background())
+	assert.NoError(t, err)
+
+	dir := t.TempDir()
+	keyFile := filepath.Join(dir, "key.json")
+	envFile := filepath.Join(dir, "worker.env")
+	assert.NoError(t, os.WriteFile(envFile, []byte("CONDUCTOR_SERVER_URL=http://localhost\nexport CONDUCTOR_AUTH_KEY=old\n"), 0644))
+	var published []string
+	rotator := NewRotator(client.NewApplicationClient(adminClient), httpSettings,
+		FileSink(keyFile),
+		EnvFileSink(envFile),
+		ClientSink(workerClient),
+		Callback(func(ctx context.Context, key Key) error {
+			published = append(published, key.String())
+			return nil
+		}),
+	).WithGracePeriod(0).WithDeletionDelay(0)
+
+	result, err := rotator.Rotate(context.Background(), "app", "old")
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{ApplicationId: "app", NewKeyId: "new", DeactivatedKeyIds: []string{"old"}, RetiredKeyIds: []string{"old"}}, result)
+	assert.Equal(t, []string{
+		"POST /applications/app/accessKeys",
+		"POST /applications/app/accessKeys/old/status",
+		"DELETE /applications/app/accessKeys/old",
+	}, fake.calls)
+	assert.Equal(t, []string{"access key new of application app"}, published)
+
+	data, err := os.ReadFile(keyFile)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"keyId": "new", "keySecret": "new-secret"}`, string(data))
+	info, err := os.Stat(keyFile)
+	assert.NoError(t, err)
+	assert.Equal(t, os.FileMode(0600), info.Mode().Perm())
+	data, err = os.ReadFile(envFile)
+	assert.NoError(t, err)
+	assert.Equal(t, "CONDUCTOR_SERVER_URL=http://localhost\nCONDUCTOR_AUTH_KEY=new\nCONDUCTOR_AUTH_SECRET=new-secret\n", string(data))
+
+	// the worker client switched to the new key without being recreated
+	_, _, err = metadataClient.GetAll(context.Background())
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"token-old", "token-new"}, fake.tokens)
+}
+
+func TestRotateUnverifiedKey(t *testing.T) {
+	fake := newFakeServer()
+	fake.failNewKeys = true
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	httpSettings := settings.NewHttpSettings(server.URL)
+	published := false
+	rotator := NewRotator(client.NewApplicationClient(client.NewAPIClient(nil, httpSettings)), httpSettings,
+		Callback(func(ctx context.Context, key Key) error {
+			published = true
+			return nil
+		}))
+
+	result, err := rotator.RotateAll(context.Background(), "app")
+	assert.ErrorContains(t, err, "the new access key new of application app failed to authenticate")
+	assert.Equal(t, "", result.NewKeyId)
+	assert.False(t, published)
+	assert.Equal(t, []string{"POST /applications/app/accessKeys", "DELETE /applications/app/accessKeys/new"}, fake.calls)
+	assert.Equal(t, "ACTIVE", fake.status["old"])
+}
+
+func TestRotatePublishFailure(t *testing.T) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	httpSettings := settings.NewHttpSettings(server.URL)
+	rotator := NewRotator(client.NewApplicationClient(client.NewAPIClient(nil, httpSettings)), httpSettings,
+		Callback(func(ctx context.Context, key Key) error {
+			return errors.New("vault unavailable")
+		}))
+
+	result, err := rotator.Rotate(context.Background(), "app", "old")
+	assert.EqualError(t, err, "failed to publish the new access key new of application app: vault unavailable")
+	assert.Equal(t, "new", result.NewKeyId)
+	assert.Equal(t, "ACTIVE", fake.status["old"])
+
+	_, err = rotator.Rotate(context.Background(), "app", "missing")
+	assert.EqualError(t, err, "access key missing not found in application app")
+
+	calls := len(fake.calls)
+	_, err = rotator.Rotate(context.Background(), "app", "")
+	assert.EqualError(t, err, "the id of the access key to rotate is required")
+	assert.Len(t, fake.calls, calls)
+}
+
+func TestRotateDeletionDelay(t *testing.T) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	httpSettings := settings.NewHttpSettings(server.URL)
+	applicationClient := client.NewApplicationClient(client.NewAPIClient(nil, httpSettings))
+	rotator := NewRotator(applicationClient, httpSettings).WithGracePeriod(0).WithDeletionDelay(500 * time.Millisecond)
+	status := func(id string) string {
+		fake.mutex.Lock()
+		defer fake.mutex.Unlock()
+		return fake.status[id]
+	}
+
+	// the old key is activated again before it is deleted
+	results := make(chan *Result, 1)
+	go func() {
+		result, err := rotator.Rotate(context.Background(), "app", "old")
+		assert.NoError(t, err)
+		results <- result
+	}()
+	assert.Eventually(t, func() bool { return status("old") == "INACTIVE" }, time.Second, 5*time.Millisecond)
+	_, _, err := applicationClient.ToggleAccessKeyStatus(context.Background(), "app", "old")
+	assert.NoError(t, err)
+	assert.Equal(t, &Result{ApplicationId: "app", NewKeyId: "new", DeactivatedKeyIds: []string{"old"}, RetiredKeyIds: []string{}}, <-results)
+	assert.Equal(t, "ACTIVE", status("old"))
+
+	// cancelled during the deletion delay
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	result, err := rotator.Rotate(ctx, "app", "old")
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+	assert.Equal(t, []string{"old"}, result.DeactivatedKeyIds)
+	assert.Empty(t, result.RetiredKeyIds)
+	assert.Equal(t, "INACTIVE", status("old"))
+}
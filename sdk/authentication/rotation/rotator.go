@@ -0,0 +1,205 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package rotation
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/authentication"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultGracePeriod   = 10 * time.Minute
+	defaultDeletionDelay = 10 * time.Minute
+	activeStatus         = "ACTIVE"
+)
+
+// Result the outcome of a rotation.  NewKeyId is set once the new key is created, DeactivatedKeyIds lists the old keys
+// deactivated and RetiredKeyIds the ones deleted after the deletion delay
+type Result struct {
+	ApplicationId     string   `json:"applicationId"`
+	NewKeyId          string   `json:"newKeyId"`
+	DeactivatedKeyIds []string `json:"deactivatedKeyIds"`
+	RetiredKeyIds     []string `json:"retiredKeyIds"`
+}
+
+// Rotator replaces the access key of an application: it creates a new key, verifies the new key authenticates,
+// publishes it to the sinks, waits for the grace period so that the workers pick it up, then deactivates the old key
+// and deletes it after the deletion delay.  A worker still using the old key fails once it is deactivated, the key
+// can be activated again until it is deleted
+type Rotator struct {
+	applicationClient client.ApplicationClient
+	httpSettings      *settings.HttpSettings
+	httpClient        *http.Client
+	sinks             []Sink
+	gracePeriod       time.Duration
+	deletionDelay     time.Duration
+}
+
+// NewRotator the rotator of the keys, the new keys are verified against the server of the http settings
+func NewRotator(applicationClient client.ApplicationClient, httpSettings *settings.HttpSettings, sinks ...Sink) *Rotator {
+	return &Rotator{
+		applicationClient: applicationClient,
+		httpSettings:      httpSettings,
+		httpClient:        &http.Client{Timeout: 30 * time.Second},
+		sinks:             sinks,
+		gracePeriod:       defaultGracePeriod,
+		deletionDelay:     defaultDeletionDelay,
+	}
+}
+
+// WithGracePeriod the time the old key remains active after the new key is published, 10 minutes by default
+func (rotator *Rotator) WithGracePeriod(gracePeriod time.Duration) *Rotator {
+	rotator.gracePeriod = gracePeriod
+	return rotator
+}
+
+// WithDeletionDelay the time the old key remains deactivated before it is deleted, 10 minutes by default.  The keys
+// activated again in the meantime are not deleted
+func (rotator *Rotator) WithDeletionDelay(deletionDelay time.Duration) *Rotator {
+	rotator.deletionDelay = deletionDelay
+	return rotator
+}
+
+// WithHttpClient the client used to verify the new keys
+func (rotator *Rotator) WithHttpClient(httpClient *http.Client) *Rotator {
+	rotator.httpClient = httpClient
+	return rotator
+}
+
+// Rotate replaces the old key of the application with a new one, see RotateAll to retire all the existing keys.
+// A new key that fails the verification is deleted and the old keys are left untouched.  When publishing fails or the
+// context is cancelled during the grace period both keys are left active, the result has the id of the new key.  When
+// the context is cancelled during the deletion delay the old keys are left deactivated
+func (rotator *Rotator) Rotate(ctx context.Context, applicationId string, oldKeyId string) (*Result, error) {
+	if oldKeyId == "" {
+		return newResult(applicationId), errors.New("the id of the access key to rotate is required")
+	}
+	return rotator.rotate(ctx, applicationId, oldKeyId)
+}
+
+// RotateAll replaces all the existing keys of the application with a new one, e.g. when the keys were leaked.
+// See Rotate for the outcome of the failures
+func (rotator *Rotator) RotateAll(ctx context.Context, applicationId string) (*Result, error) {
+	return rotator.rotate(ctx, applicationId, "")
+}
+
+func newResult(applicationId string) *Result {
+	return &Result{ApplicationId: applicationId, DeactivatedKeyIds: []string{}, RetiredKeyIds: []string{}}
+}
+
+// rotate retires the old key, or all the existing keys when oldKeyId is empty
+func (rotator *Rotator) rotate(ctx context.Context, applicationId string, oldKeyId string) (*Result, error) {
+	result := newResult(applicationId)
+	existingKeys, _, err := rotator.applicationClient.GetAccessKeys(ctx, applicationId)
+	if err != nil {
+		return result, fmt.Errorf("failed to get the access keys of application %s: %w", applicationId, err)
+	}
+	retired := map[string]bool{}
+	for _, key := range existingKeys {
+		if oldKeyId == "" || key.Id == oldKeyId {
+			retired[key.Id] = key.Status == activeStatus
+		}
+	}
+	if oldKeyId != "" && len(retired) == 0 {
+		return result, fmt.Errorf("access key %s not found in application %s", oldKeyId, applicationId)
+	}
+
+	accessKey, _, err := rotator.applicationClient.CreateAccessKeyWithSecret(ctx, applicationId)
+	if err != nil {
+		return result, fmt.Errorf("failed to create an access key for application %s: %w", applicationId, err)
+	}
+	key := Key{ApplicationId: applicationId, Id: accessKey.Id, Secret: accessKey.Secret}
+	result.NewKeyId = key.Id
+	log.Info("Created ", key)
+
+	if err := rotator.verify(key); err != nil {
+		if _, deleteErr := rotator.applicationClient.DeleteAccessKey(ctx, applicationId, key.Id); deleteErr != nil {
+			log.Warning("Failed to delete the unverified ", key, ", error: ", deleteErr)
+		}
+		result.NewKeyId = ""
+		return result, fmt.Errorf("the new %s failed to authenticate: %w", key, err)
+	}
+	for _, sink := range rotator.sinks {
+		if err := sink.Publish(ctx, key); err != nil {
+			return result, fmt.Errorf("failed to publish the new %s: %w", key, err)
+		}
+	}
+	log.Info("Published ", key, ", retiring the old keys in ", rotator.gracePeriod)
+
+	if err := internal.Sleep(ctx, rotator.gracePeriod); err != nil {
+		return result, err
+	}
+	for _, id := range sortedIds(retired) {
+		if retired[id] {
+			if _, _, err := rotator.applicationClient.ToggleAccessKeyStatus(ctx, applicationId, id); err != nil {
+				return result, fmt.Errorf("failed to deactivate access key %s: %w", id, err)
+			}
+		}
+		result.DeactivatedKeyIds = append(result.DeactivatedKeyIds, id)
+	}
+	log.Info("Deactivated the access keys ", result.DeactivatedKeyIds, " of application ", applicationId,
+		", deleting them in ", rotator.deletionDelay)
+
+	if err := internal.Sleep(ctx, rotator.deletionDelay); err != nil {
+		return result, err
+	}
+	// the keys activated again during the deletion delay are kept
+	currentKeys, _, err := rotator.applicationClient.GetAccessKeys(ctx, applicationId)
+	if err != nil {
+		return result, fmt.Errorf("failed to get the access keys of application %s: %w", applicationId, err)
+	}
+	active := map[string]bool{}
+	for _, key := range currentKeys {
+		active[key.Id] = key.Status == activeStatus
+	}
+	for _, id := range result.DeactivatedKeyIds {
+		if active[id] {
+			log.Warning("Access key ", id, " of application ", applicationId, " was activated again, it is not deleted")
+			continue
+		}
+		if _, err := rotator.applicationClient.DeleteAccessKey(ctx, applicationId, id); err != nil {
+			return result, fmt.Errorf("failed to delete access key %s: %w", id, err)
+		}
+		result.RetiredKeyIds = append(result.RetiredKeyIds, id)
+		log.Info("Retired access key ", id, " of application ", applicationId)
+	}
+	return result, nil
+}
+
+// verify the key authenticates against the server
+func (rotator *Rotator) verify(key Key) error {
+	token, _, err := authentication.GetToken(*settings.NewAuthenticationSettings(key.Id, key.Secret), rotator.httpSettings, rotator.httpClient)
+	if err != nil {
+		return err
+	}
+	if token.Token == "" {
+		return errors.New("the server returned an empty token")
+	}
+	return nil
+}
+
+func sortedIds(ids map[string]bool) []string {
+	result := make([]string, 0, len(ids))
+	for id := range ids {
+		result = append(result, id)
+	}
+	sort.Strings(result)
+	return result
+}
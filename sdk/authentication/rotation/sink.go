@@ -0,0 +1,125 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package rotation
+
+import (
+	"context"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+// Key the access key of an application
+type Key struct {
+	ApplicationId string
+	Id            string
+	Secret        string
+}
+
+// String the key without its secret, safe to log
+func (key Key) String() string {
+	return "access key " + key.Id + " of application " + key.ApplicationId
+}
+
+// Sink publishes the new key to where the workers read their credentials
+type Sink interface {
+	Publish(ctx context.Context, key Key) error
+}
+
+// SinkFunc adapts a function to a Sink
+type SinkFunc func(ctx context.Context, key Key) error
+
+func (f SinkFunc) Publish(ctx context.Context, key Key) error {
+	return f(ctx, key)
+}
+
+// Callback publishes the key by calling the function, e.g. to store it in a secret manager
+func Callback(publish func(ctx context.Context, key Key) error) Sink {
+	return SinkFunc(publish)
+}
+
+// FileSink writes the key as JSON, {"keyId": "...", "keySecret": "..."}, readable only by the owner.
+// The file is replaced atomically so the readers never see a partial key
+func FileSink(path string) Sink {
+	return SinkFunc(func(ctx context.Context, key Key) error {
+		data, err := json.MarshalIndent(map[string]string{"keyId": key.Id, "keySecret": key.Secret}, "", "  ")
+		if err != nil {
+			return err
+		}
+		return writeFileAtomically(path, append(data, '\n'))
+	})
+}
+
+// EnvFileSink sets CONDUCTOR_AUTH_KEY and CONDUCTOR_AUTH_SECRET in the env file, keeping the other lines of the file
+func EnvFileSink(path string) Sink {
+	return EnvFileSinkWithVariables(path, client.CONDUCTOR_AUTH_KEY, client.CONDUCTOR_AUTH_SECRET)
+}
+
+// EnvFileSinkWithVariables sets the variables of the key id and the key secret in the env file, keeping the other
+// lines of the file
+func EnvFileSinkWithVariables(path string, keyIdVariable string, keySecretVariable string) Sink {
+	return SinkFunc(func(ctx context.Context, key Key) error {
+		content, err := os.ReadFile(path)
+		if err != nil && !os.IsNotExist(err) {
+			return err
+		}
+		values := map[string]string{keyIdVariable: key.Id, keySecretVariable: key.Secret}
+		var lines []string
+		if len(content) > 0 {
+			lines = strings.Split(strings.TrimRight(string(content), "\n"), "\n")
+		}
+		for i, line := range lines {
+			name := strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(line), "export "))
+			name = strings.TrimSpace(strings.SplitN(name, "=", 2)[0])
+			if value, found := values[name]; found && strings.Contains(line, "=") {
+				lines[i] = name + "=" + value
+				delete(values, name)
+			}
+		}
+		for _, name := range []string{keyIdVariable, keySecretVariable} {
+			if value, found := values[name]; found {
+				lines = append(lines, name+"="+value)
+			}
+		}
+		return writeFileAtomically(path, []byte(strings.Join(lines, "\n")+"\n"))
+	})
+}
+
+// ClientSink switches the client to the new key, the requests of the client and of the task runners using it
+// authenticate with the new key from then on
+func ClientSink(apiClient *client.APIClient) Sink {
+	return SinkFunc(func(ctx context.Context, key Key) error {
+		return apiClient.UpdateCredentials(settings.NewAuthenticationSettings(key.Id, key.Secret))
+	})
+}
+
+func writeFileAtomically(path string, data []byte) error {
+	file, err := os.CreateTemp(filepath.Dir(path), "."+filepath.Base(path)+".*")
+	if err != nil {
+		return err
+	}
+	defer os.Remove(file.Name())
+	if err := file.Chmod(0600); err != nil {
+		file.Close()
+		return err
+	}
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		return err
+	}
+	if err := file.Close(); err != nil {
+		return err
+	}
+	return os.Rename(file.Name(), path)
+}
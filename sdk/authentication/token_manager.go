@@ -26,6 +26,12 @@ type TokenManager interface {
 	RefreshToken(httpSettings *settings.HttpSettings, httpClient *http.Client) (string, error)
 }
 
+// CredentialsUpdater is implemented by the token managers whose credentials can be replaced while in use,
+// e.g. after rotating the access key of the application
+type CredentialsUpdater interface {
+	UpdateCredentials(credentials settings.AuthenticationSettings)
+}
+
 type CachedTokenManager struct {
 	mutex       sync.RWMutex
 	credentials settings.AuthenticationSettings
@@ -53,6 +59,16 @@ func (t *CachedTokenManager) RefreshToken(httpSettings *settings.HttpSettings, h
 	return t.refreshToken(httpSettings, httpClient)
 }
 
+// UpdateCredentials replaces the credentials and drops the cached token, the next request authenticates with the new
+// credentials.  Safe to call while the clients using the token manager are running, e.g. the TaskRunner
+func (t *CachedTokenManager) UpdateCredentials(credentials settings.AuthenticationSettings) {
+	t.mutex.Lock()
+	defer t.mutex.Unlock()
+	t.credentials = credentials
+	t.database.Delete(tokenKey)
+	log.Debug("Updated the authentication credentials")
+}
+
 func (t *CachedTokenManager) getTokenIfCached() string {
 	t.mutex.RLock()
 	defer t.mutex.RUnlock()
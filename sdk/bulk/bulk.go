@@ -0,0 +1,161 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package bulk
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+const (
+	defaultChunkSize   = 100
+	defaultConcurrency = 4
+)
+
+// Options of Run.  ChunkSize is the number of ids sent in each request, Concurrency the number of requests in flight.
+// With DryRun the ids are collected without applying the operation.  Progress is called after each chunk, one call at a time
+type Options struct {
+	ChunkSize   int
+	Concurrency int
+	DryRun      bool
+	Progress    func(progress Progress)
+}
+
+// Progress of the operation, the number of ids processed so far
+type Progress struct {
+	Processed int
+	Succeeded int
+	Failed    int
+}
+
+// Result aggregates the responses of all the chunks.  Failed maps the workflow id to the error, including the ids of
+// the chunks whose request failed.  With DryRun, Matched lists the ids that would be processed
+type Result struct {
+	Succeeded []string
+	Failed    map[string]string
+	Matched   []string
+	DryRun    bool
+}
+
+// Run reads the ids from the source, deduplicates them and applies the operation in chunks with bounded concurrency.
+// Returns an error if the source fails or the context is cancelled, along with the result of the chunks processed so far
+//
+//	result, err := bulk.Run(ctx,
+//		bulk.Search(workflowClient, "workflowType IN (order) AND status IN (RUNNING) AND startTime < 1700000000000", ""),
+//		bulk.Terminate(bulkClient, "cleanup", false),
+//		bulk.Options{DryRun: true})
+func Run(ctx context.Context, source IdSource, operation Operation, options Options) (*Result, error) {
+	if options.ChunkSize <= 0 {
+		options.ChunkSize = defaultChunkSize
+	}
+	if options.Concurrency <= 0 {
+		options.Concurrency = defaultConcurrency
+	}
+	runner := &runner{
+		operation: operation,
+		options:   options,
+		result:    &Result{Succeeded: []string{}, Failed: map[string]string{}, DryRun: options.DryRun},
+	}
+	chunks := make(chan []string)
+	var workers sync.WaitGroup
+	if !options.DryRun {
+		for i := 0; i < options.Concurrency; i++ {
+			workers.Add(1)
+			go func() {
+				defer workers.Done()
+				for chunk := range chunks {
+					runner.process(ctx, chunk)
+				}
+			}()
+		}
+	}
+	err := runner.read(ctx, source, chunks)
+	close(chunks)
+	workers.Wait()
+	sort.Strings(runner.result.Succeeded)
+	return runner.result, err
+}
+
+type runner struct {
+	operation Operation
+	options   Options
+	mutex     sync.Mutex
+	progress  Progress
+	result    *Result
+}
+
+// read sends the chunks of unique ids to the workers, or collects them with DryRun
+func (r *runner) read(ctx context.Context, source IdSource, chunks chan<- []string) error {
+	seen := make(map[string]bool)
+	chunk := make([]string, 0, r.options.ChunkSize)
+	send := func() error {
+		if len(chunk) == 0 {
+			return nil
+		}
+		if r.options.DryRun {
+			r.result.Matched = append(r.result.Matched, chunk...)
+		} else {
+			select {
+			case chunks <- chunk:
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+		chunk = make([]string, 0, r.options.ChunkSize)
+		return nil
+	}
+	for {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		id, ok, err := source.Next(ctx)
+		if err != nil {
+			return err
+		}
+		if !ok {
+			return send()
+		}
+		if id == "" || seen[id] {
+			continue
+		}
+		seen[id] = true
+		chunk = append(chunk, id)
+		if len(chunk) == r.options.ChunkSize {
+			if err := send(); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+func (r *runner) process(ctx context.Context, chunk []string) {
+	response, err := r.operation(ctx, chunk)
+	if err != nil {
+		response = model.BulkResponse{BulkErrorResults: make(map[string]string, len(chunk))}
+		for _, id := range chunk {
+			response.BulkErrorResults[id] = err.Error()
+		}
+	}
+	r.mutex.Lock()
+	defer r.mutex.Unlock()
+	r.result.Succeeded = append(r.result.Succeeded, response.BulkSuccessfulResults...)
+	for id, message := range response.BulkErrorResults {
+		r.result.Failed[id] = message
+	}
+	r.progress.Processed += len(chunk)
+	r.progress.Succeeded += len(response.BulkSuccessfulResults)
+	r.progress.Failed += len(response.BulkErrorResults)
+	if r.options.Progress != nil {
+		r.options.Progress(r.progress)
+	}
+}
@@ -0,0 +1,134 @@
+package bulk
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strconv"
+	"sync"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func workflowIds(n int) []string {
+	ids := make([]string, n)
+	for i := range ids {
+		ids[i] = "wf_" + strconv.Itoa(i)
+	}
+	return ids
+}
+
+func TestRunChunksAndAggregates(t *testing.T) {
+	var mutex sync.Mutex
+	chunkSizes := make([]int, 0)
+	operation := func(ctx context.Context, ids []string) (model.BulkResponse, error) {
+		mutex.Lock()
+		chunkSizes = append(chunkSizes, len(ids))
+		mutex.Unlock()
+		if ids[0] == "wf_20" {
+			return model.BulkResponse{}, errors.New("unavailable")
+		}
+		response := model.BulkResponse{BulkErrorResults: map[string]string{}}
+		for _, id := range ids {
+			if id == "wf_3" {
+				response.BulkErrorResults[id] = "not running"
+			} else {
+				response.BulkSuccessfulResults = append(response.BulkSuccessfulResults, id)
+			}
+		}
+		return response, nil
+	}
+	progress := make([]Progress, 0)
+	ids := append(workflowIds(25), "wf_1", "")
+
+	result, err := Run(context.Background(), Ids(ids...), operation, Options{
+		ChunkSize:   10,
+		Concurrency: 2,
+		Progress:    func(p Progress) { progress = append(progress, p) },
+	})
+	assert.NoError(t, err)
+	sort.Ints(chunkSizes)
+	assert.Equal(t, []int{5, 10, 10}, chunkSizes)
+	assert.Len(t, result.Succeeded, 19)
+	assert.Len(t, result.Failed, 6)
+	assert.Equal(t, "not running", result.Failed["wf_3"])
+	assert.Equal(t, "unavailable", result.Failed["wf_24"])
+	assert.Len(t, progress, 3)
+	assert.Equal(t, Progress{Processed: 25, Succeeded: 19, Failed: 6}, progress[2])
+}
+
+func TestRunDryRun(t *testing.T) {
+	operation := func(ctx context.Context, ids []string) (model.BulkResponse, error) {
+		t.Fatal("dry run must not apply the operation")
+		return model.BulkResponse{}, nil
+	}
+	result, err := Run(context.Background(), Ids(workflowIds(3)...), operation, Options{DryRun: true, ChunkSize: 2})
+	assert.NoError(t, err)
+	assert.True(t, result.DryRun)
+	assert.Equal(t, workflowIds(3), result.Matched)
+	assert.Empty(t, result.Succeeded)
+}
+
+func TestRunSourceError(t *testing.T) {
+	calls := 0
+	source := IdFunc(func(ctx context.Context) (string, bool, error) {
+		calls++
+		if calls > 3 {
+			return "", false, errors.New("read failed")
+		}
+		return strconv.Itoa(calls), true, nil
+	})
+	operation := func(ctx context.Context, ids []string) (model.BulkResponse, error) {
+		return model.BulkResponse{BulkSuccessfulResults: ids}, nil
+	}
+	result, err := Run(context.Background(), source, operation, Options{ChunkSize: 2})
+	assert.EqualError(t, err, "read failed")
+	assert.Equal(t, []string{"1", "2"}, result.Succeeded)
+}
+
+func TestSearchAndTerminate(t *testing.T) {
+	running := workflowIds(1500)
+	terminated := make([]string, 0)
+	var searches []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/workflow/search":
+			searches = append(searches, r.URL.RawQuery)
+			start, _ := strconv.Atoi(r.URL.Query().Get("start"))
+			size, _ := strconv.Atoi(r.URL.Query().Get("size"))
+			end := start + size
+			if end > len(running) {
+				end = len(running)
+			}
+			summaries := make([]model.WorkflowSummary, 0)
+			for _, id := range running[start:end] {
+				summaries = append(summaries, model.WorkflowSummary{WorkflowId: id})
+			}
+			json.NewEncoder(w).Encode(model.SearchResultWorkflowSummary{TotalHits: int64(len(running)), Results: summaries})
+		case "/workflow/bulk/terminate":
+			assert.Equal(t, "cleanup", r.URL.Query().Get("reason"))
+			var ids []string
+			json.NewDecoder(r.Body).Decode(&ids)
+			terminated = append(terminated, ids...)
+			json.NewEncoder(w).Encode(model.BulkResponse{BulkSuccessfulResults: ids})
+		}
+	}))
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+
+	source := Search(client.NewWorkflowClient(apiClient), "workflowType IN (order) AND status IN (RUNNING)", "")
+	result, err := Run(context.Background(), source, Terminate(client.NewWorkflowBulkClient(apiClient), "cleanup", false), Options{ChunkSize: 500, Concurrency: 1})
+	assert.NoError(t, err)
+	assert.Len(t, searches, 2)
+	assert.Contains(t, searches[0], "query=workflowType+IN+%28order%29+AND+status+IN+%28RUNNING%29")
+	assert.Len(t, result.Succeeded, 1500)
+	assert.Equal(t, running, terminated)
+}
@@ -0,0 +1,62 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package bulk
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// Operation applied to a chunk of workflow ids
+type Operation func(ctx context.Context, workflowIds []string) (model.BulkResponse, error)
+
+func Pause(bulkClient client.WorkflowBulkClient) Operation {
+	return func(ctx context.Context, workflowIds []string) (model.BulkResponse, error) {
+		response, _, err := bulkClient.PauseWorkflow1(ctx, workflowIds)
+		return response, err
+	}
+}
+
+func Resume(bulkClient client.WorkflowBulkClient) Operation {
+	return func(ctx context.Context, workflowIds []string) (model.BulkResponse, error) {
+		response, _, err := bulkClient.ResumeWorkflow(ctx, workflowIds)
+		return response, err
+	}
+}
+
+func Retry(bulkClient client.WorkflowBulkClient) Operation {
+	return func(ctx context.Context, workflowIds []string) (model.BulkResponse, error) {
+		response, _, err := bulkClient.Retry1(ctx, workflowIds)
+		return response, err
+	}
+}
+
+func Restart(bulkClient client.WorkflowBulkClient, useLatestDefinitions bool) Operation {
+	return func(ctx context.Context, workflowIds []string) (model.BulkResponse, error) {
+		response, _, err := bulkClient.Restart(ctx, workflowIds, &client.WorkflowBulkResourceApiRestart1Opts{
+			UseLatestDefinitions: optional.NewBool(useLatestDefinitions),
+		})
+		return response, err
+	}
+}
+
+func Terminate(bulkClient client.WorkflowBulkClient, reason string, triggerFailureWorkflow bool) Operation {
+	return func(ctx context.Context, workflowIds []string) (model.BulkResponse, error) {
+		opts := &client.WorkflowBulkResourceApiTerminateOpts{TriggerFailureWorkflow: optional.NewBool(triggerFailureWorkflow)}
+		if reason != "" {
+			opts.Reason = optional.NewString(reason)
+		}
+		response, _, err := bulkClient.Terminate(ctx, workflowIds, opts)
+		return response, err
+	}
+}
@@ -0,0 +1,99 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package bulk
+
+import (
+	"context"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+)
+
+const defaultSearchPageSize = 1000
+
+// IdSource iterates over the ids of the workflows to process.  Next returns false once there are no more ids
+type IdSource interface {
+	Next(ctx context.Context) (id string, ok bool, err error)
+}
+
+type idSlice struct {
+	ids   []string
+	index int
+}
+
+// Ids source of the given workflow ids
+func Ids(ids ...string) IdSource {
+	return &idSlice{ids: ids}
+}
+
+func (s *idSlice) Next(ctx context.Context) (string, bool, error) {
+	if s.index >= len(s.ids) {
+		return "", false, nil
+	}
+	s.index++
+	return s.ids[s.index-1], true, nil
+}
+
+// IdFunc adapts a function to the IdSource interface, e.g. to read the ids from a file
+type IdFunc func(ctx context.Context) (id string, ok bool, err error)
+
+func (f IdFunc) Next(ctx context.Context) (string, bool, error) {
+	return f(ctx)
+}
+
+type searchSource struct {
+	workflowClient client.WorkflowClient
+	query          string
+	freeText       string
+	ids            IdSource
+}
+
+// Search source of the workflows matching the query, e.g. workflowType IN (order) AND status IN (RUNNING).
+// All the matching ids are fetched before the first one is returned: paging through the results while the operation
+// changes them (e.g. terminating the RUNNING workflows) would skip workflows
+func Search(workflowClient client.WorkflowClient, query string, freeText string) IdSource {
+	if freeText == "" {
+		freeText = "*"
+	}
+	return &searchSource{workflowClient: workflowClient, query: query, freeText: freeText}
+}
+
+func (s *searchSource) Next(ctx context.Context) (string, bool, error) {
+	if s.ids == nil {
+		ids, err := s.search(ctx)
+		if err != nil {
+			return "", false, err
+		}
+		s.ids = Ids(ids...)
+	}
+	return s.ids.Next(ctx)
+}
+
+func (s *searchSource) search(ctx context.Context) ([]string, error) {
+	ids := make([]string, 0)
+	for start := int32(0); ; {
+		result, _, err := s.workflowClient.Search(ctx, &client.WorkflowResourceApiSearchOpts{
+			Start:    optional.NewInt32(start),
+			Size:     optional.NewInt32(defaultSearchPageSize),
+			FreeText: optional.NewString(s.freeText),
+			Query:    optional.NewString(s.query),
+		})
+		if err != nil {
+			return nil, err
+		}
+		for _, summary := range result.Results {
+			ids = append(ids, summary.WorkflowId)
+		}
+		start += int32(len(result.Results))
+		if len(result.Results) < defaultSearchPageSize || (result.TotalHits > 0 && int64(start) >= result.TotalHits) {
+			return ids, nil
+		}
+	}
+}
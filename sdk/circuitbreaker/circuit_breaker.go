@@ -0,0 +1,367 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package circuitbreaker
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// State the state of a circuit breaker, the same names the server uses
+type State string
+
+const (
+	Closed   State = "CLOSED"
+	Open     State = "OPEN"
+	HalfOpen State = "HALF_OPEN"
+	// ForcedOpen rejects every call until the circuit breaker is closed
+	ForcedOpen State = "FORCED_OPEN"
+	// Disabled permits every call and records none
+	Disabled State = "DISABLED"
+)
+
+// The defaults of the server for the properties of the configuration that are not set
+const (
+	defaultFailureRateThreshold                  = 50
+	defaultSlowCallRateThreshold                 = 100
+	defaultSlowCallDurationThreshold             = time.Minute
+	defaultMinimumNumberOfCalls                  = 100
+	defaultSlidingWindowSize                     = 100
+	defaultPermittedNumberOfCallsInHalfOpenState = 10
+	defaultWaitDurationInOpenState               = time.Minute
+)
+
+// ErrCallNotPermitted the circuit breaker is open, or half open with all the trial calls in progress
+var ErrCallNotPermitted = errors.New("call not permitted")
+
+type outcome struct {
+	failed bool
+	slow   bool
+}
+
+// Permit the permission of a call given by Acquire.  The outcomes of the calls permitted before the last transition
+// are discarded, they were permitted in another state
+type Permit struct {
+	circuitBreaker *CircuitBreaker
+	generation     int
+	recorded       bool
+}
+
+// CircuitBreaker a count based circuit breaker with the semantics of the circuit breakers of the service registry.
+// While closed the outcomes of the last SlidingWindowSize calls are recorded, once MinimumNumberOfCalls are recorded
+// the circuit breaker opens when the failure rate or the rate of the calls slower than SlowCallDurationThreshold reach
+// their threshold.  Open, the calls are rejected with ErrCallNotPermitted for WaitDurationInOpenState, then
+// PermittedNumberOfCallsInHalfOpenState trial calls are permitted: it closes when their rates are below the thresholds
+// and opens again otherwise, or when they take longer than MaxWaitDurationInHalfOpenState.
+// The durations of the configuration are in milliseconds
+type CircuitBreaker struct {
+	service                  string
+	failureRateThreshold     float64
+	slowCallRateThreshold    float64
+	slowCallDuration         time.Duration
+	minimumNumberOfCalls     int
+	slidingWindowSize        int
+	permittedInHalfOpenState int
+	waitInOpenState          time.Duration
+	maxWaitInHalfOpenState   time.Duration
+	automaticHalfOpen        bool
+	isFailure                func(err error) bool
+	listeners                []func(transition model.CircuitBreakerTransitionResponse)
+	now                      func() time.Time
+
+	mutex    sync.Mutex
+	state    State
+	since    time.Time
+	outcomes []outcome
+	next     int
+	recorded int
+	// the trial calls permitted since the circuit breaker is half open
+	permitted int
+	// incremented by each transition, the generation of the permits
+	generation int
+	transition model.CircuitBreakerTransitionResponse
+	// the timestamp of the last transition of the server mirrored, see Refresh
+	serverTransition int64
+}
+
+// NewCircuitBreaker the circuit breaker of the service, closed.  The properties of the configuration that are not set
+// take the defaults of the server
+func NewCircuitBreaker(service string, config model.OrkesCircuitBreakerConfig) *CircuitBreaker {
+	circuitBreaker := &CircuitBreaker{
+		service:                  service,
+		failureRateThreshold:     float64(config.FailureRateThreshold),
+		slowCallRateThreshold:    float64(config.SlowCallRateThreshold),
+		slowCallDuration:         time.Duration(config.SlowCallDurationThreshold) * time.Millisecond,
+		minimumNumberOfCalls:     int(config.MinimumNumberOfCalls),
+		slidingWindowSize:        int(config.SlidingWindowSize),
+		permittedInHalfOpenState: int(config.PermittedNumberOfCallsInHalfOpenState),
+		waitInOpenState:          time.Duration(config.WaitDurationInOpenState) * time.Millisecond,
+		maxWaitInHalfOpenState:   time.Duration(config.MaxWaitDurationInHalfOpenState) * time.Millisecond,
+		automaticHalfOpen:        config.AutomaticTransitionFromOpenToHalfOpenEnabled,
+		isFailure:                func(err error) bool { return err != nil },
+		now:                      time.Now,
+		state:                    Closed,
+	}
+	if circuitBreaker.failureRateThreshold <= 0 {
+		circuitBreaker.failureRateThreshold = defaultFailureRateThreshold
+	}
+	if circuitBreaker.slowCallRateThreshold <= 0 {
+		circuitBreaker.slowCallRateThreshold = defaultSlowCallRateThreshold
+	}
+	if circuitBreaker.slowCallDuration <= 0 {
+		circuitBreaker.slowCallDuration = defaultSlowCallDurationThreshold
+	}
+	if circuitBreaker.slidingWindowSize <= 0 {
+		circuitBreaker.slidingWindowSize = defaultSlidingWindowSize
+	}
+	if circuitBreaker.minimumNumberOfCalls <= 0 {
+		circuitBreaker.minimumNumberOfCalls = defaultMinimumNumberOfCalls
+	}
+	// the rates are computed once the window is full when it is smaller than the minimum number of calls
+	if circuitBreaker.minimumNumberOfCalls > circuitBreaker.slidingWindowSize {
+		circuitBreaker.minimumNumberOfCalls = circuitBreaker.slidingWindowSize
+	}
+	if circuitBreaker.permittedInHalfOpenState <= 0 {
+		circuitBreaker.permittedInHalfOpenState = defaultPermittedNumberOfCallsInHalfOpenState
+	}
+	if circuitBreaker.waitInOpenState <= 0 {
+		circuitBreaker.waitInOpenState = defaultWaitDurationInOpenState
+	}
+	circuitBreaker.since = circuitBreaker.now()
+	circuitBreaker.reset(circuitBreaker.slidingWindowSize)
+	return circuitBreaker
+}
+
+// WithFailurePredicate which errors are failures, every error by default.  The errors that are not failures are
+// recorded as successful calls, e.g. the validation errors of the caller
+func (circuitBreaker *CircuitBreaker) WithFailurePredicate(isFailure func(err error) bool) *CircuitBreaker {
+	circuitBreaker.isFailure = isFailure
+	return circuitBreaker
+}
+
+// OnTransition calls the listener after each transition, outside of the lock of the circuit breaker
+func (circuitBreaker *CircuitBreaker) OnTransition(listener func(transition model.CircuitBreakerTransitionResponse)) *CircuitBreaker {
+	circuitBreaker.listeners = append(circuitBreaker.listeners, listener)
+	return circuitBreaker
+}
+
+// Service the name of the service of the circuit breaker
+func (circuitBreaker *CircuitBreaker) Service() string {
+	return circuitBreaker.service
+}
+
+// State the current state, an open circuit breaker is half open once the wait duration elapsed when the automatic
+// transition is enabled, and on the next call otherwise
+func (circuitBreaker *CircuitBreaker) State() State {
+	circuitBreaker.mutex.Lock()
+	transitions := circuitBreaker.expire(circuitBreaker.automaticHalfOpen)
+	state := circuitBreaker.state
+	circuitBreaker.mutex.Unlock()
+	circuitBreaker.notify(transitions)
+	return state
+}
+
+// Status the last transition, like GetCircuitBreakerStatus returns for the circuit breaker of the server
+func (circuitBreaker *CircuitBreaker) Status() model.CircuitBreakerTransitionResponse {
+	circuitBreaker.State()
+	circuitBreaker.mutex.Lock()
+	defer circuitBreaker.mutex.Unlock()
+	status := circuitBreaker.transition
+	if status.CurrentState == "" {
+		status = model.CircuitBreakerTransitionResponse{
+			Service:             circuitBreaker.service,
+			CurrentState:        string(circuitBreaker.state),
+			TransitionTimestamp: circuitBreaker.since.UnixMilli(),
+		}
+	}
+	return status
+}
+
+// Execute calls fn when the circuit breaker permits it and records its outcome, the error of fn is returned as is.
+// ErrCallNotPermitted is returned without calling fn otherwise.  A panic of fn is recorded as a failure
+func (circuitBreaker *CircuitBreaker) Execute(ctx context.Context, fn func(ctx context.Context) error) (err error) {
+	permit, err := circuitBreaker.Acquire()
+	if err != nil {
+		return err
+	}
+	start := circuitBreaker.now()
+	defer func() {
+		if recovered := recover(); recovered != nil {
+			permit.Record(circuitBreaker.now().Sub(start), fmt.Errorf("panic: %v", recovered))
+			panic(recovered)
+		}
+		permit.Record(circuitBreaker.now().Sub(start), err)
+	}()
+	return fn(ctx)
+}
+
+// Acquire the permission of a call, the outcome of each permitted call must be recorded with Permit.Record
+func (circuitBreaker *CircuitBreaker) Acquire() (*Permit, error) {
+	circuitBreaker.mutex.Lock()
+	transitions := circuitBreaker.expire(true)
+	state := circuitBreaker.state
+	permitted := true
+	switch state {
+	case Open, ForcedOpen:
+		permitted = false
+	case HalfOpen:
+		permitted = circuitBreaker.permitted < circuitBreaker.permittedInHalfOpenState
+		if permitted {
+			circuitBreaker.permitted++
+		}
+	}
+	permit := &Permit{circuitBreaker: circuitBreaker, generation: circuitBreaker.generation}
+	circuitBreaker.mutex.Unlock()
+	circuitBreaker.notify(transitions)
+	if !permitted {
+		return nil, fmt.Errorf("circuit breaker of service %s is %s: %w", circuitBreaker.service, state, ErrCallNotPermitted)
+	}
+	return permit, nil
+}
+
+// Record the outcome of the call, once.  It is discarded when the circuit breaker transitioned since the call was
+// permitted
+func (permit *Permit) Record(duration time.Duration, err error) {
+	circuitBreaker := permit.circuitBreaker
+	circuitBreaker.mutex.Lock()
+	var transitions []model.CircuitBreakerTransitionResponse
+	current := !permit.recorded && permit.generation == circuitBreaker.generation
+	permit.recorded = true
+	if current && (circuitBreaker.state == Closed || circuitBreaker.state == HalfOpen) {
+		circuitBreaker.outcomes[circuitBreaker.next] = outcome{
+			failed: circuitBreaker.isFailure(err),
+			slow:   duration >= circuitBreaker.slowCallDuration,
+		}
+		circuitBreaker.next = (circuitBreaker.next + 1) % len(circuitBreaker.outcomes)
+		if circuitBreaker.recorded < len(circuitBreaker.outcomes) {
+			circuitBreaker.recorded++
+		}
+		transitions = circuitBreaker.evaluate()
+	}
+	circuitBreaker.mutex.Unlock()
+	circuitBreaker.notify(transitions)
+}
+
+// Open opens the circuit breaker like OpenCircuitBreaker opens the one of the server, it is half open after the wait
+// duration
+func (circuitBreaker *CircuitBreaker) Open() model.CircuitBreakerTransitionResponse {
+	return circuitBreaker.transitionTo(Open, "opened manually")
+}
+
+// ForceOpen opens the circuit breaker until it is closed
+func (circuitBreaker *CircuitBreaker) ForceOpen() model.CircuitBreakerTransitionResponse {
+	return circuitBreaker.transitionTo(ForcedOpen, "forced open manually")
+}
+
+// Close closes the circuit breaker like CloseCircuitBreaker closes the one of the server, the recorded calls are
+// discarded
+func (circuitBreaker *CircuitBreaker) Close() model.CircuitBreakerTransitionResponse {
+	return circuitBreaker.transitionTo(Closed, "closed manually")
+}
+
+// Disable permits every call until the circuit breaker is closed or opened
+func (circuitBreaker *CircuitBreaker) Disable() model.CircuitBreakerTransitionResponse {
+	return circuitBreaker.transitionTo(Disabled, "disabled manually")
+}
+
+func (circuitBreaker *CircuitBreaker) transitionTo(state State, message string) model.CircuitBreakerTransitionResponse {
+	circuitBreaker.mutex.Lock()
+	transitions := circuitBreaker.transitionLocked(state, message)
+	status := circuitBreaker.transition
+	circuitBreaker.mutex.Unlock()
+	circuitBreaker.notify(transitions)
+	return status
+}
+
+// expire moves an open circuit breaker to half open once the wait duration elapsed, and a half open one back to open
+// when the trial calls take longer than the max wait duration.  Must be called with the lock held
+func (circuitBreaker *CircuitBreaker) expire(toHalfOpen bool) []model.CircuitBreakerTransitionResponse {
+	elapsed := circuitBreaker.now().Sub(circuitBreaker.since)
+	switch {
+	case circuitBreaker.state == Open && toHalfOpen && elapsed >= circuitBreaker.waitInOpenState:
+		return circuitBreaker.transitionLocked(HalfOpen, fmt.Sprintf("wait duration of %s elapsed", circuitBreaker.waitInOpenState))
+	case circuitBreaker.state == HalfOpen && circuitBreaker.maxWaitInHalfOpenState > 0 && elapsed >= circuitBreaker.maxWaitInHalfOpenState:
+		return circuitBreaker.transitionLocked(Open, fmt.Sprintf("max wait duration of %s in half open state elapsed", circuitBreaker.maxWaitInHalfOpenState))
+	}
+	return nil
+}
+
+// evaluate opens or closes the circuit breaker once enough calls are recorded.  Must be called with the lock held
+func (circuitBreaker *CircuitBreaker) evaluate() []model.CircuitBreakerTransitionResponse {
+	minimum := circuitBreaker.minimumNumberOfCalls
+	if circuitBreaker.state == HalfOpen {
+		minimum = circuitBreaker.permittedInHalfOpenState
+	}
+	if circuitBreaker.recorded < minimum {
+		return nil
+	}
+	failed, slow := 0, 0
+	for _, outcome := range circuitBreaker.outcomes[:circuitBreaker.recorded] {
+		if outcome.failed {
+			failed++
+		}
+		if outcome.slow {
+			slow++
+		}
+	}
+	failureRate := float64(failed) * 100 / float64(circuitBreaker.recorded)
+	slowCallRate := float64(slow) * 100 / float64(circuitBreaker.recorded)
+	switch {
+	case failureRate >= circuitBreaker.failureRateThreshold:
+		return circuitBreaker.transitionLocked(Open, fmt.Sprintf("failure rate of %.1f%% reached the threshold of %.1f%%", failureRate, circuitBreaker.failureRateThreshold))
+	case slowCallRate >= circuitBreaker.slowCallRateThreshold:
+		return circuitBreaker.transitionLocked(Open, fmt.Sprintf("slow call rate of %.1f%% reached the threshold of %.1f%%", slowCallRate, circuitBreaker.slowCallRateThreshold))
+	case circuitBreaker.state == HalfOpen:
+		return circuitBreaker.transitionLocked(Closed, fmt.Sprintf("failure rate of %.1f%% and slow call rate of %.1f%% below the thresholds", failureRate, slowCallRate))
+	}
+	return nil
+}
+
+// transitionLocked moves to the state and discards the recorded calls.  Must be called with the lock held, the
+// transitions returned are notified once the lock is released
+func (circuitBreaker *CircuitBreaker) transitionLocked(state State, message string) []model.CircuitBreakerTransitionResponse {
+	previous := circuitBreaker.state
+	circuitBreaker.state = state
+	circuitBreaker.since = circuitBreaker.now()
+	circuitBreaker.permitted = 0
+	circuitBreaker.generation++
+	if state == HalfOpen {
+		circuitBreaker.reset(circuitBreaker.permittedInHalfOpenState)
+	} else {
+		circuitBreaker.reset(circuitBreaker.slidingWindowSize)
+	}
+	circuitBreaker.transition = model.CircuitBreakerTransitionResponse{
+		Service:             circuitBreaker.service,
+		PreviousState:       string(previous),
+		CurrentState:        string(state),
+		Message:             message,
+		TransitionTimestamp: circuitBreaker.since.UnixMilli(),
+	}
+	return []model.CircuitBreakerTransitionResponse{circuitBreaker.transition}
+}
+
+func (circuitBreaker *CircuitBreaker) reset(size int) {
+	circuitBreaker.outcomes = make([]outcome, size)
+	circuitBreaker.next = 0
+	circuitBreaker.recorded = 0
+}
+
+func (circuitBreaker *CircuitBreaker) notify(transitions []model.CircuitBreakerTransitionResponse) {
+	for _, transition := range transitions {
+		for _, listener := range circuitBreaker.listeners {
+			listener(transition)
+		}
+	}
+}
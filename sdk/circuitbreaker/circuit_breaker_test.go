@@ -0,0 +1,316 @@
+package circuitbreaker
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+var errCall = errors.New("service unavailable")
+
+type clock struct {
+	now time.Time
+}
+
+func (c *clock) advance(duration time.Duration) {
+	c.now = c.now.Add(duration)
+}
+
+func newTestCircuitBreaker(config model.OrkesCircuitBreakerConfig) (*CircuitBreaker, *clock, *[]model.CircuitBreakerTransitionResponse) {
+	c := &clock{now: time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)}
+	transitions := &[]model.CircuitBreakerTransitionResponse{}
+	circuitBreaker := NewCircuitBreaker("payments", config).
+		OnTransition(func(transition model.CircuitBreakerTransitionResponse) {
+			*transitions = append(*transitions, transition)
+		})
+	circuitBreaker.now = func() time.Time { return c.now }
+	circuitBreaker.since = c.now
+	return circuitBreaker, c, transitions
+}
+
+func call(circuitBreaker *CircuitBreaker, err error) error {
+	return circuitBreaker.Execute(context.Background(), func(ctx context.Context) error {
+		return err
+	})
+}
+
+func TestDefaults(t *testing.T) {
+	circuitBreaker := NewCircuitBreaker("payments", model.OrkesCircuitBreakerConfig{})
+	assert.Equal(t, float64(50), circuitBreaker.failureRateThreshold)
+	assert.Equal(t, float64(100), circuitBreaker.slowCallRateThreshold)
+	assert.Equal(t, time.Minute, circuitBreaker.slowCallDuration)
+	assert.Equal(t, 100, circuitBreaker.slidingWindowSize)
+	assert.Equal(t, 100, circuitBreaker.minimumNumberOfCalls)
+	assert.Equal(t, 10, circuitBreaker.permittedInHalfOpenState)
+	assert.Equal(t, time.Minute, circuitBreaker.waitInOpenState)
+	assert.Equal(t, Closed, circuitBreaker.State())
+	assert.Equal(t, "CLOSED", circuitBreaker.Status().CurrentState)
+}
+
+func TestOpensOnFailureRate(t *testing.T) {
+	circuitBreaker, c, transitions := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{
+		FailureRateThreshold:                  50,
+		MinimumNumberOfCalls:                  4,
+		SlidingWindowSize:                     4,
+		PermittedNumberOfCallsInHalfOpenState: 2,
+		WaitDurationInOpenState:               10000,
+	})
+
+	// below the minimum number of calls
+	assert.Equal(t, errCall, call(circuitBreaker, errCall))
+	assert.Equal(t, errCall, call(circuitBreaker, errCall))
+	assert.NoError(t, call(circuitBreaker, nil))
+	assert.Equal(t, Closed, circuitBreaker.State())
+
+	assert.Equal(t, errCall, call(circuitBreaker, errCall))
+	assert.Equal(t, Open, circuitBreaker.State())
+	assert.Equal(t, []model.CircuitBreakerTransitionResponse{{
+		Service:             "payments",
+		PreviousState:       "CLOSED",
+		CurrentState:        "OPEN",
+		Message:             "failure rate of 75.0% reached the threshold of 50.0%",
+		TransitionTimestamp: c.now.UnixMilli(),
+	}}, *transitions)
+
+	called := false
+	err := circuitBreaker.Execute(context.Background(), func(ctx context.Context) error {
+		called = true
+		return nil
+	})
+	assert.ErrorIs(t, err, ErrCallNotPermitted)
+	assert.EqualError(t, err, "circuit breaker of service payments is OPEN: call not permitted")
+	assert.False(t, called)
+
+	// half open on the next call after the wait duration
+	c.advance(10 * time.Second)
+	assert.Equal(t, Open, circuitBreaker.State())
+	assert.NoError(t, call(circuitBreaker, nil))
+	assert.Equal(t, HalfOpen, circuitBreaker.State())
+	permit, err := circuitBreaker.Acquire()
+	assert.NoError(t, err)
+	_, err = circuitBreaker.Acquire()
+	assert.ErrorIs(t, err, ErrCallNotPermitted)
+	permit.Record(time.Millisecond, nil)
+	assert.Equal(t, Closed, circuitBreaker.State())
+	assert.Equal(t, "failure rate of 0.0% and slow call rate of 0.0% below the thresholds", circuitBreaker.Status().Message)
+	assert.Len(t, *transitions, 3)
+}
+
+func TestSlidingWindow(t *testing.T) {
+	circuitBreaker, _, _ := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{
+		FailureRateThreshold: 50,
+		MinimumNumberOfCalls: 4,
+		SlidingWindowSize:    4,
+	})
+	// 3 failures out of 7 calls, the oldest calls leave the window
+	for _, err := range []error{errCall, nil, nil, nil, errCall, nil} {
+		call(circuitBreaker, err)
+		assert.Equal(t, Closed, circuitBreaker.State())
+	}
+	call(circuitBreaker, errCall)
+	assert.Equal(t, Open, circuitBreaker.State())
+}
+
+func TestHalfOpenReopens(t *testing.T) {
+	circuitBreaker, c, _ := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{
+		MinimumNumberOfCalls:                         1,
+		SlidingWindowSize:                            1,
+		PermittedNumberOfCallsInHalfOpenState:        2,
+		WaitDurationInOpenState:                      1000,
+		MaxWaitDurationInHalfOpenState:               5000,
+		AutomaticTransitionFromOpenToHalfOpenEnabled: true,
+	})
+	call(circuitBreaker, errCall)
+	assert.Equal(t, Open, circuitBreaker.State())
+	c.advance(time.Second)
+	assert.Equal(t, HalfOpen, circuitBreaker.State())
+
+	call(circuitBreaker, nil)
+	call(circuitBreaker, errCall)
+	assert.Equal(t, Open, circuitBreaker.State())
+
+	// the trial calls take too long
+	c.advance(time.Second)
+	_, err := circuitBreaker.Acquire()
+	assert.NoError(t, err)
+	c.advance(5 * time.Second)
+	assert.Equal(t, Open, circuitBreaker.State())
+	assert.Equal(t, "max wait duration of 5s in half open state elapsed", circuitBreaker.Status().Message)
+}
+
+func TestOpensOnSlowCallRate(t *testing.T) {
+	circuitBreaker, c, _ := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{
+		MinimumNumberOfCalls:      2,
+		SlidingWindowSize:         2,
+		SlowCallDurationThreshold: 500,
+		SlowCallRateThreshold:     50,
+	})
+	circuitBreaker.Execute(context.Background(), func(ctx context.Context) error {
+		c.advance(time.Second)
+		return nil
+	})
+	call(circuitBreaker, nil)
+	assert.Equal(t, Open, circuitBreaker.State())
+	assert.Equal(t, "slow call rate of 50.0% reached the threshold of 50.0%", circuitBreaker.Status().Message)
+}
+
+func TestFailurePredicate(t *testing.T) {
+	circuitBreaker, _, _ := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{MinimumNumberOfCalls: 1, SlidingWindowSize: 1})
+	circuitBreaker.WithFailurePredicate(func(err error) bool { return errors.Is(err, errCall) })
+	call(circuitBreaker, errors.New("invalid input"))
+	assert.Equal(t, Closed, circuitBreaker.State())
+	call(circuitBreaker, errCall)
+	assert.Equal(t, Open, circuitBreaker.State())
+}
+
+func TestManualTransitions(t *testing.T) {
+	circuitBreaker, c, _ := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{WaitDurationInOpenState: 1000})
+	assert.Equal(t, model.CircuitBreakerTransitionResponse{
+		Service:             "payments",
+		PreviousState:       "CLOSED",
+		CurrentState:        "FORCED_OPEN",
+		Message:             "forced open manually",
+		TransitionTimestamp: c.now.UnixMilli(),
+	}, circuitBreaker.ForceOpen())
+	c.advance(time.Hour)
+	_, err := circuitBreaker.Acquire()
+	assert.ErrorIs(t, err, ErrCallNotPermitted)
+
+	circuitBreaker.Disable()
+	for i := 0; i < 200; i++ {
+		assert.Equal(t, errCall, call(circuitBreaker, errCall))
+	}
+	assert.Equal(t, Disabled, circuitBreaker.State())
+
+	assert.Equal(t, "CLOSED", circuitBreaker.Close().CurrentState)
+	assert.Equal(t, "OPEN", circuitBreaker.Open().CurrentState)
+	c.advance(time.Second)
+	_, err = circuitBreaker.Acquire()
+	assert.NoError(t, err)
+	assert.Equal(t, HalfOpen, circuitBreaker.State())
+}
+
+func TestPanicReleasesTrialCall(t *testing.T) {
+	circuitBreaker, c, _ := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{
+		MinimumNumberOfCalls:                  1,
+		SlidingWindowSize:                     1,
+		PermittedNumberOfCallsInHalfOpenState: 1,
+		WaitDurationInOpenState:               1000,
+	})
+	circuitBreaker.Open()
+	c.advance(time.Second)
+	assert.PanicsWithValue(t, "boom", func() {
+		circuitBreaker.Execute(context.Background(), func(ctx context.Context) error {
+			panic("boom")
+		})
+	})
+	// the panic is the failure of the trial call
+	assert.Equal(t, Open, circuitBreaker.State())
+	assert.Equal(t, "failure rate of 100.0% reached the threshold of 50.0%", circuitBreaker.Status().Message)
+	c.advance(time.Second)
+	assert.NoError(t, call(circuitBreaker, nil))
+	assert.Equal(t, Closed, circuitBreaker.State())
+}
+
+func TestDiscardsOutcomesOfPreviousState(t *testing.T) {
+	circuitBreaker, c, _ := newTestCircuitBreaker(model.OrkesCircuitBreakerConfig{
+		MinimumNumberOfCalls:                  2,
+		SlidingWindowSize:                     2,
+		PermittedNumberOfCallsInHalfOpenState: 2,
+		WaitDurationInOpenState:               1000,
+	})
+	// permitted while closed, completed once half open
+	slow, err := circuitBreaker.Acquire()
+	assert.NoError(t, err)
+	circuitBreaker.Open()
+	c.advance(time.Second)
+	trial, err := circuitBreaker.Acquire()
+	assert.NoError(t, err)
+	assert.Equal(t, HalfOpen, circuitBreaker.State())
+
+	slow.Record(time.Millisecond, errCall)
+	trial.Record(time.Millisecond, nil)
+	// recorded once
+	trial.Record(time.Millisecond, nil)
+	assert.Equal(t, HalfOpen, circuitBreaker.State())
+	assert.NoError(t, call(circuitBreaker, nil))
+	assert.Equal(t, Closed, circuitBreaker.State())
+}
+
+func TestServiceRegistry(t *testing.T) {
+	status := model.CircuitBreakerTransitionResponse{Service: "payments", CurrentState: "CLOSED", TransitionTimestamp: 1000}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/registry/service/payments":
+			json.NewEncoder(w).Encode(model.ServiceRegistry{
+				Name: "payments",
+				Config: &model.Config{CircuitBreakerConfig: &model.OrkesCircuitBreakerConfig{
+					FailureRateThreshold: 25,
+					SlidingWindowSize:    20,
+				}},
+			})
+		case "/registry/service/payments/circuit-breaker/status":
+			json.NewEncoder(w).Encode(status)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			w.Write([]byte(`{"message":"not found"}`))
+		}
+	}))
+	defer server.Close()
+	serviceRegistryClient := client.NewServiceRegistryClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+	ctx := context.Background()
+
+	circuitBreaker, err := FromServiceRegistry(ctx, serviceRegistryClient, "payments")
+	assert.NoError(t, err)
+	assert.Equal(t, float64(25), circuitBreaker.failureRateThreshold)
+	assert.Equal(t, 20, circuitBreaker.slidingWindowSize)
+	assert.Equal(t, 20, circuitBreaker.minimumNumberOfCalls)
+
+	changed, err := circuitBreaker.Refresh(ctx, serviceRegistryClient)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+
+	// opened on the server
+	status = model.CircuitBreakerTransitionResponse{Service: "payments", PreviousState: "CLOSED", CurrentState: "OPEN", TransitionTimestamp: 2000}
+	changed, err = circuitBreaker.Refresh(ctx, serviceRegistryClient)
+	assert.NoError(t, err)
+	assert.True(t, changed)
+	assert.Equal(t, Open, circuitBreaker.State())
+	assert.Equal(t, "mirrored from the server", circuitBreaker.Status().Message)
+
+	// the transition of the server is applied once
+	circuitBreaker.Close()
+	changed, err = circuitBreaker.Refresh(ctx, serviceRegistryClient)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+	assert.Equal(t, Closed, circuitBreaker.State())
+
+	// the statuses without transition timestamp are ignored
+	status = model.CircuitBreakerTransitionResponse{Service: "payments", CurrentState: "OPEN"}
+	for i := 0; i < 2; i++ {
+		changed, err = circuitBreaker.Refresh(ctx, serviceRegistryClient)
+		assert.NoError(t, err)
+		assert.False(t, changed)
+		assert.Equal(t, Closed, circuitBreaker.State())
+	}
+
+	status.CurrentState = "UNKNOWN"
+	status.TransitionTimestamp = 3000
+	_, err = circuitBreaker.Refresh(ctx, serviceRegistryClient)
+	assert.EqualError(t, err, `unknown circuit breaker state "UNKNOWN" of service payments`)
+
+	// no circuit breaker on the server
+	changed, err = NewCircuitBreaker("orders", model.OrkesCircuitBreakerConfig{}).Refresh(ctx, serviceRegistryClient)
+	assert.NoError(t, err)
+	assert.False(t, changed)
+}
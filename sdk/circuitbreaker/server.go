@@ -0,0 +1,87 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package circuitbreaker
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// FromServiceRegistry the circuit breaker of the service with the configuration of the service registry, the defaults
+// of the server when the service has no circuit breaker configuration
+func FromServiceRegistry(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient, service string) (*CircuitBreaker, error) {
+	registry, _, err := serviceRegistryClient.GetService(ctx, service)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get service %s: %w", service, err)
+	}
+	var config model.OrkesCircuitBreakerConfig
+	if registry.Config != nil && registry.Config.CircuitBreakerConfig != nil {
+		config = *registry.Config.CircuitBreakerConfig
+	}
+	return NewCircuitBreaker(service, config), nil
+}
+
+// Refresh mirrors the last transition of the circuit breaker of the server, e.g. after OpenCircuitBreaker or
+// CloseCircuitBreaker.  A transition of the server is applied once, the circuit breaker then transitions on its own
+// calls.  The statuses without a transition timestamp are ignored.  Returns whether the state changed
+func (circuitBreaker *CircuitBreaker) Refresh(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient) (bool, error) {
+	status, response, err := serviceRegistryClient.GetCircuitBreakerStatus(ctx, circuitBreaker.service)
+	if internal.IsNotFound(response, err) {
+		return false, nil
+	}
+	if err != nil {
+		return false, fmt.Errorf("failed to get the circuit breaker status of service %s: %w", circuitBreaker.service, err)
+	}
+	state := State(status.CurrentState)
+	switch state {
+	case Closed, Open, HalfOpen, ForcedOpen, Disabled:
+	default:
+		return false, fmt.Errorf("unknown circuit breaker state %q of service %s", status.CurrentState, circuitBreaker.service)
+	}
+
+	circuitBreaker.mutex.Lock()
+	if status.TransitionTimestamp <= circuitBreaker.serverTransition {
+		circuitBreaker.mutex.Unlock()
+		return false, nil
+	}
+	circuitBreaker.serverTransition = status.TransitionTimestamp
+	var transitions []model.CircuitBreakerTransitionResponse
+	if circuitBreaker.state != state {
+		transitions = circuitBreaker.transitionLocked(state, "mirrored from the server")
+	}
+	circuitBreaker.mutex.Unlock()
+	circuitBreaker.notify(transitions)
+	return len(transitions) > 0, nil
+}
+
+// Mirror refreshes the state from the server every interval until the context is done, the failures are logged and
+// retried on the next interval
+func (circuitBreaker *CircuitBreaker) Mirror(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if _, err := circuitBreaker.Refresh(ctx, serviceRegistryClient); err != nil && ctx.Err() == nil {
+				log.Warning(fmt.Sprintf("Failed to mirror the circuit breaker of the server: %s", err.Error()))
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
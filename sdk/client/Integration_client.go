@@ -48,6 +48,7 @@ type IntegrationClient interface {
 	AssociatePromptWithIntegration(ctx context.Context, integrationName string, model string, promptName string) (*http.Response, error)
 	GetTokenUsageForIntegration(ctx context.Context, integrationName string, model string) (int32, *http.Response, error)
 	GetTokenUsageForIntegrationProvider(ctx context.Context, name string) (map[string]string, *http.Response, error)
+	RegisterTokenUsage(ctx context.Context, tokens int32, name string, integrationName string) (*http.Response, error)
 
 	GetAllIntegrations(ctx context.Context, optionals *IntegrationResourceApiGetAllIntegrationsOpts) ([]model.Integration, *http.Response, error)
 	RecordEventStats(ctx context.Context, body []model.EventLog, type_ string) (*http.Response, error)
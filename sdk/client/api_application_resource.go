@@ -55,6 +55,22 @@ func (a *ApplicationResourceApiService) CreateAccessKey(ctx context.Context, id
 	return &result, resp, nil
 }
 
+/*
+ApplicationResourceApiService Create an access key for an application, the secret of the key is only returned here
+* @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param id
+    @return *rbac.AccessKey
+*/
+func (a *ApplicationResourceApiService) CreateAccessKeyWithSecret(ctx context.Context, id string) (*rbac.AccessKey, *http.Response, error) {
+	var result rbac.AccessKey
+	path := fmt.Sprintf("/applications/%s/accessKeys", id)
+	resp, err := a.Post(ctx, path, nil, &result)
+	if err != nil {
+		return nil, resp, err
+	}
+	return &result, resp, nil
+}
+
 /*
 ApplicationResourceApiService Create an application
 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
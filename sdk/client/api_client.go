@@ -182,6 +182,16 @@ func (c *APIClient) decode(v interface{}, b []byte, contentType string) (err err
 		}
 		rv.Elem().SetString(string(b))
 		return nil
+	} else if strings.Contains(contentType, "application/octet-stream") {
+		// binary content, e.g. the proto data of the service registry
+		switch target := v.(type) {
+		case *string:
+			*target = string(b)
+			return nil
+		case *[]byte:
+			*target = b
+			return nil
+		}
 	}
 
 	return errors.New("undefined response type")
@@ -459,3 +469,18 @@ func (c *APIClient) DeleteWithBody(ctx context.Context, path string, body interf
 func (c *APIClient) Patch(ctx context.Context, path string, body interface{}, result interface{}) (*http.Response, error) {
 	return c.executeCall(ctx, "PATCH", path, nil, body, "", result)
 }
+
+// UpdateCredentials replaces the credentials used to authenticate the requests of the client and of everything built
+// on it, e.g. the TaskRunner, without restarting them.  Requires a token manager implementing
+// authentication.CredentialsUpdater, as the default one does
+func (c *APIClient) UpdateCredentials(credentials *settings.AuthenticationSettings) error {
+	if credentials == nil || credentials.IsEmpty() {
+		return fmt.Errorf("the key id and the key secret are required")
+	}
+	updater, ok := c.httpRequester.tokenManager.(authentication.CredentialsUpdater)
+	if !ok {
+		return fmt.Errorf("the client was created without a token manager supporting credential updates")
+	}
+	updater.UpdateCredentials(*credentials)
+	return nil
+}
@@ -339,6 +339,26 @@ func (a *HumanTaskApiService) GetTemplateByTaskId(ctx context.Context, humanTask
 	return result, resp, nil
 }
 
+/*
+HumanTaskApiService Get user form template by name and version, including its JSON schema
+* @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param name
+  - @param version
+    @return human.HumanTaskTemplate
+*/
+func (a *HumanTaskApiService) GetUserFormTemplate(ctx context.Context, name string, version int32) (human.HumanTaskTemplate, *http.Response, error) {
+	var result human.HumanTaskTemplate
+
+	path := fmt.Sprintf("/human/template/%s/%d", name, version)
+
+	resp, err := a.Get(ctx, path, nil, &result)
+	if err != nil {
+		return human.HumanTaskTemplate{}, resp, err
+	}
+
+	return result, resp, nil
+}
+
 /*
 HumanTaskApiService Release a task without completing it
 * @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
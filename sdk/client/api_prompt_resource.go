@@ -142,7 +142,9 @@ func (a *PromptResourceApiService) SaveMessageTemplate(ctx context.Context, body
 	queryParams := url.Values{}
 	queryParams.Add("description", parameterToString(description, ""))
 	if optionals != nil {
-		queryParams.Add("models", parameterToString(optionals.Models, "multi"))
+		for _, modelName := range optionals.Models {
+			queryParams.Add("models", modelName)
+		}
 	}
 	resp, err := a.PostWithParams(ctx, path, queryParams, body, nil)
 	if err != nil {
@@ -0,0 +1,134 @@
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+type SchemaResourceApiService struct {
+	*APIClient
+}
+
+/*
+SchemaResourceApiService Save the schemas
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param body
+  - @param optional nil or *SchemaResourceApiSaveOpts - Optional Parameters:
+  - @param "NewVersion" (optional.Bool) -  save as a new version instead of overwriting the version of the schema
+*/
+type SchemaResourceApiSaveOpts struct {
+	NewVersion optional.Bool
+}
+
+func (a *SchemaResourceApiService) Save(ctx context.Context, body []model.SchemaDef, opts *SchemaResourceApiSaveOpts) (*http.Response, error) {
+	path := "/schema"
+
+	queryParams := url.Values{}
+	if opts != nil && opts.NewVersion.IsSet() {
+		queryParams.Add("newVersion", parameterToString(opts.NewVersion.Value(), ""))
+	}
+
+	resp, err := a.PostWithParams(ctx, path, queryParams, body, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+/*
+SchemaResourceApiService Get all the schemas
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+    @return []SchemaDef
+*/
+func (a *SchemaResourceApiService) GetAllSchemas(ctx context.Context) ([]model.SchemaDef, *http.Response, error) {
+	var result []model.SchemaDef
+
+	path := "/schema"
+
+	resp, err := a.Get(ctx, path, nil, &result)
+	if err != nil {
+		return []model.SchemaDef{}, resp, err
+	}
+	return result, resp, nil
+}
+
+/*
+SchemaResourceApiService Get the latest version of the schema
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param name
+    @return SchemaDef
+*/
+func (a *SchemaResourceApiService) GetSchemaByNameWithLatestVersion(ctx context.Context, name string) (model.SchemaDef, *http.Response, error) {
+	var result model.SchemaDef
+
+	path := fmt.Sprintf("/schema/%s", name)
+
+	resp, err := a.Get(ctx, path, nil, &result)
+	if err != nil {
+		return model.SchemaDef{}, resp, err
+	}
+	return result, resp, nil
+}
+
+/*
+SchemaResourceApiService Get the version of the schema
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param name
+  - @param version
+    @return SchemaDef
+*/
+func (a *SchemaResourceApiService) GetSchemaByNameAndVersion(ctx context.Context, name string, version int32) (model.SchemaDef, *http.Response, error) {
+	var result model.SchemaDef
+
+	path := fmt.Sprintf("/schema/%s/%d", name, version)
+
+	resp, err := a.Get(ctx, path, nil, &result)
+	if err != nil {
+		return model.SchemaDef{}, resp, err
+	}
+	return result, resp, nil
+}
+
+/*
+SchemaResourceApiService Delete all the versions of the schema
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param name
+*/
+func (a *SchemaResourceApiService) DeleteSchemaByName(ctx context.Context, name string) (*http.Response, error) {
+	path := fmt.Sprintf("/schema/%s", name)
+
+	resp, err := a.Delete(ctx, path, nil, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
+
+/*
+SchemaResourceApiService Delete the version of the schema
+  - @param ctx context.Context - for authentication, logging, cancellation, deadlines, tracing, etc. Passed from http.Request or context.Background().
+  - @param name
+  - @param version
+*/
+func (a *SchemaResourceApiService) DeleteSchemaByNameAndVersion(ctx context.Context, name string, version int32) (*http.Response, error) {
+	path := fmt.Sprintf("/schema/%s/%d", name, version)
+
+	resp, err := a.Delete(ctx, path, nil, nil)
+	if err != nil {
+		return resp, err
+	}
+	return resp, nil
+}
@@ -131,14 +131,14 @@ WorkflowResourceApiService Get the uri and path of the external storage where th
 func (a *WorkflowResourceApiService) GetExternalStorageLocation(ctx context.Context, path string, operation string, payloadType string) (model.ExternalStorageLocation, *http.Response, error) {
 	var result model.ExternalStorageLocation
 
-	path = "/workflow/externalstoragelocation"
+	http_path := "/workflow/externalstoragelocation"
 
 	queryParams := url.Values{}
 	queryParams.Add("path", parameterToString(path, ""))
 	queryParams.Add("operation", parameterToString(operation, ""))
 	queryParams.Add("payloadType", parameterToString(payloadType, ""))
 
-	resp, err := a.Get(ctx, path, queryParams, &result)
+	resp, err := a.Get(ctx, http_path, queryParams, &result)
 	if err != nil {
 		return model.ExternalStorageLocation{}, resp, err
 	}
@@ -1046,14 +1046,12 @@ type WorkflowResourceApiJumpToTaskOpts struct {
 }
 
 func (a *WorkflowResourceApiService) JumpToTask(ctx context.Context, body map[string]interface{}, workflowId string, optionals *WorkflowResourceApiJumpToTaskOpts) (*http.Response, error) {
-	path := fmt.Sprintf("/workflow/%s/jump/{taskReferenceName}", workflowId)
-
-	queryParams := url.Values{}
-	if optionals != nil && optionals.TaskReferenceName.IsSet() {
-		queryParams.Add("taskReferenceName", parameterToString(optionals.TaskReferenceName.Value(), ""))
+	if optionals == nil || !optionals.TaskReferenceName.IsSet() {
+		return nil, fmt.Errorf("task reference name is required")
 	}
+	path := fmt.Sprintf("/workflow/%s/jump/%s", workflowId, url.PathEscape(optionals.TaskReferenceName.Value()))
 
-	resp, err := a.PostWithParams(ctx, path, queryParams, body, nil)
+	resp, err := a.Post(ctx, path, body, nil)
 	if err != nil {
 		return resp, err
 	}
@@ -1101,6 +1099,41 @@ func (a *WorkflowResourceApiService) UpdateWorkflowAndTaskState(ctx context.Cont
 	return result, resp, nil
 }
 
+// UpdateWorkflowStateOpts options of UpdateWorkflowStateWithReturnStrategy
+type UpdateWorkflowStateOpts struct {
+	RequestId        string
+	WaitUntilTaskRef []string
+	WaitForSeconds   int
+	ReturnStrategy   model.ReturnStrategy
+}
+
+// UpdateWorkflowStateWithReturnStrategy updates the variables and the in progress task of the workflow, then waits
+// until one of the WaitUntilTaskRef tasks is reached and returns the response according to the ReturnStrategy
+func (a *WorkflowResourceApiService) UpdateWorkflowStateWithReturnStrategy(ctx context.Context, body model.WorkflowStateUpdate, workflowId string, opts UpdateWorkflowStateOpts) (*model.SignalResponse, error) {
+	if opts.ReturnStrategy == "" {
+		opts.ReturnStrategy = model.ReturnTargetWorkflow
+	}
+
+	path := fmt.Sprintf("/workflow/%s/state", workflowId)
+
+	queryParams := url.Values{}
+	queryParams.Add("requestId", parameterToString(opts.RequestId, ""))
+	if len(opts.WaitUntilTaskRef) > 0 {
+		queryParams.Add("waitUntilTaskRef", strings.Join(opts.WaitUntilTaskRef, ","))
+	}
+	if opts.WaitForSeconds > 0 {
+		queryParams.Add("waitForSeconds", parameterToString(opts.WaitForSeconds, ""))
+	}
+	queryParams.Add("returnStrategy", parameterToString(string(opts.ReturnStrategy), ""))
+
+	var result model.SignalResponse
+	_, err := a.PostWithParams(ctx, path, queryParams, body, &result)
+	if err != nil {
+		return nil, err
+	}
+	return &result, nil
+}
+
 /*
 WorkflowResourceApiService Upgrade running workflow to newer version
 
@@ -20,6 +20,7 @@ import (
 type ApplicationClient interface {
 	AddRoleToApplicationUser(ctx context.Context, applicationId string, role string) (interface{}, *http.Response, error)
 	CreateAccessKey(ctx context.Context, id string) (*rbac.ConductorApplication, *http.Response, error)
+	CreateAccessKeyWithSecret(ctx context.Context, id string) (*rbac.AccessKey, *http.Response, error)
 	CreateApplication(ctx context.Context, body rbac.CreateOrUpdateApplicationRequest) (*rbac.ConductorApplication, *http.Response, error)
 	DeleteAccessKey(ctx context.Context, applicationId string, keyId string) (*http.Response, error)
 	DeleteApplication(ctx context.Context, id string) (interface{}, *http.Response, error)
@@ -29,6 +29,7 @@ type HumanTaskClient interface {
 	GetTaskDisplayNames(ctx context.Context, searchType string) ([]string, *http.Response, error)
 	GetTemplateByNameAndVersion(ctx context.Context, name string, version int32) (human.HumanTaskSearch, *http.Response, error)
 	GetTemplateByTaskId(ctx context.Context, humanTaskId string) (human.HumanTaskSearch, *http.Response, error)
+	GetUserFormTemplate(ctx context.Context, name string, version int32) (human.HumanTaskTemplate, *http.Response, error)
 	ReassignTask(ctx context.Context, body []human.HumanTaskAssignment, taskId string) (*http.Response, error)
 	ReleaseTask(ctx context.Context, taskId string) (*http.Response, error)
 	SaveTemplate(ctx context.Context, body human.HumanTaskSearch, optionals *HumanTaskApiSaveTemplateOpts) (human.HumanTaskSearch, *http.Response, error)
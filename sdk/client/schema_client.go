@@ -0,0 +1,31 @@
+package client
+
+import (
+	"context"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"net/http"
+)
+
+type SchemaClient interface {
+	//Save the schemas, overwriting the versions already registered unless NewVersion is set
+	Save(ctx context.Context, body []model.SchemaDef, opts *SchemaResourceApiSaveOpts) (*http.Response, error)
+
+	//GetAllSchemas return all the versions of all the schemas
+	GetAllSchemas(ctx context.Context) ([]model.SchemaDef, *http.Response, error)
+
+	//GetSchemaByNameWithLatestVersion return the latest version of the schema
+	GetSchemaByNameWithLatestVersion(ctx context.Context, name string) (model.SchemaDef, *http.Response, error)
+
+	//GetSchemaByNameAndVersion return the version of the schema
+	GetSchemaByNameAndVersion(ctx context.Context, name string, version int32) (model.SchemaDef, *http.Response, error)
+
+	//DeleteSchemaByName delete all the versions of the schema
+	DeleteSchemaByName(ctx context.Context, name string) (*http.Response, error)
+
+	//DeleteSchemaByNameAndVersion delete the version of the schema
+	DeleteSchemaByNameAndVersion(ctx context.Context, name string, version int32) (*http.Response, error)
+}
+
+func NewSchemaClient(client *APIClient) SchemaClient {
+	return &SchemaResourceApiService{client}
+}
@@ -0,0 +1,150 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// ApplyResult the resources by the change applied, e.g. WorkflowDef charge_card v2
+type ApplyResult struct {
+	Created   []string `json:"created"`
+	Updated   []string `json:"updated"`
+	Deleted   []string `json:"deleted"`
+	Unchanged []string `json:"unchanged"`
+}
+
+func (result *ApplyResult) HasChanges() bool {
+	return len(result.Created)+len(result.Updated)+len(result.Deleted) > 0
+}
+
+// Apply makes the changes of the plan in order, stopping at the first failure.  The result lists the changes applied
+// before the failure, planning and applying again resumes from there
+func (applier *Applier) Apply(ctx context.Context, plan *Plan) (*ApplyResult, error) {
+	result := &ApplyResult{Created: []string{}, Updated: []string{}, Deleted: []string{}, Unchanged: []string{}}
+	for i := range plan.Changes {
+		change := &plan.Changes[i]
+		if change.Action == NoChange {
+			result.Unchanged = append(result.Unchanged, change.String())
+			continue
+		}
+		if err := ctx.Err(); err != nil {
+			return result, err
+		}
+		if !applier.manages(change.Kind) {
+			return result, fmt.Errorf("%s: no client for the %s resources", change, change.Kind)
+		}
+		var err error
+		switch change.Action {
+		case Create, Update:
+			if change.resource == nil {
+				return result, fmt.Errorf("%s: the change has no resource, apply the plans computed by Plan", change)
+			}
+			err = applier.save(ctx, change)
+		case Delete:
+			err = applier.delete(ctx, change)
+		default:
+			err = fmt.Errorf("unknown action %s", change.Action)
+		}
+		if err != nil {
+			return result, fmt.Errorf("failed to %s %s: %w", change.Action, change, err)
+		}
+		switch change.Action {
+		case Create:
+			result.Created = append(result.Created, change.String())
+		case Update:
+			result.Updated = append(result.Updated, change.String())
+		case Delete:
+			result.Deleted = append(result.Deleted, change.String())
+		}
+	}
+	return result, nil
+}
+
+func (applier *Applier) save(ctx context.Context, change *Change) error {
+	resource := change.resource
+	var err error
+	switch change.Kind {
+	case TaskDefKind:
+		taskDef := *resource.TaskDef
+		switch {
+		case change.Action == Create && resource.Tags != nil:
+			_, err = applier.metadataClient.RegisterTaskDefWithTags(ctx, taskDef, toMetadataTags(resource.Tags))
+		case change.Action == Create:
+			_, err = applier.metadataClient.RegisterTaskDef(ctx, []model.TaskDef{taskDef})
+		case resource.Tags != nil:
+			_, err = applier.metadataClient.UpdateTaskDefWithTags(ctx, taskDef, toMetadataTags(resource.Tags), true)
+		default:
+			_, err = applier.metadataClient.UpdateTaskDef(ctx, taskDef)
+		}
+	case WorkflowDefKind:
+		workflowDef := *resource.WorkflowDef
+		workflowDef.Version = resource.Version()
+		switch {
+		case change.Action == Create && resource.Tags != nil:
+			_, err = applier.metadataClient.RegisterWorkflowDefWithTags(ctx, true, workflowDef, toMetadataTags(resource.Tags))
+		case change.Action == Create:
+			_, err = applier.metadataClient.RegisterWorkflowDef(ctx, true, workflowDef)
+		case resource.Tags != nil:
+			_, err = applier.metadataClient.UpdateWorkflowDefWithTags(ctx, workflowDef, toMetadataTags(resource.Tags), true)
+		default:
+			_, err = applier.metadataClient.Update(ctx, []model.WorkflowDef{workflowDef})
+		}
+	case ScheduleKind:
+		if _, _, err = applier.schedulerClient.SaveSchedule(ctx, *resource.Schedule); err == nil {
+			err = applier.replaceScheduleTags(ctx, change)
+		}
+	case EventHandlerKind:
+		if change.Action == Create {
+			_, err = applier.eventHandlerClient.AddEventHandler(ctx, *resource.EventHandler)
+		} else {
+			_, err = applier.eventHandlerClient.UpdateEventHandler(ctx, *resource.EventHandler)
+		}
+	}
+	return err
+}
+
+// replaceScheduleTags the schedule API adds and removes tags rather than replacing them
+func (applier *Applier) replaceScheduleTags(ctx context.Context, change *Change) error {
+	desired := change.resource.Tags
+	if desired == nil || reflect.DeepEqual(desired, change.currentTags) {
+		return nil
+	}
+	if len(change.currentTags) > 0 {
+		if _, err := applier.schedulerClient.DeleteTagForSchedule(ctx, toTags(change.currentTags), change.Name); err != nil {
+			return err
+		}
+	}
+	if len(desired) > 0 {
+		if _, err := applier.schedulerClient.PutTagForSchedule(ctx, toTags(desired), change.Name); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (applier *Applier) delete(ctx context.Context, change *Change) error {
+	var err error
+	switch change.Kind {
+	case TaskDefKind:
+		_, err = applier.metadataClient.UnregisterTaskDef(ctx, change.Name)
+	case WorkflowDefKind:
+		_, err = applier.metadataClient.UnregisterWorkflowDef(ctx, change.Name, change.Version)
+	case ScheduleKind:
+		_, _, err = applier.schedulerClient.DeleteSchedule(ctx, change.Name)
+	case EventHandlerKind:
+		_, err = applier.eventHandlerClient.RemoveEventHandler(ctx, change.Name)
+	}
+	return err
+}
@@ -0,0 +1,137 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package declarative
+
+import (
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow"
+)
+
+// Difference a property with a different value on the server, Current is nil when the property is not set on the
+// server and Desired is nil when the property is removed
+type Difference struct {
+	Property string      `json:"property"`
+	Current  interface{} `json:"current,omitempty"`
+	Desired  interface{} `json:"desired,omitempty"`
+}
+
+// properties maintained by the server or compared separately
+var ignoredProperties = []string{"createTime", "updateTime", "updatedTime", "createdBy", "updatedBy", "ownerApp", "tags", "overwriteTags", "pausedReason"}
+
+// diffDeclared compares the properties declared by the desired definition with the current one.  The properties the
+// definition leaves unset are left to the server defaults and are not compared, the declared ones are compared in full.
+// declared holds the properties set in the file of the resource: declaring false, 0 or "" is compared with the server
+// as well.  Without it, for the resources built in Go, the properties with an empty value, as defined by isEmpty, are
+// considered unset
+func diffDeclared(currentProperties map[string]interface{}, desiredProperties map[string]interface{}, declared map[string]interface{}, isEmpty func(value interface{}) bool) []Difference {
+	differences := []Difference{}
+	for _, property := range declaredProperties(desiredProperties, declared, isEmpty) {
+		currentValue, desiredValue := currentProperties[property], desiredProperties[property]
+		if desiredValue == nil {
+			// declared with a zero value
+			desiredValue = declared[property]
+		}
+		if isEmpty(currentValue) && isEmpty(desiredValue) {
+			continue
+		}
+		if !reflect.DeepEqual(currentValue, desiredValue) {
+			differences = append(differences, Difference{Property: property, Current: currentValue, Desired: desiredValue})
+		}
+	}
+	return differences
+}
+
+// declaredProperties the properties to compare: the non-empty desired ones, and the ones the file sets to a zero value
+func declaredProperties(desiredProperties map[string]interface{}, declared map[string]interface{}, isEmpty func(value interface{}) bool) []string {
+	properties := make(map[string]bool, len(desiredProperties))
+	for property := range desiredProperties {
+		properties[property] = true
+	}
+	for property, value := range declared {
+		// the properties unknown to the model are ignored as when the spec is parsed
+		if _, found := desiredProperties[property]; !found && !isIgnored(property) && isEmpty(internal.WithoutEmptyValues(value, isEmpty)) {
+			properties[property] = true
+		}
+	}
+	return sortedKeys(properties)
+}
+
+func isIgnored(property string) bool {
+	for _, ignored := range ignoredProperties {
+		if ignored == property {
+			return true
+		}
+	}
+	return false
+}
+
+// diffWorkflowDefs compares the workflow definitions structurally, see workflow.DiffWorkflowDefs.
+// The properties the desired definition leaves unset are not compared as for the other kinds, unless the file of the
+// resource declares them with a zero value
+func diffWorkflowDefs(current model.WorkflowDef, desired model.WorkflowDef, declared map[string]interface{}) []Difference {
+	current.Tags, desired.Tags = nil, nil
+	current.Version = desired.Version
+	differences := []Difference{}
+	for _, change := range workflow.DiffWorkflowDefs(&current, &desired).Changes {
+		switch change.Type {
+		case workflow.WorkflowModified:
+			if change.To != nil {
+				differences = append(differences, Difference{Property: change.Property, Current: change.From, Desired: change.To})
+			} else if value, found := declared[strings.SplitN(change.Property, ".", 2)[0]]; found {
+				difference := Difference{Property: change.Property, Current: change.From}
+				if !strings.Contains(change.Property, ".") {
+					difference.Desired = value
+				}
+				differences = append(differences, difference)
+			}
+		case workflow.TaskModified:
+			if change.To != nil {
+				differences = append(differences, Difference{Property: change.TaskReferenceName + "." + change.Property, Current: change.From, Desired: change.To})
+			}
+		default:
+			differences = append(differences, Difference{Property: "task " + change.TaskReferenceName, Current: change.From, Desired: change.To})
+		}
+	}
+	return differences
+}
+
+// diffTags compares the tags when the resource declares them
+func diffTags(current map[string]string, desired map[string]string) []Difference {
+	if desired == nil || (len(current) == 0 && len(desired) == 0) || reflect.DeepEqual(current, desired) {
+		return nil
+	}
+	difference := Difference{Property: "tags", Desired: desired}
+	if len(current) > 0 {
+		difference.Current = current
+	}
+	if len(desired) == 0 {
+		difference.Desired = nil
+	}
+	return []Difference{difference}
+}
+
+// toProperties the JSON properties of the value without the ignored properties and the empty values
+func toProperties(value interface{}, isEmpty func(value interface{}) bool) map[string]interface{} {
+	return internal.ToProperties(value, isEmpty, ignoredProperties...)
+}
+
+func sortedKeys[V any](values map[string]V) []string {
+	keys := make([]string, 0, len(values))
+	for key := range values {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
@@ -0,0 +1,422 @@
+package declarative
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/event"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/scheduler"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow"
+	"github.com/stretchr/testify/assert"
+)
+
+const resourcesYaml = `
+kind: TaskDef
+spec:
+  name: charge_card
+  retryCount: 3
+  timeoutSeconds: 120
+  tags:
+    - key: team
+      value: payments
+---
+kind: workflowdef
+tags:
+  team: payments
+spec:
+  name: charge
+  version: 2
+  timeoutSeconds: 60
+  tasks:
+    - name: charge_card
+      taskReferenceName: charge_ref
+      type: SIMPLE
+`
+
+const resourcesJson = `[
+  {"kind": "Schedule", "spec": {"name": "nightly", "cronExpression": "0 0 0 * * ?", "startWorkflowRequest": {"name": "charge", "version": 2}}},
+  {"kind": "EventHandler", "spec": {"name": "on_order", "event": "kafka:orders", "actions": [{"action": "start_workflow", "start_workflow": {"name": "charge", "version": 2}}], "active": true}}
+]`
+
+func TestParse(t *testing.T) {
+	resources, err := Parse([]byte(resourcesYaml))
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, TaskDefKind, resources[0].Kind)
+	assert.Equal(t, "charge_card", resources[0].Name())
+	assert.Equal(t, map[string]string{"team": "payments"}, resources[0].Tags)
+	assert.Nil(t, resources[0].TaskDef.Tags)
+	assert.Equal(t, WorkflowDefKind, resources[1].Kind)
+	assert.Equal(t, "WorkflowDef charge v2", resources[1].String())
+	assert.Equal(t, "charge_ref", resources[1].WorkflowDef.Tasks[0].TaskReferenceName)
+
+	resources, err = Parse([]byte(resourcesJson))
+	assert.NoError(t, err)
+	assert.Len(t, resources, 2)
+	assert.Equal(t, "charge", resources[0].Schedule.StartWorkflowRequest.Name)
+	assert.Equal(t, "start_workflow", resources[1].EventHandler.Actions[0].Action)
+
+	_, err = Parse([]byte("kind: Secret\nspec:\n  name: key\n"))
+	assert.ErrorContains(t, err, `unknown kind "Secret"`)
+	_, err = Parse([]byte("spec:\n  name: key\n"))
+	assert.ErrorContains(t, err, "kind is required")
+}
+
+func TestParseNonStringKeys(t *testing.T) {
+	resources, err := Parse([]byte(`
+kind: WorkflowDef
+spec:
+  name: approval
+  version: 1
+  tasks:
+    - name: approved
+      taskReferenceName: approved_ref
+      type: SWITCH
+      evaluatorType: value-param
+      expression: switchCaseValue
+      decisionCases:
+        true:
+          - name: notify
+            taskReferenceName: notify_ref
+            type: SIMPLE
+        1:
+          - name: retry
+            taskReferenceName: retry_ref
+            type: SIMPLE
+`))
+	assert.NoError(t, err)
+	assert.Len(t, resources, 1)
+	decisionCases := resources[0].WorkflowDef.Tasks[0].DecisionCases
+	assert.Equal(t, "notify_ref", decisionCases["true"][0].TaskReferenceName)
+	assert.Equal(t, "retry_ref", decisionCases["1"][0].TaskReferenceName)
+}
+
+func TestLoadDir(t *testing.T) {
+	dir := t.TempDir()
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "schedules"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "charge.yaml"), []byte(resourcesYaml), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "schedules", "all.json"), []byte(resourcesJson), 0644))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "README.md"), []byte("# not a resource"), 0644))
+
+	resources, err := LoadDir(dir)
+	assert.NoError(t, err)
+	assert.Len(t, resources, 4)
+	assert.Equal(t, filepath.Join(dir, "charge.yaml"), resources[0].Source)
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "copy.yml"), []byte(resourcesYaml), 0644))
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "TaskDef charge_card is declared more than once")
+
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "copy.yml"), []byte("kind: Schedule\nspec:\n  name: hourly\n"), 0644))
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "invalid Schedule hourly")
+	assert.ErrorContains(t, err, "cronExpression is required")
+}
+
+func TestFromWorkflow(t *testing.T) {
+	conductorWorkflow := workflow.NewConductorWorkflow(nil).
+		Name("charge").
+		Version(3).
+		Tags(map[string]string{"team": "payments"}).
+		Add(workflow.NewSimpleTask("charge_card", "charge_ref"))
+	resource := FromWorkflow(conductorWorkflow)
+	assert.Equal(t, "WorkflowDef charge v3", resource.String())
+	assert.Equal(t, map[string]string{"team": "payments"}, resource.Tags)
+	assert.Nil(t, resource.WorkflowDef.Tags)
+	assert.NoError(t, resource.Validate())
+
+	assert.ErrorContains(t, FromEventHandler(&model.EventHandler{Name: "on_order", Event: "kafka:orders", Actions: []model.Action{{Action: "start_workflow"}}}).
+		WithTags(map[string]string{"team": "payments"}).Validate(), "event handlers do not support tags")
+}
+
+type fakeServer struct {
+	taskDefs      []model.TaskDef
+	workflowDefs  []model.WorkflowDef
+	schedules     []model.WorkflowScheduleModel
+	eventHandlers []model.EventHandler
+	tags          map[string][]model.TagObject
+	calls         []string
+}
+
+func (server *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodGet {
+		switch {
+		case r.URL.Path == "/metadata/taskdefs":
+			json.NewEncoder(w).Encode(server.taskDefs)
+		case r.URL.Path == "/metadata/workflow":
+			json.NewEncoder(w).Encode(server.workflowDefs)
+		case r.URL.Query().Get("metadata") == "true":
+			json.NewEncoder(w).Encode(model.WorkflowDef{Tags: server.tags[r.URL.Path]})
+		case r.URL.Path == "/scheduler/schedules":
+			json.NewEncoder(w).Encode(server.schedules)
+		case r.URL.Path == "/event":
+			json.NewEncoder(w).Encode(server.eventHandlers)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+		return
+	}
+	body, _ := io.ReadAll(r.Body)
+	server.calls = append(server.calls, strings.TrimSpace(r.Method+" "+r.URL.Path+" "+string(body)))
+	w.Write([]byte("{}"))
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{
+		taskDefs: []model.TaskDef{
+			{Name: "charge_card", RetryCount: 3, TimeoutSeconds: 60, RetryLogic: "FIXED", CreatedBy: "admin"},
+			{Name: "legacy_task", TimeoutSeconds: 60},
+		},
+		workflowDefs: []model.WorkflowDef{
+			{Name: "charge", Version: 1, TimeoutSeconds: 60},
+			{Name: "charge", Version: 2, TimeoutSeconds: 60, UpdateTime: 1700000000000, Tasks: []model.WorkflowTask{
+				{Name: "charge_card", TaskReferenceName: "charge_ref", Type_: "SIMPLE"},
+			}},
+			{Name: "legacy", Version: 1},
+		},
+		schedules: []model.WorkflowScheduleModel{
+			{Name: "nightly", CronExpression: "0 0 0 * * ?", ZoneId: "UTC", StartWorkflowRequest: &model.StartWorkflowRequest{Name: "charge", Version: 2},
+				Tags: []model.Tag{{Key: "team", Value: "billing"}}},
+		},
+		eventHandlers: []model.EventHandler{
+			{Name: "on_order", Event: "kafka:orders", Active: true, EvaluatorType: "javascript",
+				Actions: []model.Action{{Action: "start_workflow", StartWorkflow: &model.StartWorkflow{Name: "charge", Version: 2}}}},
+			{Name: "obsolete", Event: "sqs:old"},
+		},
+		tags: map[string][]model.TagObject{
+			"/metadata/taskdefs/charge_card": {{Key: "team", Value: "payments"}},
+			"/metadata/workflow/charge":      {{Key: "team", Value: "payments"}},
+		},
+	}
+}
+
+func TestPlanAndApply(t *testing.T) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	applier := NewApplier(client.NewMetadataClient(apiClient), client.NewSchedulerClient(apiClient), client.NewEventHandlerClient(apiClient))
+
+	yamlResources, err := Parse([]byte(resourcesYaml))
+	assert.NoError(t, err)
+	jsonResources, err := Parse([]byte(resourcesJson))
+	assert.NoError(t, err)
+	jsonResources[0].Tags = map[string]string{"team": "payments"}
+	resources := append(jsonResources, yamlResources...)
+	resources = append(resources, FromTaskDef(&model.TaskDef{Name: "refund_card", TimeoutSeconds: 60}))
+
+	plan, err := applier.Plan(context.Background(), resources, PlanOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.True(t, plan.HasChanges())
+	assert.Equal(t, `~ TaskDef charge_card
+    timeoutSeconds: 60 -> 120
++ TaskDef refund_card
+~ Schedule nightly
+    tags: {"team":"billing"} -> {"team":"payments"}
+- EventHandler obsolete
+- WorkflowDef legacy v1
+- TaskDef legacy_task
+1 to create, 2 to update, 3 to delete, 2 unchanged
+`, plan.String())
+	assert.Empty(t, fake.calls)
+
+	result, err := applier.Apply(context.Background(), plan)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"TaskDef refund_card"}, result.Created)
+	assert.Equal(t, []string{"TaskDef charge_card", "Schedule nightly"}, result.Updated)
+	assert.Equal(t, []string{"EventHandler obsolete", "WorkflowDef legacy v1", "TaskDef legacy_task"}, result.Deleted)
+	assert.Equal(t, []string{"WorkflowDef charge v2", "EventHandler on_order"}, result.Unchanged)
+	assert.Len(t, fake.calls, 8)
+	assert.True(t, strings.HasPrefix(fake.calls[0], `PUT /metadata/taskdefs {"name":"charge_card"`))
+	assert.Contains(t, fake.calls[0], `"tags":[{"key":"team","type":"METADATA","value":"payments"}]`)
+	assert.True(t, strings.HasPrefix(fake.calls[1], `POST /metadata/taskdefs [{"name":"refund_card"`))
+	assert.True(t, strings.HasPrefix(fake.calls[2], `POST /scheduler/schedules {`))
+	assert.Equal(t, []string{
+		`DELETE /scheduler/schedules/nightly/tags [{"key":"team","type":"METADATA","value":"billing"}]`,
+		`PUT /scheduler/schedules/nightly/tags [{"key":"team","type":"METADATA","value":"payments"}]`,
+		"DELETE /event/obsolete",
+		"DELETE /metadata/workflow/legacy/1",
+		"DELETE /metadata/taskdefs/legacy_task",
+	}, fake.calls[3:])
+}
+
+func TestPlanWithoutPrune(t *testing.T) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	applier := NewApplier(client.NewMetadataClient(apiClient), nil, nil)
+
+	plan, err := applier.Plan(context.Background(), []*Resource{
+		FromWorkflowDef(&model.WorkflowDef{Name: "charge", Version: 2, TimeoutSeconds: 60, Tasks: []model.WorkflowTask{
+			{Name: "validate", TaskReferenceName: "validate_ref", Type_: "SIMPLE"},
+			{Name: "charge_card", TaskReferenceName: "charge_ref", Type_: "SIMPLE", Optional: true},
+		}}),
+	}, PlanOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(Update))
+	assert.Equal(t, []Difference{
+		{Property: "task validate_ref", Desired: "tasks[0]"},
+		{Property: "charge_ref.optional", Desired: true},
+	}, plan.Changes[0].Differences)
+
+	_, err = applier.Plan(context.Background(), []*Resource{FromSchedule(&model.SaveScheduleRequest{Name: "nightly", CronExpression: "0 0 0 * * ?",
+		StartWorkflowRequest: &model.StartWorkflowRequest{Name: "charge"}})}, PlanOptions{})
+	assert.ErrorContains(t, err, "no client for the Schedule resources")
+
+	data, err := json.Marshal(plan)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"action":"UPDATE","kind":"WorkflowDef","name":"charge","version":2`)
+}
+
+func TestPruneOnlyDeclaredKinds(t *testing.T) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	applier := NewApplier(client.NewMetadataClient(apiClient), client.NewSchedulerClient(apiClient), client.NewEventHandlerClient(apiClient))
+	resources := []*Resource{FromWorkflowDef(&model.WorkflowDef{Name: "charge", Version: 2, TimeoutSeconds: 60, Tasks: []model.WorkflowTask{
+		{Name: "charge_card", TaskReferenceName: "charge_ref", Type_: "SIMPLE"},
+	}})}
+
+	plan, err := applier.Plan(context.Background(), resources, PlanOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, "- WorkflowDef legacy v1\n0 to create, 0 to update, 1 to delete, 1 unchanged\n", plan.String())
+	for _, change := range plan.Changes {
+		assert.NotEqual(t, TaskDefKind, change.Kind)
+	}
+
+	plan, err = applier.Plan(context.Background(), resources, PlanOptions{PruneKinds: []Kind{TaskDefKind}})
+	assert.NoError(t, err)
+	assert.Equal(t, "- TaskDef charge_card\n- TaskDef legacy_task\n0 to create, 0 to update, 2 to delete, 1 unchanged\n", plan.String())
+}
+
+func TestPlanDeclaredZeroValues(t *testing.T) {
+	fake := newFakeServer()
+	fake.workflowDefs[1].Restartable = true
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	applier := NewApplier(client.NewMetadataClient(apiClient), client.NewSchedulerClient(apiClient), client.NewEventHandlerClient(apiClient))
+
+	resources, err := Parse([]byte(`
+kind: TaskDef
+spec:
+  name: charge_card
+  retryCount: 0
+  timeoutSeconds: 60
+  retryLogic: FIXED
+  description: ""
+---
+kind: WorkflowDef
+spec:
+  name: charge
+  version: 2
+  timeoutSeconds: 60
+  restartable: false
+  tasks:
+    - name: charge_card
+      taskReferenceName: charge_ref
+      type: SIMPLE
+---
+kind: EventHandler
+spec:
+  name: on_order
+  event: kafka:orders
+  active: false
+  actions:
+    - action: start_workflow
+      start_workflow:
+        name: charge
+        version: 2
+`))
+	assert.NoError(t, err)
+	plan, err := applier.Plan(context.Background(), resources, PlanOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `~ TaskDef charge_card
+    retryCount: 3 -> 0
+~ WorkflowDef charge v2
+    restartable: true -> false
+~ EventHandler on_order
+    active: true -> false
+0 to create, 3 to update, 0 to delete, 0 unchanged
+`, plan.String())
+
+	// the same definitions built in Go leave the zero values to the server
+	plan, err = applier.Plan(context.Background(), []*Resource{
+		FromTaskDef(&model.TaskDef{Name: "charge_card", RetryCount: 0, TimeoutSeconds: 60}),
+		FromEventHandler(&model.EventHandler{Name: "on_order", Event: "kafka:orders", Active: false,
+			Actions: []model.Action{{Action: "start_workflow", StartWorkflow: &model.StartWorkflow{Name: "charge", Version: 2}}}}),
+	}, PlanOptions{})
+	assert.NoError(t, err)
+	assert.False(t, plan.HasChanges())
+}
+
+func TestPlanSchedulesAsSync(t *testing.T) {
+	fake := newFakeServer()
+	fake.schedules = append(fake.schedules, model.WorkflowScheduleModel{Name: "weekly", CronExpression: "0 0 0 ? * MON", ZoneId: "Europe/Paris",
+		StartWorkflowRequest: &model.StartWorkflowRequest{Name: "charge", Version: 2, Input: map[string]interface{}{}}})
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	schedulerClient := client.NewSchedulerClient(apiClient)
+	applier := NewApplier(nil, schedulerClient, nil)
+
+	// saving the schedules without zone resets the zone of the server to UTC
+	desired := []*scheduler.Schedule{
+		scheduler.NewSchedule("nightly").Cron("0 0 0 * * ?").StartsRequest(&model.StartWorkflowRequest{Name: "charge", Version: 2}),
+		scheduler.NewSchedule("weekly").Cron("0 0 0 ? * MON").StartsRequest(&model.StartWorkflowRequest{Name: "charge", Version: 2}),
+	}
+	resources := make([]*Resource, 0, len(desired))
+	for _, schedule := range desired {
+		request, err := schedule.ToSaveScheduleRequest()
+		assert.NoError(t, err)
+		resources = append(resources, FromSchedule(request))
+	}
+	plan, err := applier.Plan(context.Background(), resources, PlanOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, `~ Schedule weekly
+    zoneId: "Europe/Paris" -> "UTC"
+0 to create, 1 to update, 0 to delete, 1 unchanged
+`, plan.String())
+
+	result, err := scheduler.Sync(context.Background(), schedulerClient, desired, scheduler.SyncOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"weekly"}, result.Updated)
+	assert.Equal(t, []string{"nightly"}, result.Unchanged)
+}
+
+func TestPlanEventHandlersAsSync(t *testing.T) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	eventHandlerClient := client.NewEventHandlerClient(apiClient)
+	applier := NewApplier(nil, nil, eventHandlerClient)
+
+	// the 0 of the input is compared with the server
+	handler := event.NewEventHandler("on_order", event.KafkaSink("orders")).
+		EvaluatorType("javascript").
+		Action(event.StartWorkflow("charge", 2).Input("attempt", 0))
+	eventHandler, err := handler.ToEventHandler()
+	assert.NoError(t, err)
+	plan, err := applier.Plan(context.Background(), []*Resource{FromEventHandler(eventHandler)}, PlanOptions{})
+	assert.NoError(t, err)
+	assert.Equal(t, 1, plan.Count(Update))
+	assert.Equal(t, "actions", plan.Changes[0].Differences[0].Property)
+
+	result, err := event.Sync(context.Background(), eventHandlerClient, []*event.EventHandler{handler}, event.SyncOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"on_order"}, result.Updated)
+}
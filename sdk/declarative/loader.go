@@ -0,0 +1,223 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package declarative
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// LoadDir loads the resources of all the .json, .yaml and .yml files in the directory and its subdirectories.
+// Each document declares its kind, the optional tags and the definition as the spec:
+//
+//	kind: WorkflowDef
+//	tags:
+//	  team: payments
+//	spec:
+//	  name: charge_card
+//	  version: 1
+//	  tasks: [...]
+//
+// A file may contain several YAML documents separated by --- or a JSON array of documents.
+// The resources are validated and must be unique
+func LoadDir(dir string) ([]*Resource, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && isResourceFile(path) {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	var resources []*Resource
+	for _, file := range files {
+		loaded, err := LoadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		resources = append(resources, loaded...)
+	}
+	if err := checkUnique(resources); err != nil {
+		return nil, err
+	}
+	return resources, nil
+}
+
+// LoadFile loads the resources of a single JSON or YAML file, see LoadDir for the format
+func LoadFile(path string) ([]*Resource, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	resources, err := Parse(data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	for _, resource := range resources {
+		resource.Source = path
+	}
+	return resources, nil
+}
+
+// Parse reads the resources of JSON or YAML documents, see LoadDir for the format
+func Parse(data []byte) ([]*Resource, error) {
+	var resources []*Resource
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	for {
+		var document interface{}
+		err := decoder.Decode(&document)
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+		documents, isList := document.([]interface{})
+		if !isList {
+			documents = []interface{}{document}
+		}
+		for _, item := range documents {
+			if item == nil {
+				continue
+			}
+			resource, err := parseDocument(item)
+			if err != nil {
+				return nil, fmt.Errorf("document %d: %w", len(resources)+1, err)
+			}
+			resources = append(resources, resource)
+		}
+	}
+	return resources, nil
+}
+
+type document struct {
+	Kind string            `json:"kind"`
+	Tags map[string]string `json:"tags"`
+	Spec json.RawMessage   `json:"spec"`
+}
+
+func parseDocument(item interface{}) (*Resource, error) {
+	data, err := json.Marshal(withStringKeys(item))
+	if err != nil {
+		return nil, err
+	}
+	var doc document
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if doc.Kind == "" {
+		return nil, errors.New("kind is required")
+	}
+	if len(doc.Spec) == 0 {
+		return nil, errors.New("spec is required")
+	}
+	kind, err := parseKind(doc.Kind)
+	if err != nil {
+		return nil, err
+	}
+	resource := &Resource{Kind: kind, Tags: doc.Tags}
+	switch kind {
+	case TaskDefKind:
+		err = json.Unmarshal(doc.Spec, &resource.TaskDef)
+		if err == nil && resource.TaskDef != nil {
+			if resource.Tags == nil && len(resource.TaskDef.Tags) > 0 {
+				resource.Tags = tagObjectsToMap(resource.TaskDef.Tags)
+			}
+			resource.TaskDef.Tags = nil
+		}
+	case WorkflowDefKind:
+		err = json.Unmarshal(doc.Spec, &resource.WorkflowDef)
+		if err == nil && resource.WorkflowDef != nil {
+			if resource.Tags == nil && len(resource.WorkflowDef.Tags) > 0 {
+				resource.Tags = tagObjectsToMap(resource.WorkflowDef.Tags)
+			}
+			resource.WorkflowDef.Tags = nil
+		}
+	case ScheduleKind:
+		err = json.Unmarshal(doc.Spec, &resource.Schedule)
+	case EventHandlerKind:
+		err = json.Unmarshal(doc.Spec, &resource.EventHandler)
+	}
+	if err == nil {
+		err = json.Unmarshal(doc.Spec, &resource.declared)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s spec: %w", kind, err)
+	}
+	return resource, nil
+}
+
+// withStringKeys converts the keys of the YAML mappings to strings, YAML decodes the keys such as true: or 1: of the
+// switch cases as booleans and numbers which cannot be encoded to JSON
+func withStringKeys(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[interface{}]interface{}:
+		converted := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			converted[fmt.Sprint(key)] = withStringKeys(item)
+		}
+		return converted
+	case map[string]interface{}:
+		for key, item := range typed {
+			typed[key] = withStringKeys(item)
+		}
+	case []interface{}:
+		for i, item := range typed {
+			typed[i] = withStringKeys(item)
+		}
+	}
+	return value
+}
+
+func isResourceFile(path string) bool {
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json", ".yaml", ".yml":
+		return true
+	}
+	return false
+}
+
+// checkUnique validates the resources and rejects the resources declared more than once
+func checkUnique(resources []*Resource) error {
+	seen := make(map[string]*Resource, len(resources))
+	for _, resource := range resources {
+		if err := resource.Validate(); err != nil {
+			return err
+		}
+		if previous, found := seen[resource.key()]; found {
+			return fmt.Errorf("%s is declared more than once: %s and %s", resource, sourceOf(previous), sourceOf(resource))
+		}
+		seen[resource.key()] = resource
+	}
+	return nil
+}
+
+func sourceOf(resource *Resource) string {
+	if resource.Source == "" {
+		return "(code)"
+	}
+	return resource.Source
+}
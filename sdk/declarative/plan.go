@@ -0,0 +1,374 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package declarative
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+type Action string
+
+const (
+	Create   Action = "CREATE"
+	Update   Action = "UPDATE"
+	Delete   Action = "DELETE"
+	NoChange Action = "NO_CHANGE"
+)
+
+// Change the action on a single resource.  Differences lists the properties that differ from the server for updates
+type Change struct {
+	Action      Action       `json:"action"`
+	Kind        Kind         `json:"kind"`
+	Name        string       `json:"name"`
+	Version     int32        `json:"version,omitempty"`
+	Source      string       `json:"source,omitempty"`
+	Differences []Difference `json:"differences,omitempty"`
+
+	resource *Resource
+	// tags on the server, replaced when the resource declares different tags
+	currentTags map[string]string
+}
+
+func (change *Change) String() string {
+	return describe(change.Kind, change.Name, change.Version)
+}
+
+// Plan the changes turning the state of the server into the declared resources.  The creates and updates come first,
+// ordered so that the resources are created after the resources they depend on: task definitions, workflows, schedules
+// and event handlers.  The deletes follow in the reverse order.
+// Serializes to JSON, String returns the human-readable report
+type Plan struct {
+	Changes []Change `json:"changes"`
+}
+
+// HasChanges returns true if applying the plan changes the server, i.e. the server drifted from the declared resources
+func (plan *Plan) HasChanges() bool {
+	for _, change := range plan.Changes {
+		if change.Action != NoChange {
+			return true
+		}
+	}
+	return false
+}
+
+// Count the number of changes with the action
+func (plan *Plan) Count(action Action) int {
+	count := 0
+	for _, change := range plan.Changes {
+		if change.Action == action {
+			count++
+		}
+	}
+	return count
+}
+
+// String the changes, one per line followed by the properties that differ, and a summary line
+func (plan *Plan) String() string {
+	var builder strings.Builder
+	for _, change := range plan.Changes {
+		switch change.Action {
+		case Create:
+			fmt.Fprintf(&builder, "+ %s\n", change.String())
+		case Update:
+			fmt.Fprintf(&builder, "~ %s\n", change.String())
+			for _, difference := range change.Differences {
+				fmt.Fprintf(&builder, "    %s: %s -> %s\n", difference.Property, internal.FormatValue(difference.Current), internal.FormatValue(difference.Desired))
+			}
+		case Delete:
+			fmt.Fprintf(&builder, "- %s\n", change.String())
+		}
+	}
+	fmt.Fprintf(&builder, "%d to create, %d to update, %d to delete, %d unchanged\n",
+		plan.Count(Create), plan.Count(Update), plan.Count(Delete), plan.Count(NoChange))
+	return builder.String()
+}
+
+// PlanOptions controls how the plan is computed
+type PlanOptions struct {
+	// Prune plans the deletion of the resources on the server that are not declared, for the kinds with at least one
+	// declared resource: files declaring only workflows never delete the task definitions.  For workflows only the
+	// definitions whose name is not declared at all are deleted: the other versions of the declared workflows are kept
+	// as executions may still reference them
+	Prune bool
+	// PruneKinds the kinds pruned even when no resource of the kind is declared, i.e. all their resources on the server
+	// are deleted.  Only the kinds with a client are pruned
+	PruneKinds []Kind
+}
+
+func (options PlanOptions) prunes(kind Kind, declared bool) bool {
+	if options.Prune && declared {
+		return true
+	}
+	for _, pruneKind := range options.PruneKinds {
+		if pruneKind == kind {
+			return true
+		}
+	}
+	return false
+}
+
+// Applier computes and applies the plans.  A nil client leaves the kinds it manages out of the plans: the metadata
+// client manages the task and workflow definitions, the scheduler client the schedules and the event handler client
+// the event handlers
+type Applier struct {
+	metadataClient     client.MetadataClient
+	schedulerClient    client.SchedulerClient
+	eventHandlerClient client.EventHandlerClient
+}
+
+func NewApplier(metadataClient client.MetadataClient, schedulerClient client.SchedulerClient, eventHandlerClient client.EventHandlerClient) *Applier {
+	return &Applier{
+		metadataClient:     metadataClient,
+		schedulerClient:    schedulerClient,
+		eventHandlerClient: eventHandlerClient,
+	}
+}
+
+func (applier *Applier) manages(kind Kind) bool {
+	switch kind {
+	case TaskDefKind, WorkflowDefKind:
+		return applier.metadataClient != nil
+	case ScheduleKind:
+		return applier.schedulerClient != nil
+	case EventHandlerKind:
+		return applier.eventHandlerClient != nil
+	}
+	return false
+}
+
+// Plan compares the resources with the server without changing anything, e.g. to review the changes or to detect
+// drift in CI.  The resources are validated first and must be unique
+func (applier *Applier) Plan(ctx context.Context, resources []*Resource, options PlanOptions) (*Plan, error) {
+	if err := checkUnique(resources); err != nil {
+		return nil, err
+	}
+	desired := make(map[Kind][]*Resource, len(kinds))
+	for _, resource := range resources {
+		if !applier.manages(resource.Kind) {
+			return nil, fmt.Errorf("%s: no client for the %s resources", resource, resource.Kind)
+		}
+		desired[resource.Kind] = append(desired[resource.Kind], resource)
+	}
+	plan := &Plan{Changes: []Change{}}
+	var deletes []Change
+	for _, kind := range kinds {
+		prune := options.prunes(kind, len(desired[kind]) > 0)
+		if !applier.manages(kind) || (len(desired[kind]) == 0 && !prune) {
+			continue
+		}
+		sort.Slice(desired[kind], func(i, j int) bool {
+			a, b := desired[kind][i], desired[kind][j]
+			if a.Name() != b.Name() {
+				return a.Name() < b.Name()
+			}
+			return a.Version() < b.Version()
+		})
+		var changes, kindDeletes []Change
+		var err error
+		switch kind {
+		case TaskDefKind:
+			changes, kindDeletes, err = applier.planTaskDefs(ctx, desired[kind], prune)
+		case WorkflowDefKind:
+			changes, kindDeletes, err = applier.planWorkflowDefs(ctx, desired[kind], prune)
+		case ScheduleKind:
+			changes, kindDeletes, err = applier.planSchedules(ctx, desired[kind], prune)
+		case EventHandlerKind:
+			changes, kindDeletes, err = applier.planEventHandlers(ctx, desired[kind], prune)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the %s resources: %w", kind, err)
+		}
+		plan.Changes = append(plan.Changes, changes...)
+		deletes = append(kindDeletes, deletes...)
+	}
+	plan.Changes = append(plan.Changes, deletes...)
+	return plan, nil
+}
+
+func newChange(resource *Resource, differences []Difference) Change {
+	change := Change{
+		Action:   Create,
+		Kind:     resource.Kind,
+		Name:     resource.Name(),
+		Version:  resource.Version(),
+		Source:   resource.Source,
+		resource: resource,
+	}
+	if differences != nil {
+		change.Action = NoChange
+		if len(differences) > 0 {
+			change.Action = Update
+			change.Differences = differences
+		}
+	}
+	return change
+}
+
+func (applier *Applier) planTaskDefs(ctx context.Context, resources []*Resource, prune bool) ([]Change, []Change, error) {
+	taskDefs, _, err := applier.metadataClient.GetTaskDefs(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := make(map[string]model.TaskDef, len(taskDefs))
+	for _, taskDef := range taskDefs {
+		current[taskDef.Name] = taskDef
+	}
+	changes := make([]Change, 0, len(resources))
+	declared := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		declared[resource.Name()] = true
+		taskDef, found := current[resource.Name()]
+		if !found {
+			changes = append(changes, newChange(resource, nil))
+			continue
+		}
+		differences := diffDeclared(toProperties(taskDef, internal.IsZero), toProperties(resource.TaskDef, internal.IsZero), resource.declared, internal.IsZero)
+		if resource.Tags != nil {
+			tags, err := applier.metadataClient.GetTagsForTaskDef(ctx, resource.Name())
+			if err != nil {
+				return nil, nil, err
+			}
+			differences = append(differences, diffTags(metadataTagsToMap(tags), resource.Tags)...)
+		}
+		changes = append(changes, newChange(resource, differences))
+	}
+	var deletes []Change
+	if prune {
+		for _, name := range sortedKeys(current) {
+			if !declared[name] {
+				deletes = append(deletes, Change{Action: Delete, Kind: TaskDefKind, Name: name})
+			}
+		}
+	}
+	return changes, deletes, nil
+}
+
+func (applier *Applier) planWorkflowDefs(ctx context.Context, resources []*Resource, prune bool) ([]Change, []Change, error) {
+	workflowDefs, _, err := applier.metadataClient.GetAll(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := make(map[string]model.WorkflowDef, len(workflowDefs))
+	for _, workflowDef := range workflowDefs {
+		current[describe(WorkflowDefKind, workflowDef.Name, workflowDef.Version)] = workflowDef
+	}
+	changes := make([]Change, 0, len(resources))
+	declared := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		declared[resource.Name()] = true
+		workflowDef, found := current[resource.key()]
+		if !found {
+			changes = append(changes, newChange(resource, nil))
+			continue
+		}
+		differences := diffWorkflowDefs(workflowDef, *resource.WorkflowDef, resource.declared)
+		if resource.Tags != nil {
+			tags, err := applier.metadataClient.GetTagsForWorkflowDef(ctx, resource.Name())
+			if err != nil {
+				return nil, nil, err
+			}
+			differences = append(differences, diffTags(metadataTagsToMap(tags), resource.Tags)...)
+		}
+		changes = append(changes, newChange(resource, differences))
+	}
+	var deletes []Change
+	if prune {
+		for _, key := range sortedKeys(current) {
+			workflowDef := current[key]
+			if !declared[workflowDef.Name] {
+				deletes = append(deletes, Change{Action: Delete, Kind: WorkflowDefKind, Name: workflowDef.Name, Version: workflowDef.Version})
+			}
+		}
+		sort.SliceStable(deletes, func(i, j int) bool {
+			if deletes[i].Name != deletes[j].Name {
+				return deletes[i].Name < deletes[j].Name
+			}
+			return deletes[i].Version < deletes[j].Version
+		})
+	}
+	return changes, deletes, nil
+}
+
+func (applier *Applier) planSchedules(ctx context.Context, resources []*Resource, prune bool) ([]Change, []Change, error) {
+	schedules, _, err := applier.schedulerClient.GetAllSchedules(ctx, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := make(map[string]model.WorkflowScheduleModel, len(schedules))
+	for _, schedule := range schedules {
+		current[schedule.Name] = schedule
+	}
+	changes := make([]Change, 0, len(resources))
+	declared := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		declared[resource.Name()] = true
+		schedule, found := current[resource.Name()]
+		if !found {
+			changes = append(changes, newChange(resource, nil))
+			continue
+		}
+		currentTags := tagsToMap(schedule.Tags)
+		// compared as by scheduler.Sync, the zone defaults to the one of the server
+		properties := diffDeclared(internal.ScheduleProperties(schedule), internal.ScheduleProperties(resource.Schedule), resource.declared, internal.IsZero)
+		differences := append(properties, diffTags(currentTags, resource.Tags)...)
+		change := newChange(resource, differences)
+		change.currentTags = currentTags
+		changes = append(changes, change)
+	}
+	var deletes []Change
+	if prune {
+		for _, name := range sortedKeys(current) {
+			if !declared[name] {
+				deletes = append(deletes, Change{Action: Delete, Kind: ScheduleKind, Name: name})
+			}
+		}
+	}
+	return changes, deletes, nil
+}
+
+func (applier *Applier) planEventHandlers(ctx context.Context, resources []*Resource, prune bool) ([]Change, []Change, error) {
+	eventHandlers, _, err := applier.eventHandlerClient.GetEventHandlers(ctx)
+	if err != nil {
+		return nil, nil, err
+	}
+	current := make(map[string]model.EventHandler, len(eventHandlers))
+	for _, eventHandler := range eventHandlers {
+		current[eventHandler.Name] = eventHandler
+	}
+	changes := make([]Change, 0, len(resources))
+	declared := make(map[string]bool, len(resources))
+	for _, resource := range resources {
+		declared[resource.Name()] = true
+		eventHandler, found := current[resource.Name()]
+		if !found {
+			changes = append(changes, newChange(resource, nil))
+			continue
+		}
+		// compared as by event.Sync, 0 is not considered empty
+		differences := diffDeclared(toProperties(eventHandler, internal.IsEmpty), toProperties(resource.EventHandler, internal.IsEmpty), resource.declared, internal.IsEmpty)
+		changes = append(changes, newChange(resource, differences))
+	}
+	var deletes []Change
+	if prune {
+		for _, name := range sortedKeys(current) {
+			if !declared[name] {
+				deletes = append(deletes, Change{Action: Delete, Kind: EventHandlerKind, Name: name})
+			}
+		}
+	}
+	return changes, deletes, nil
+}
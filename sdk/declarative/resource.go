@@ -0,0 +1,239 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package declarative
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow"
+)
+
+type Kind string
+
+const (
+	TaskDefKind      Kind = "TaskDef"
+	WorkflowDefKind  Kind = "WorkflowDef"
+	ScheduleKind     Kind = "Schedule"
+	EventHandlerKind Kind = "EventHandler"
+)
+
+// kinds in the order they are created, resources only reference the kinds before them, e.g. a workflow references
+// the task definitions and a schedule the workflow.  Deletes happen in the reverse order
+var kinds = []Kind{TaskDefKind, WorkflowDefKind, ScheduleKind, EventHandlerKind}
+
+func parseKind(value string) (Kind, error) {
+	for _, kind := range kinds {
+		if strings.EqualFold(string(kind), value) {
+			return kind, nil
+		}
+	}
+	return "", fmt.Errorf("unknown kind %q, expected one of %v", value, kinds)
+}
+
+func (kind Kind) order() int {
+	for i, candidate := range kinds {
+		if candidate == kind {
+			return i
+		}
+	}
+	return len(kinds)
+}
+
+// Resource a definition managed declaratively, exactly one of the definitions is set according to the Kind.
+// Tags, when not nil, are the complete set of tags of the resource: tags missing from the map are removed on apply
+type Resource struct {
+	Kind         Kind
+	TaskDef      *model.TaskDef
+	WorkflowDef  *model.WorkflowDef
+	Schedule     *model.SaveScheduleRequest
+	EventHandler *model.EventHandler
+	Tags         map[string]string
+	// Source the file the resource was loaded from, empty for the resources built in Go
+	Source string
+
+	// the properties set in the spec of the file, nil for the resources built in Go
+	declared map[string]interface{}
+}
+
+func FromTaskDef(taskDef *model.TaskDef) *Resource {
+	return &Resource{Kind: TaskDefKind, TaskDef: taskDef}
+}
+
+// FromWorkflowDef the workflow definition, the version defaults to 1 as on the server
+func FromWorkflowDef(workflowDef *model.WorkflowDef) *Resource {
+	return &Resource{Kind: WorkflowDefKind, WorkflowDef: workflowDef}
+}
+
+// FromWorkflow the definition of the workflow, the tags of the workflow become the tags of the resource
+func FromWorkflow(conductorWorkflow *workflow.ConductorWorkflow) *Resource {
+	workflowDef := conductorWorkflow.ToWorkflowDef()
+	resource := FromWorkflowDef(workflowDef)
+	if len(workflowDef.Tags) > 0 {
+		resource.Tags = tagObjectsToMap(workflowDef.Tags)
+	}
+	workflowDef.Tags = nil
+	return resource
+}
+
+func FromSchedule(schedule *model.SaveScheduleRequest) *Resource {
+	return &Resource{Kind: ScheduleKind, Schedule: schedule}
+}
+
+func FromEventHandler(eventHandler *model.EventHandler) *Resource {
+	return &Resource{Kind: EventHandlerKind, EventHandler: eventHandler}
+}
+
+// WithTags sets the tags of the resource, event handlers do not support tags
+func (resource *Resource) WithTags(tags map[string]string) *Resource {
+	resource.Tags = tags
+	return resource
+}
+
+// Name the name of the definition
+func (resource *Resource) Name() string {
+	switch resource.Kind {
+	case TaskDefKind:
+		if resource.TaskDef != nil {
+			return resource.TaskDef.Name
+		}
+	case WorkflowDefKind:
+		if resource.WorkflowDef != nil {
+			return resource.WorkflowDef.Name
+		}
+	case ScheduleKind:
+		if resource.Schedule != nil {
+			return resource.Schedule.Name
+		}
+	case EventHandlerKind:
+		if resource.EventHandler != nil {
+			return resource.EventHandler.Name
+		}
+	}
+	return ""
+}
+
+// Version the version of the workflow definition, 0 for the other kinds
+func (resource *Resource) Version() int32 {
+	if resource.Kind != WorkflowDefKind || resource.WorkflowDef == nil {
+		return 0
+	}
+	if resource.WorkflowDef.Version == 0 {
+		return 1
+	}
+	return resource.WorkflowDef.Version
+}
+
+// String identifies the resource, e.g. WorkflowDef charge_card v2
+func (resource *Resource) String() string {
+	return describe(resource.Kind, resource.Name(), resource.Version())
+}
+
+func describe(kind Kind, name string, version int32) string {
+	if kind == WorkflowDefKind {
+		return fmt.Sprintf("%s %s v%d", kind, name, version)
+	}
+	return fmt.Sprintf("%s %s", kind, name)
+}
+
+func (resource *Resource) key() string {
+	return resource.String()
+}
+
+// Validate checks the resource offline, e.g. before computing the plan
+func (resource *Resource) Validate() error {
+	var problems []string
+	var definitions int
+	for _, set := range []bool{resource.TaskDef != nil, resource.WorkflowDef != nil, resource.Schedule != nil, resource.EventHandler != nil} {
+		if set {
+			definitions++
+		}
+	}
+	if definitions != 1 {
+		problems = append(problems, "exactly one definition is required")
+	} else if resource.Name() == "" {
+		problems = append(problems, "name is required")
+	}
+	switch resource.Kind {
+	case TaskDefKind, WorkflowDefKind:
+	case ScheduleKind:
+		if resource.Schedule != nil {
+			if resource.Schedule.CronExpression == "" {
+				problems = append(problems, "cronExpression is required")
+			}
+			if resource.Schedule.StartWorkflowRequest == nil || resource.Schedule.StartWorkflowRequest.Name == "" {
+				problems = append(problems, "startWorkflowRequest.name is required")
+			}
+		}
+	case EventHandlerKind:
+		if resource.EventHandler != nil {
+			if resource.EventHandler.Event == "" {
+				problems = append(problems, "event is required")
+			}
+			if len(resource.EventHandler.Actions) == 0 {
+				problems = append(problems, "at least one action is required")
+			}
+		}
+		if resource.Tags != nil {
+			problems = append(problems, "event handlers do not support tags")
+		}
+	default:
+		problems = append(problems, fmt.Sprintf("unknown kind %q", resource.Kind))
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	location := resource.String()
+	if resource.Source != "" {
+		location += " (" + resource.Source + ")"
+	}
+	return fmt.Errorf("invalid %s: %s", location, strings.Join(problems, ", "))
+}
+
+func tagObjectsToMap(tags []model.TagObject) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
+
+func metadataTagsToMap(tags []model.MetadataTag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
+
+func tagsToMap(tags []model.Tag) map[string]string {
+	result := make(map[string]string, len(tags))
+	for _, tag := range tags {
+		result[tag.Key] = tag.Value
+	}
+	return result
+}
+
+func toMetadataTags(tags map[string]string) []model.MetadataTag {
+	result := make([]model.MetadataTag, 0, len(tags))
+	for _, key := range sortedKeys(tags) {
+		result = append(result, model.MetadataTag{Key: key, Value: tags[key]})
+	}
+	return result
+}
+
+func toTags(tags map[string]string) []model.Tag {
+	result := make([]model.Tag, 0, len(tags))
+	for _, key := range sortedKeys(tags) {
+		result = append(result, model.Tag{Key: key, Value: tags[key], Type_: "METADATA"})
+	}
+	return result
+}
@@ -0,0 +1,262 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package event
+
+import (
+	"fmt"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// Action executed by the event handler when an event matches its condition.  The values of the actions are
+// templates resolved against the payload of the event, e.g. "${workflowId}" or "${$.order.id}"
+type Action interface {
+	toAction() model.Action
+	validate() []string
+}
+
+// StartWorkflowAction starts a workflow for each event
+type StartWorkflowAction struct {
+	startWorkflow    model.StartWorkflow
+	expandInlineJson bool
+}
+
+// StartWorkflow the start_workflow action, a version of 0 starts the latest version
+func StartWorkflow(name string, version int32) *StartWorkflowAction {
+	return &StartWorkflowAction{
+		startWorkflow: model.StartWorkflow{
+			Name:    name,
+			Version: version,
+			Input:   map[string]interface{}{},
+		},
+	}
+}
+
+// Input of the workflow
+func (action *StartWorkflowAction) Input(key string, value interface{}) *StartWorkflowAction {
+	action.startWorkflow.Input[key] = value
+	return action
+}
+
+// InputMap of the workflow
+func (action *StartWorkflowAction) InputMap(inputMap map[string]interface{}) *StartWorkflowAction {
+	for k, v := range inputMap {
+		action.startWorkflow.Input[k] = v
+	}
+	return action
+}
+
+func (action *StartWorkflowAction) CorrelationId(correlationId string) *StartWorkflowAction {
+	action.startWorkflow.CorrelationId = correlationId
+	return action
+}
+
+func (action *StartWorkflowAction) TaskToDomain(taskToDomain map[string]string) *StartWorkflowAction {
+	action.startWorkflow.TaskToDomain = taskToDomain
+	return action
+}
+
+// ExpandInlineJSON parses the JSON strings of the payload before resolving the templates
+func (action *StartWorkflowAction) ExpandInlineJSON(expandInlineJson bool) *StartWorkflowAction {
+	action.expandInlineJson = expandInlineJson
+	return action
+}
+
+func (action *StartWorkflowAction) toAction() model.Action {
+	startWorkflow := action.startWorkflow
+	return model.Action{
+		Action:           "start_workflow",
+		StartWorkflow:    &startWorkflow,
+		ExpandInlineJSON: action.expandInlineJson,
+	}
+}
+
+func (action *StartWorkflowAction) validate() []string {
+	problems := make([]string, 0)
+	if action.startWorkflow.Name == "" {
+		problems = append(problems, "start_workflow requires the name of the workflow")
+	}
+	problems = append(problems, validateTemplates("start_workflow correlationId", action.startWorkflow.CorrelationId)...)
+	return append(problems, validateTemplates("start_workflow input", action.startWorkflow.Input)...)
+}
+
+// TaskAction completes or fails a task for each event
+type TaskAction struct {
+	action           string
+	taskDetails      model.TaskDetails
+	expandInlineJson bool
+}
+
+// CompleteTask the complete_task action, the task is identified by the workflow id and its reference name
+func CompleteTask(workflowId string, taskRefName string) *TaskAction {
+	return newTaskAction("complete_task", workflowId, taskRefName)
+}
+
+// FailTask the fail_task action, the task is identified by the workflow id and its reference name
+func FailTask(workflowId string, taskRefName string) *TaskAction {
+	return newTaskAction("fail_task", workflowId, taskRefName)
+}
+
+func newTaskAction(action string, workflowId string, taskRefName string) *TaskAction {
+	return &TaskAction{
+		action: action,
+		taskDetails: model.TaskDetails{
+			WorkflowId:  workflowId,
+			TaskRefName: taskRefName,
+			Output:      map[string]interface{}{},
+		},
+	}
+}
+
+// TaskId identifies the task by its id instead of the workflow id and reference name
+func (action *TaskAction) TaskId(taskId string) *TaskAction {
+	action.taskDetails.TaskId = taskId
+	return action
+}
+
+// Output of the task
+func (action *TaskAction) Output(key string, value interface{}) *TaskAction {
+	action.taskDetails.Output[key] = value
+	return action
+}
+
+// OutputMap of the task
+func (action *TaskAction) OutputMap(outputMap map[string]interface{}) *TaskAction {
+	for k, v := range outputMap {
+		action.taskDetails.Output[k] = v
+	}
+	return action
+}
+
+// ExpandInlineJSON parses the JSON strings of the payload before resolving the templates
+func (action *TaskAction) ExpandInlineJSON(expandInlineJson bool) *TaskAction {
+	action.expandInlineJson = expandInlineJson
+	return action
+}
+
+func (action *TaskAction) toAction() model.Action {
+	taskDetails := action.taskDetails
+	result := model.Action{
+		Action:           action.action,
+		ExpandInlineJSON: action.expandInlineJson,
+	}
+	if action.action == "complete_task" {
+		result.CompleteTask = &taskDetails
+	} else {
+		result.FailTask = &taskDetails
+	}
+	return result
+}
+
+func (action *TaskAction) validate() []string {
+	problems := make([]string, 0)
+	details := action.taskDetails
+	if details.TaskId == "" && (details.WorkflowId == "" || details.TaskRefName == "") {
+		problems = append(problems, fmt.Sprintf("%s requires the task id or the workflow id and task reference name", action.action))
+	}
+	problems = append(problems, validateTemplates(action.action+" workflowId", details.WorkflowId)...)
+	problems = append(problems, validateTemplates(action.action+" taskRefName", details.TaskRefName)...)
+	problems = append(problems, validateTemplates(action.action+" taskId", details.TaskId)...)
+	return append(problems, validateTemplates(action.action+" output", details.Output)...)
+}
+
+// UpdateWorkflowVariablesAction updates the variables of a workflow for each event
+type UpdateWorkflowVariablesAction struct {
+	updateWorkflowVariables model.UpdateWorkflowVariables
+	expandInlineJson        bool
+}
+
+// UpdateWorkflowVariables the update_workflow_variables action
+func UpdateWorkflowVariables(workflowId string) *UpdateWorkflowVariablesAction {
+	return &UpdateWorkflowVariablesAction{
+		updateWorkflowVariables: model.UpdateWorkflowVariables{
+			WorkflowId: workflowId,
+			Variables:  map[string]interface{}{},
+		},
+	}
+}
+
+func (action *UpdateWorkflowVariablesAction) Variable(name string, value interface{}) *UpdateWorkflowVariablesAction {
+	action.updateWorkflowVariables.Variables[name] = value
+	return action
+}
+
+// AppendArray appends the values to the array variables instead of replacing them
+func (action *UpdateWorkflowVariablesAction) AppendArray(appendArray bool) *UpdateWorkflowVariablesAction {
+	action.updateWorkflowVariables.AppendArray = appendArray
+	return action
+}
+
+// ExpandInlineJSON parses the JSON strings of the payload before resolving the templates
+func (action *UpdateWorkflowVariablesAction) ExpandInlineJSON(expandInlineJson bool) *UpdateWorkflowVariablesAction {
+	action.expandInlineJson = expandInlineJson
+	return action
+}
+
+func (action *UpdateWorkflowVariablesAction) toAction() model.Action {
+	updateWorkflowVariables := action.updateWorkflowVariables
+	return model.Action{
+		Action:                  "update_workflow_variables",
+		UpdateWorkflowVariables: &updateWorkflowVariables,
+		ExpandInlineJSON:        action.expandInlineJson,
+	}
+}
+
+func (action *UpdateWorkflowVariablesAction) validate() []string {
+	problems := make([]string, 0)
+	if action.updateWorkflowVariables.WorkflowId == "" {
+		problems = append(problems, "update_workflow_variables requires the workflow id")
+	}
+	if len(action.updateWorkflowVariables.Variables) == 0 {
+		problems = append(problems, "update_workflow_variables requires at least one variable")
+	}
+	problems = append(problems, validateTemplates("update_workflow_variables workflowId", action.updateWorkflowVariables.WorkflowId)...)
+	return append(problems, validateTemplates("update_workflow_variables variables", action.updateWorkflowVariables.Variables)...)
+}
+
+// TerminateWorkflowAction terminates a workflow for each event
+type TerminateWorkflowAction struct {
+	terminateWorkflow model.TerminateWorkflow
+	expandInlineJson  bool
+}
+
+// TerminateWorkflow the terminate_workflow action
+func TerminateWorkflow(workflowId string, terminationReason string) *TerminateWorkflowAction {
+	return &TerminateWorkflowAction{
+		terminateWorkflow: model.TerminateWorkflow{
+			WorkflowId:        workflowId,
+			TerminationReason: terminationReason,
+		},
+	}
+}
+
+// ExpandInlineJSON parses the JSON strings of the payload before resolving the templates
+func (action *TerminateWorkflowAction) ExpandInlineJSON(expandInlineJson bool) *TerminateWorkflowAction {
+	action.expandInlineJson = expandInlineJson
+	return action
+}
+
+func (action *TerminateWorkflowAction) toAction() model.Action {
+	terminateWorkflow := action.terminateWorkflow
+	return model.Action{
+		Action:            "terminate_workflow",
+		TerminateWorkflow: &terminateWorkflow,
+		ExpandInlineJSON:  action.expandInlineJson,
+	}
+}
+
+func (action *TerminateWorkflowAction) validate() []string {
+	problems := make([]string, 0)
+	if action.terminateWorkflow.WorkflowId == "" {
+		problems = append(problems, "terminate_workflow requires the workflow id")
+	}
+	problems = append(problems, validateTemplates("terminate_workflow workflowId", action.terminateWorkflow.WorkflowId)...)
+	return append(problems, validateTemplates("terminate_workflow terminationReason", action.terminateWorkflow.TerminationReason)...)
+}
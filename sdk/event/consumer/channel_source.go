@@ -0,0 +1,104 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"sync"
+)
+
+// ChannelSource in-memory Source backed by a channel, meant for the tests and the local development
+type ChannelSource struct {
+	messages chan *Message
+	mutex    sync.Mutex
+	acked    []*Message
+	nacked   []*Message
+}
+
+func NewChannelSource(bufferSize int) *ChannelSource {
+	return &ChannelSource{
+		messages: make(chan *Message, bufferSize),
+	}
+}
+
+// Publish the message, blocks when the buffer is full
+func (s *ChannelSource) Publish(ctx context.Context, message *Message) error {
+	select {
+	case s.messages <- message:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// Close the source, Receive returns ErrSourceClosed once the published messages are consumed
+func (s *ChannelSource) Close() {
+	close(s.messages)
+}
+
+func (s *ChannelSource) Receive(ctx context.Context) (*Message, error) {
+	select {
+	case message, ok := <-s.messages:
+		if !ok {
+			return nil, ErrSourceClosed
+		}
+		return message, nil
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+}
+
+func (s *ChannelSource) Ack(ctx context.Context, message *Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.acked = append(s.acked, message)
+	return nil
+}
+
+func (s *ChannelSource) Nack(ctx context.Context, message *Message) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.nacked = append(s.nacked, message)
+	return nil
+}
+
+// Acked messages, in the order they were acknowledged
+func (s *ChannelSource) Acked() []*Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*Message(nil), s.acked...)
+}
+
+// Nacked messages, in the order they were rejected
+func (s *ChannelSource) Nacked() []*Message {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return append([]*Message(nil), s.nacked...)
+}
+
+// ChannelDeadLetterQueue in-memory DeadLetterQueue
+type ChannelDeadLetterQueue struct {
+	DeadLetters chan *DeadLetter
+}
+
+func NewChannelDeadLetterQueue(bufferSize int) *ChannelDeadLetterQueue {
+	return &ChannelDeadLetterQueue{
+		DeadLetters: make(chan *DeadLetter, bufferSize),
+	}
+}
+
+func (q *ChannelDeadLetterQueue) Send(ctx context.Context, deadLetter *DeadLetter) error {
+	select {
+	case q.DeadLetters <- deadLetter:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,293 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultMaxRetries   = 3
+	defaultRetryBackoff = time.Second
+	defaultDedupSize    = 10000
+)
+
+// ErrNoMatchingRule the message does not match any of the rules
+var ErrNoMatchingRule = errors.New("no matching rule")
+
+// Executor the operations used by the consumer, implemented by executor.WorkflowExecutor
+type Executor interface {
+	StartWorkflowWithContext(ctx context.Context, startWorkflowRequest *model.StartWorkflowRequest) (workflowId string, err error)
+	UpdateTaskByRefNameWithContext(ctx context.Context, taskRefName string, workflowInstanceId string, status model.TaskResultStatus, output interface{}) error
+}
+
+// Consumer consumes the messages from the source and feeds them to Conductor: each message is mapped by the first
+// matching rule to a workflow execution or a task update.  Failures are retried with exponential backoff, except the
+// ones that cannot succeed (invalid payload, no matching rule, 4xx responses), and the messages that could not be
+// processed are sent to the dead letter queue.  Messages with an id already processed are acknowledged and skipped
+type Consumer struct {
+	source          Source
+	executor        Executor
+	rules           []*Rule
+	deadLetterQueue DeadLetterQueue
+	maxRetries      int
+	retryBackoff    time.Duration
+	processed       *processedIds
+}
+
+func NewConsumer(source Source, executor Executor) *Consumer {
+	return &Consumer{
+		source:       source,
+		executor:     executor,
+		maxRetries:   defaultMaxRetries,
+		retryBackoff: defaultRetryBackoff,
+		processed:    newProcessedIds(defaultDedupSize),
+	}
+}
+
+// WithRule adds the rules, evaluated in the order they were added
+func (c *Consumer) WithRule(rules ...*Rule) *Consumer {
+	c.rules = append(c.rules, rules...)
+	return c
+}
+
+// WithDeadLetterQueue where the messages that could not be processed are sent.  Without dead letter queue the
+// messages are rejected using Source.Nack
+func (c *Consumer) WithDeadLetterQueue(deadLetterQueue DeadLetterQueue) *Consumer {
+	c.deadLetterQueue = deadLetterQueue
+	return c
+}
+
+// WithRetries number of times a failure is retried, the backoff doubles after each attempt
+func (c *Consumer) WithRetries(maxRetries int, backoff time.Duration) *Consumer {
+	c.maxRetries = maxRetries
+	c.retryBackoff = backoff
+	return c
+}
+
+// WithDedupSize number of the most recently processed message ids remembered to skip the duplicates
+func (c *Consumer) WithDedupSize(size int) *Consumer {
+	c.processed = newProcessedIds(size)
+	return c
+}
+
+// Run consumes the messages until the source is closed or the context is cancelled
+func (c *Consumer) Run(ctx context.Context) error {
+	if err := c.validate(); err != nil {
+		return err
+	}
+	for {
+		message, err := c.source.Receive(ctx)
+		if errors.Is(err, ErrSourceClosed) {
+			return nil
+		}
+		if ctx.Err() != nil {
+			// received as the context was cancelled, delivered again
+			if err == nil && message != nil {
+				c.nack(message)
+			}
+			return ctx.Err()
+		}
+		if err != nil {
+			log.Warning("Failed to receive message: ", err.Error())
+			if err := internal.Sleep(ctx, c.retryBackoff); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := c.Handle(ctx, message); err != nil {
+			log.Warning(fmt.Sprintf("Failed to process message %s: %s", message.Id, err.Error()))
+		}
+	}
+}
+
+// Handle processes a single message, acknowledging it once processed or dead lettered.  The id of the message is
+// reserved while it is processed, the duplicates received meanwhile are skipped, and released when it fails.
+// Returns the error that prevented the message from being processed
+func (c *Consumer) Handle(ctx context.Context, message *Message) error {
+	if !c.processed.reserve(message.Id) {
+		log.Debug("Skipping duplicate message ", message.Id)
+		return c.source.Ack(ctx, message)
+	}
+	attempts, err := c.process(ctx, message)
+	if err == nil {
+		return c.source.Ack(ctx, message)
+	}
+	c.processed.release(message.Id)
+	if ctx.Err() != nil {
+		c.nack(message)
+		return err
+	}
+	if c.deadLetterQueue == nil {
+		c.nack(message)
+		return err
+	}
+	deadLetter := &DeadLetter{Message: message, Error: err, Attempts: attempts}
+	if dlqErr := c.deadLetterQueue.Send(ctx, deadLetter); dlqErr != nil {
+		c.nack(message)
+		return fmt.Errorf("%s, failed to send to the dead letter queue: %s", err.Error(), dlqErr.Error())
+	}
+	if ackErr := c.source.Ack(ctx, message); ackErr != nil {
+		log.Warning(fmt.Sprintf("Failed to ack message %s: %s", message.Id, ackErr.Error()))
+	}
+	return err
+}
+
+func (c *Consumer) validate() error {
+	if len(c.rules) == 0 {
+		return fmt.Errorf("at least one rule is required")
+	}
+	for _, rule := range c.rules {
+		if err := rule.validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) nack(message *Message) {
+	// the context may be cancelled, nack regardless so that the message is delivered again
+	if err := c.source.Nack(context.Background(), message); err != nil {
+		log.Warning(fmt.Sprintf("Failed to nack message %s: %s", message.Id, err.Error()))
+	}
+}
+
+// process applies the matching rule, returns the number of attempts made
+func (c *Consumer) process(ctx context.Context, message *Message) (int, error) {
+	var payload map[string]interface{}
+	if err := json.Unmarshal(message.Payload, &payload); err != nil {
+		return 0, fmt.Errorf("payload is not a JSON object: %s", err.Error())
+	}
+	rule := c.matchingRule(message, payload)
+	if rule == nil {
+		return 0, ErrNoMatchingRule
+	}
+	apply, err := c.prepare(rule, message, payload)
+	if err != nil {
+		return 0, fmt.Errorf("%s: %s", rule, err.Error())
+	}
+	backoff := c.retryBackoff
+	for attempt := 1; ; attempt++ {
+		err = apply(ctx)
+		if err == nil || attempt > c.maxRetries || !isRetryable(err) {
+			return attempt, err
+		}
+		log.Debug(fmt.Sprintf("Retrying message %s after %s, attempt %d failed: %s", message.Id, backoff, attempt, err.Error()))
+		if sleepErr := internal.Sleep(ctx, backoff); sleepErr != nil {
+			return attempt, err
+		}
+		backoff *= 2
+	}
+}
+
+func (c *Consumer) matchingRule(message *Message, payload map[string]interface{}) *Rule {
+	for _, rule := range c.rules {
+		if rule.matches(message, payload) {
+			return rule
+		}
+	}
+	return nil
+}
+
+func (c *Consumer) prepare(rule *Rule, message *Message, payload map[string]interface{}) (func(ctx context.Context) error, error) {
+	if rule.action == startWorkflowAction {
+		request, err := rule.startWorkflowRequest(message, payload)
+		if err != nil {
+			return nil, err
+		}
+		return func(ctx context.Context) error {
+			_, err := c.executor.StartWorkflowWithContext(ctx, request)
+			return err
+		}, nil
+	}
+	workflowId, err := rule.workflowId(message, payload)
+	if err != nil {
+		return nil, err
+	}
+	output, err := rule.data(payload)
+	if err != nil {
+		return nil, err
+	}
+	return func(ctx context.Context) error {
+		return c.executor.UpdateTaskByRefNameWithContext(ctx, rule.taskRefName, workflowId, rule.status, output)
+	}, nil
+}
+
+// isRetryable the server errors, throttling and transport errors are retried, the other client errors are not
+func isRetryable(err error) bool {
+	var swaggerErr client.GenericSwaggerError
+	if errors.As(err, &swaggerErr) {
+		statusCode := swaggerErr.StatusCode()
+		return statusCode >= 500 || statusCode == http.StatusTooManyRequests || statusCode == http.StatusRequestTimeout
+	}
+	return true
+}
+
+// processedIds the most recently processed message ids, bounded by size
+type processedIds struct {
+	mutex sync.Mutex
+	size  int
+	ids   map[string]struct{}
+	order []string
+}
+
+func newProcessedIds(size int) *processedIds {
+	return &processedIds{
+		size: size,
+		ids:  make(map[string]struct{}),
+	}
+}
+
+// reserve returns false if the id is processed or being processed, otherwise records it.  The messages without id
+// are always processed
+func (p *processedIds) reserve(id string) bool {
+	if id == "" || p.size <= 0 {
+		return true
+	}
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.ids[id]; ok {
+		return false
+	}
+	if len(p.order) >= p.size {
+		delete(p.ids, p.order[0])
+		p.order = p.order[1:]
+	}
+	p.ids[id] = struct{}{}
+	p.order = append(p.order, id)
+	return true
+}
+
+// release forgets the id so that the message can be processed again
+func (p *processedIds) release(id string) {
+	p.mutex.Lock()
+	defer p.mutex.Unlock()
+	if _, ok := p.ids[id]; !ok {
+		return
+	}
+	delete(p.ids, id)
+	for i, candidate := range p.order {
+		if candidate == id {
+			p.order = append(p.order[:i], p.order[i+1:]...)
+			break
+		}
+	}
+}
@@ -0,0 +1,232 @@
+package consumer
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/stretchr/testify/assert"
+)
+
+type taskUpdate struct {
+	taskRefName string
+	workflowId  string
+	status      model.TaskResultStatus
+	output      interface{}
+}
+
+type fakeExecutor struct {
+	mutex    sync.Mutex
+	errors   []error
+	attempts int
+	started  []*model.StartWorkflowRequest
+	updates  []taskUpdate
+}
+
+func (e *fakeExecutor) nextError() error {
+	e.mutex.Lock()
+	defer e.mutex.Unlock()
+	e.attempts++
+	if len(e.errors) == 0 {
+		return nil
+	}
+	err := e.errors[0]
+	e.errors = e.errors[1:]
+	return err
+}
+
+func (e *fakeExecutor) StartWorkflowWithContext(ctx context.Context, startWorkflowRequest *model.StartWorkflowRequest) (string, error) {
+	if err := e.nextError(); err != nil {
+		return "", err
+	}
+	e.started = append(e.started, startWorkflowRequest)
+	return "workflow_id", nil
+}
+
+func (e *fakeExecutor) UpdateTaskByRefNameWithContext(ctx context.Context, taskRefName string, workflowInstanceId string, status model.TaskResultStatus, output interface{}) error {
+	if err := e.nextError(); err != nil {
+		return err
+	}
+	e.updates = append(e.updates, taskUpdate{taskRefName, workflowInstanceId, status, output})
+	return nil
+}
+
+func newTestConsumer(source Source, executor Executor) *Consumer {
+	return NewConsumer(source, executor).
+		WithRetries(2, time.Millisecond).
+		WithRule(
+			UpdateTask("wait_for_payment", model.CompletedTask).
+				WhenHeader("type", "payment").
+				WorkflowIdHeader("workflowId").
+				PayloadField("payment"),
+			StartWorkflow("process_order", 2).
+				WhenField("order.status", "NEW").
+				CorrelationIdField("order.id"),
+		)
+}
+
+func TestConsumerRoutesMessages(t *testing.T) {
+	source := NewChannelSource(10)
+	deadLetterQueue := NewChannelDeadLetterQueue(10)
+	executor := &fakeExecutor{}
+	ctx := context.Background()
+	source.Publish(ctx, &Message{Id: "1", Payload: []byte(`{"order": {"id": 42, "status": "NEW"}}`)})
+	source.Publish(ctx, &Message{Id: "2", Payload: []byte(`{"payment": {"amount": 10}}`), Headers: map[string]string{"type": "payment", "workflowId": "wf_1"}})
+	source.Publish(ctx, &Message{Id: "1", Payload: []byte(`{"order": {"id": 42, "status": "NEW"}}`)})
+	source.Publish(ctx, &Message{Id: "3", Payload: []byte(`{"order": {"id": 43, "status": "SHIPPED"}}`)})
+	source.Publish(ctx, &Message{Id: "4", Payload: []byte(`not json`)})
+	source.Close()
+
+	err := newTestConsumer(source, executor).WithDeadLetterQueue(deadLetterQueue).Run(ctx)
+	assert.NoError(t, err)
+
+	assert.Equal(t, []*model.StartWorkflowRequest{{
+		Name:                "process_order",
+		Version:             2,
+		CorrelationId:       "42",
+		Input:               map[string]interface{}{"order": map[string]interface{}{"id": float64(42), "status": "NEW"}},
+		IdempotencyKey:      "1",
+		IdempotencyStrategy: model.ReturnExisting,
+	}}, executor.started)
+	assert.Equal(t, []taskUpdate{{"wait_for_payment", "wf_1", model.CompletedTask, map[string]interface{}{"amount": float64(10)}}}, executor.updates)
+	assert.Len(t, source.Acked(), 5)
+	assert.Empty(t, source.Nacked())
+
+	noMatch := <-deadLetterQueue.DeadLetters
+	assert.Equal(t, "3", noMatch.Message.Id)
+	assert.True(t, errors.Is(noMatch.Error, ErrNoMatchingRule))
+	invalid := <-deadLetterQueue.DeadLetters
+	assert.Equal(t, "4", invalid.Message.Id)
+	assert.Contains(t, invalid.Error.Error(), "payload is not a JSON object")
+}
+
+func TestConsumerRetries(t *testing.T) {
+	ctx := context.Background()
+	message := &Message{Id: "1", Payload: []byte(`{"order": {"id": 42, "status": "NEW"}}`)}
+	serverError := client.NewGenericSwaggerError(nil, "unavailable", nil, 503)
+
+	executor := &fakeExecutor{errors: []error{serverError, serverError}}
+	source := NewChannelSource(1)
+	assert.NoError(t, newTestConsumer(source, executor).Handle(ctx, message))
+	assert.Equal(t, 3, executor.attempts)
+	assert.Len(t, executor.started, 1)
+
+	executor = &fakeExecutor{errors: []error{serverError, serverError, serverError}}
+	deadLetterQueue := NewChannelDeadLetterQueue(1)
+	err := newTestConsumer(source, executor).WithDeadLetterQueue(deadLetterQueue).Handle(ctx, message)
+	assert.Equal(t, serverError, err)
+	deadLetter := <-deadLetterQueue.DeadLetters
+	assert.Equal(t, 3, deadLetter.Attempts)
+
+	executor = &fakeExecutor{errors: []error{client.NewGenericSwaggerError(nil, "bad request", nil, 400)}}
+	source = NewChannelSource(1)
+	err = newTestConsumer(source, executor).Handle(ctx, message)
+	assert.Error(t, err)
+	assert.Equal(t, 1, executor.attempts)
+	assert.Equal(t, []*Message{message}, source.Nacked())
+	assert.Empty(t, source.Acked())
+}
+
+func TestConsumerValidatesRules(t *testing.T) {
+	source := NewChannelSource(1)
+	assert.EqualError(t, NewConsumer(source, &fakeExecutor{}).Run(context.Background()), "at least one rule is required")
+	err := NewConsumer(source, &fakeExecutor{}).WithRule(UpdateTask("wait", model.CompletedTask)).Run(context.Background())
+	assert.EqualError(t, err, "update_task wait requires the workflow id field or header")
+}
+
+func TestConsumerStopsWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	err := newTestConsumer(NewChannelSource(1), &fakeExecutor{}).Run(ctx)
+	assert.Equal(t, context.DeadlineExceeded, err)
+}
+
+func TestProcessedIdsBounded(t *testing.T) {
+	processed := newProcessedIds(2)
+	assert.True(t, processed.reserve("1"))
+	assert.True(t, processed.reserve("2"))
+	assert.True(t, processed.reserve("3"))
+	assert.True(t, processed.reserve("1"))
+	assert.False(t, processed.reserve("3"))
+	processed.release("3")
+	assert.True(t, processed.reserve("3"))
+	assert.True(t, processed.reserve(""))
+	assert.True(t, processed.reserve(""))
+}
+
+// gatedExecutor blocks the workflow starts until proceed is closed
+type gatedExecutor struct {
+	*fakeExecutor
+	entered chan struct{}
+	proceed chan struct{}
+}
+
+func (e *gatedExecutor) StartWorkflowWithContext(ctx context.Context, startWorkflowRequest *model.StartWorkflowRequest) (string, error) {
+	e.entered <- struct{}{}
+	<-e.proceed
+	return e.fakeExecutor.StartWorkflowWithContext(ctx, startWorkflowRequest)
+}
+
+func TestConsumerSkipsDuplicatesInProgress(t *testing.T) {
+	ctx := context.Background()
+	message := &Message{Id: "1", Payload: []byte(`{"order": {"id": 42, "status": "NEW"}}`)}
+	executor := &gatedExecutor{fakeExecutor: &fakeExecutor{}, entered: make(chan struct{}, 1), proceed: make(chan struct{})}
+	source := NewChannelSource(1)
+	consumer := newTestConsumer(source, executor)
+
+	done := make(chan error)
+	go func() { done <- consumer.Handle(ctx, message) }()
+	<-executor.entered
+	// received again while the first delivery is processed
+	assert.NoError(t, consumer.Handle(ctx, message))
+	close(executor.proceed)
+	assert.NoError(t, <-done)
+	assert.Equal(t, 1, executor.attempts)
+	assert.Len(t, source.Acked(), 2)
+}
+
+func TestConsumerReleasesFailedMessages(t *testing.T) {
+	ctx := context.Background()
+	message := &Message{Id: "1", Payload: []byte(`{"order": {"id": 42, "status": "NEW"}}`)}
+	executor := &fakeExecutor{errors: []error{client.NewGenericSwaggerError(nil, "bad request", nil, 400)}}
+	source := NewChannelSource(1)
+	consumer := newTestConsumer(source, executor)
+
+	assert.Error(t, consumer.Handle(ctx, message))
+	assert.Equal(t, []*Message{message}, source.Nacked())
+	// the delivery of the rejected message is processed
+	assert.NoError(t, consumer.Handle(ctx, message))
+	assert.Equal(t, 2, executor.attempts)
+	assert.Len(t, executor.started, 1)
+}
+
+// cancellingSource cancels the context of the consumer once a message is received
+type cancellingSource struct {
+	*ChannelSource
+	cancel context.CancelFunc
+}
+
+func (s *cancellingSource) Receive(ctx context.Context) (*Message, error) {
+	message, err := s.ChannelSource.Receive(ctx)
+	s.cancel()
+	return message, err
+}
+
+func TestConsumerNacksWhenCancelled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	message := &Message{Id: "1", Payload: []byte(`{"order": {"id": 42, "status": "NEW"}}`)}
+	source := &cancellingSource{ChannelSource: NewChannelSource(1), cancel: cancel}
+	source.Publish(ctx, message)
+	executor := &fakeExecutor{}
+
+	err := newTestConsumer(source, executor).Run(ctx)
+	assert.Equal(t, context.Canceled, err)
+	assert.Equal(t, []*Message{message}, source.Nacked())
+	assert.Empty(t, source.Acked())
+	assert.Equal(t, 0, executor.attempts)
+}
@@ -0,0 +1,214 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package consumer
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+type ruleAction string
+
+const (
+	startWorkflowAction ruleAction = "start_workflow"
+	updateTaskAction    ruleAction = "update_task"
+)
+
+// Rule maps the matching messages to a workflow execution or to the completion of a task.
+// The payload of the messages is expected to be a JSON object, fields are referred using dot separated paths e.g. order.id
+type Rule struct {
+	action            ruleAction
+	conditions        []func(message *Message, payload map[string]interface{}) bool
+	workflowName      string
+	version           int32
+	taskToDomain      map[string]string
+	correlationIdPath string
+	taskRefName       string
+	status            model.TaskResultStatus
+	workflowIdPath    string
+	workflowIdHeader  string
+	payloadPath       string
+}
+
+// StartWorkflow starts the workflow using the payload as the input.  The id of the message is used as the idempotency
+// key so that redelivered messages do not start the workflow twice
+func StartWorkflow(workflowName string, version int32) *Rule {
+	return &Rule{
+		action:       startWorkflowAction,
+		workflowName: workflowName,
+		version:      version,
+	}
+}
+
+// UpdateTask updates the task with the given reference name using the payload as the output.  The id of the workflow
+// is read from the payload or from the headers, see WorkflowIdField and WorkflowIdHeader
+func UpdateTask(taskRefName string, status model.TaskResultStatus) *Rule {
+	return &Rule{
+		action:      updateTaskAction,
+		taskRefName: taskRefName,
+		status:      status,
+	}
+}
+
+// When the condition is true.  All the conditions must be true for the rule to match
+func (r *Rule) When(condition func(message *Message, payload map[string]interface{}) bool) *Rule {
+	r.conditions = append(r.conditions, condition)
+	return r
+}
+
+// WhenHeader the header has the given value
+func (r *Rule) WhenHeader(name string, value string) *Rule {
+	return r.When(func(message *Message, payload map[string]interface{}) bool {
+		return message.Headers[name] == value
+	})
+}
+
+// WhenField the field of the payload has the given value, compared as a string e.g. WhenField("order.status", "PAID")
+func (r *Rule) WhenField(path string, value interface{}) *Rule {
+	return r.When(func(message *Message, payload map[string]interface{}) bool {
+		fieldValue, ok := lookup(payload, path)
+		return ok && (reflect.DeepEqual(fieldValue, value) || fmt.Sprint(fieldValue) == fmt.Sprint(value))
+	})
+}
+
+// CorrelationIdField sets the correlation id of the started workflow from the payload
+func (r *Rule) CorrelationIdField(path string) *Rule {
+	r.correlationIdPath = path
+	return r
+}
+
+// TaskToDomain mapping of the started workflow
+func (r *Rule) TaskToDomain(taskToDomain map[string]string) *Rule {
+	r.taskToDomain = taskToDomain
+	return r
+}
+
+// WorkflowIdField reads the id of the workflow to update from the payload
+func (r *Rule) WorkflowIdField(path string) *Rule {
+	r.workflowIdPath = path
+	return r
+}
+
+// WorkflowIdHeader reads the id of the workflow to update from the header
+func (r *Rule) WorkflowIdHeader(name string) *Rule {
+	r.workflowIdHeader = name
+	return r
+}
+
+// PayloadField uses the field of the payload instead of the whole payload as the workflow input or the task output
+func (r *Rule) PayloadField(path string) *Rule {
+	r.payloadPath = path
+	return r
+}
+
+func (r *Rule) String() string {
+	if r.action == startWorkflowAction {
+		return fmt.Sprintf("%s %s", r.action, r.workflowName)
+	}
+	return fmt.Sprintf("%s %s", r.action, r.taskRefName)
+}
+
+func (r *Rule) matches(message *Message, payload map[string]interface{}) bool {
+	for _, condition := range r.conditions {
+		if !condition(message, payload) {
+			return false
+		}
+	}
+	return true
+}
+
+func (r *Rule) validate() error {
+	switch r.action {
+	case startWorkflowAction:
+		if r.workflowName == "" {
+			return fmt.Errorf("%s requires the name of the workflow", r.action)
+		}
+	case updateTaskAction:
+		if r.taskRefName == "" {
+			return fmt.Errorf("%s requires the task reference name", r.action)
+		}
+		if r.workflowIdPath == "" && r.workflowIdHeader == "" {
+			return fmt.Errorf("%s requires the workflow id field or header", r)
+		}
+	}
+	return nil
+}
+
+func (r *Rule) data(payload map[string]interface{}) (map[string]interface{}, error) {
+	if r.payloadPath == "" {
+		return payload, nil
+	}
+	value, ok := lookup(payload, r.payloadPath)
+	if !ok {
+		return nil, fmt.Errorf("payload has no field %s", r.payloadPath)
+	}
+	data, ok := value.(map[string]interface{})
+	if !ok {
+		return nil, fmt.Errorf("field %s is not a JSON object", r.payloadPath)
+	}
+	return data, nil
+}
+
+func (r *Rule) startWorkflowRequest(message *Message, payload map[string]interface{}) (*model.StartWorkflowRequest, error) {
+	input, err := r.data(payload)
+	if err != nil {
+		return nil, err
+	}
+	request := &model.StartWorkflowRequest{
+		Name:         r.workflowName,
+		Version:      r.version,
+		Input:        input,
+		TaskToDomain: r.taskToDomain,
+	}
+	if message.Id != "" {
+		request.IdempotencyKey = message.Id
+		request.IdempotencyStrategy = model.ReturnExisting
+	}
+	if r.correlationIdPath != "" {
+		correlationId, ok := lookup(payload, r.correlationIdPath)
+		if !ok {
+			return nil, fmt.Errorf("payload has no field %s", r.correlationIdPath)
+		}
+		request.CorrelationId = fmt.Sprint(correlationId)
+	}
+	return request, nil
+}
+
+func (r *Rule) workflowId(message *Message, payload map[string]interface{}) (string, error) {
+	if r.workflowIdHeader != "" {
+		if workflowId := message.Headers[r.workflowIdHeader]; workflowId != "" {
+			return workflowId, nil
+		}
+	}
+	if r.workflowIdPath != "" {
+		if workflowId, ok := lookup(payload, r.workflowIdPath); ok && workflowId != nil && workflowId != "" {
+			return fmt.Sprint(workflowId), nil
+		}
+	}
+	return "", fmt.Errorf("message has no workflow id")
+}
+
+func lookup(payload map[string]interface{}, path string) (interface{}, bool) {
+	var current interface{} = payload
+	for _, key := range strings.Split(path, ".") {
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil, false
+		}
+		current, ok = object[key]
+		if !ok {
+			return nil, false
+		}
+	}
+	return current, true
+}
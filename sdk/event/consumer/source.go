@@ -0,0 +1,55 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package consumer
+
+import (
+	"context"
+	"errors"
+)
+
+// ErrSourceClosed is returned by Source.Receive when no more messages will be delivered
+var ErrSourceClosed = errors.New("source closed")
+
+// Message received from the queue
+type Message struct {
+	// Id used to detect the messages delivered more than once
+	Id      string
+	Payload []byte
+	Headers map[string]string
+}
+
+// Source of the messages consumed, e.g. a Kafka consumer group or an SQS queue.
+// Receive blocks until a message is available, the context is cancelled or the source is closed (ErrSourceClosed).
+// Ack is called once the message was processed or sent to the dead letter queue, Nack when the message could not be
+// processed and there is no dead letter queue, allowing the source to deliver it again
+type Source interface {
+	Receive(ctx context.Context) (*Message, error)
+	Ack(ctx context.Context, message *Message) error
+	Nack(ctx context.Context, message *Message) error
+}
+
+// DeadLetter message that could not be processed along with the reason
+type DeadLetter struct {
+	Message  *Message
+	Error    error
+	Attempts int
+}
+
+// DeadLetterQueue receives the messages that could not be processed
+type DeadLetterQueue interface {
+	Send(ctx context.Context, deadLetter *DeadLetter) error
+}
+
+// DeadLetterFunc adapts a function to the DeadLetterQueue interface
+type DeadLetterFunc func(ctx context.Context, deadLetter *DeadLetter) error
+
+func (f DeadLetterFunc) Send(ctx context.Context, deadLetter *DeadLetter) error {
+	return f(ctx, deadLetter)
+}
@@ -0,0 +1,215 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package event
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+const javascriptEvaluator = "javascript"
+
+// EventHandler builder of the event handlers, e.g.
+//
+//	event.NewEventHandler("order_created", event.KafkaSink("orders")).
+//		Condition("$.type == 'created'").
+//		Action(event.StartWorkflow("process_order", 1).Input("orderId", "${orderId}"))
+type EventHandler struct {
+	name          string
+	sink          Sink
+	condition     string
+	evaluatorType string
+	actions       []Action
+	active        bool
+}
+
+// NewEventHandler the handler of the events published to the sink, active by default
+func NewEventHandler(name string, sink Sink) *EventHandler {
+	return &EventHandler{
+		name:    name,
+		sink:    sink,
+		actions: []Action{},
+		active:  true,
+	}
+}
+
+// Condition javascript expression evaluated against the payload of the event, e.g. $.type == 'created'.
+// The actions are executed only for the events matching the condition
+func (handler *EventHandler) Condition(condition string) *EventHandler {
+	handler.condition = condition
+	handler.evaluatorType = javascriptEvaluator
+	return handler
+}
+
+// EvaluatorType of the condition, defaults to javascript when a condition is set
+func (handler *EventHandler) EvaluatorType(evaluatorType string) *EventHandler {
+	handler.evaluatorType = evaluatorType
+	return handler
+}
+
+// Action executed for each matching event, in the order added
+func (handler *EventHandler) Action(actions ...Action) *EventHandler {
+	handler.actions = append(handler.actions, actions...)
+	return handler
+}
+
+func (handler *EventHandler) Active(active bool) *EventHandler {
+	handler.active = active
+	return handler
+}
+
+func (handler *EventHandler) GetName() string {
+	return handler.name
+}
+
+// Validate checks the handler offline: the sink, the syntax of the condition, the required fields of the actions
+// and the ${...} templates of their values
+func (handler *EventHandler) Validate() error {
+	problems := make([]string, 0)
+	if handler.name == "" {
+		problems = append(problems, "name is required")
+	}
+	if err := handler.sink.Validate(); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if handler.condition != "" && (handler.evaluatorType == "" || handler.evaluatorType == javascriptEvaluator) {
+		problems = append(problems, validateCondition(handler.condition)...)
+	}
+	if len(handler.actions) == 0 {
+		problems = append(problems, "at least one action is required")
+	}
+	for _, action := range handler.actions {
+		problems = append(problems, action.validate()...)
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("event handler %s is invalid: %s", handler.name, strings.Join(problems, "; "))
+}
+
+// ToEventHandler validates the handler and returns the definition to register
+func (handler *EventHandler) ToEventHandler() (*model.EventHandler, error) {
+	if err := handler.Validate(); err != nil {
+		return nil, err
+	}
+	actions := make([]model.Action, len(handler.actions))
+	for i, action := range handler.actions {
+		actions[i] = action.toAction()
+	}
+	return &model.EventHandler{
+		Name:          handler.name,
+		Event:         string(handler.sink),
+		Condition:     handler.condition,
+		EvaluatorType: handler.evaluatorType,
+		Actions:       actions,
+		Active:        handler.active,
+	}, nil
+}
+
+// validateCondition checks the brackets and the string literals are balanced, the expression can only be evaluated
+// by the server
+func validateCondition(condition string) []string {
+	problems := make([]string, 0)
+	if strings.Contains(condition, "${") {
+		problems = append(problems, fmt.Sprintf("condition %q refers to the payload as $.name, not ${name}", condition))
+	}
+	closing := map[rune]rune{')': '(', ']': '[', '}': '{'}
+	stack := make([]rune, 0)
+	var quote rune
+	escaped := false
+	for _, c := range condition {
+		switch {
+		case quote != 0:
+			if escaped {
+				escaped = false
+			} else if c == '\\' {
+				escaped = true
+			} else if c == quote {
+				quote = 0
+			}
+		case c == '\'' || c == '"' || c == '`':
+			quote = c
+		case c == '(' || c == '[' || c == '{':
+			stack = append(stack, c)
+		case closing[c] != 0:
+			if len(stack) == 0 || stack[len(stack)-1] != closing[c] {
+				return append(problems, fmt.Sprintf("condition %q has an unexpected %c", condition, c))
+			}
+			stack = stack[:len(stack)-1]
+		}
+	}
+	if quote != 0 {
+		problems = append(problems, fmt.Sprintf("condition %q has an unterminated string", condition))
+	} else if len(stack) > 0 {
+		problems = append(problems, fmt.Sprintf("condition %q has an unclosed %c", condition, stack[len(stack)-1]))
+	}
+	trimmed := strings.TrimSpace(condition)
+	for _, operator := range []string{"&&", "||", "==", "!=", "<", ">", "+", "-", "*", "/", "!", "="} {
+		if strings.HasSuffix(trimmed, operator) {
+			problems = append(problems, fmt.Sprintf("condition %q ends with the operator %s", condition, operator))
+			break
+		}
+	}
+	return problems
+}
+
+// validateTemplates checks the ${...} templates in the strings of the value, including nested maps and slices
+func validateTemplates(location string, value interface{}) []string {
+	problems := make([]string, 0)
+	var check func(value reflect.Value)
+	check = func(value reflect.Value) {
+		switch value.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			if !value.IsNil() {
+				check(value.Elem())
+			}
+		case reflect.String:
+			if problem := validateTemplate(value.String()); problem != "" {
+				problems = append(problems, fmt.Sprintf("%s %q %s", location, value.String(), problem))
+			}
+		case reflect.Map:
+			for _, key := range value.MapKeys() {
+				check(value.MapIndex(key))
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				check(value.Index(i))
+			}
+		}
+	}
+	if value != nil {
+		check(reflect.ValueOf(value))
+	}
+	return problems
+}
+
+func validateTemplate(template string) string {
+	for {
+		start := strings.Index(template, "${")
+		if start < 0 {
+			return ""
+		}
+		end := strings.Index(template[start:], "}")
+		if end < 0 {
+			return "has an unclosed ${"
+		}
+		expression := template[start+2 : start+end]
+		if strings.TrimSpace(expression) == "" {
+			return "has an empty ${}"
+		}
+		if strings.Contains(expression, "${") {
+			return "has a nested ${"
+		}
+		template = template[start+end+1:]
+	}
+}
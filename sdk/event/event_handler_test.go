@@ -0,0 +1,135 @@
+package event
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow"
+	"github.com/stretchr/testify/assert"
+)
+
+func newOrderHandler() *EventHandler {
+	return NewEventHandler("order_created", KafkaSink("orders")).
+		Condition("$.type == 'created' && ($.amount > 0)").
+		Action(
+			StartWorkflow("process_order", 1).
+				Input("orderId", "${orderId}").
+				CorrelationId("${orderId}"),
+			UpdateWorkflowVariables("${workflowId}").Variable("status", "${status}").AppendArray(true),
+		)
+}
+
+func TestEventHandlerBuilder(t *testing.T) {
+	eventHandler, err := newOrderHandler().Action(
+		CompleteTask("${workflowId}", "wait_ref").Output("approved", true),
+		FailTask("", "").TaskId("${taskId}").ExpandInlineJSON(true),
+		TerminateWorkflow("${workflowId}", "cancelled by ${user}"),
+	).ToEventHandler()
+	assert.NoError(t, err)
+	data, err := json.Marshal(eventHandler)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"name": "order_created",
+		"event": "kafka:orders",
+		"condition": "$.type == 'created' && ($.amount > 0)",
+		"evaluatorType": "javascript",
+		"active": true,
+		"actions": [
+			{"action": "start_workflow", "start_workflow": {"name": "process_order", "version": 1, "correlationId": "${orderId}", "input": {"orderId": "${orderId}"}}},
+			{"action": "update_workflow_variables", "update_workflow_variables": {"workflowId": "${workflowId}", "variables": {"status": "${status}"}, "appendArray": true}},
+			{"action": "complete_task", "complete_task": {"workflowId": "${workflowId}", "taskRefName": "wait_ref", "output": {"approved": true}}},
+			{"action": "fail_task", "fail_task": {"taskId": "${taskId}"}, "expandInlineJSON": true},
+			{"action": "terminate_workflow", "terminate_workflow": {"workflowId": "${workflowId}", "terminationReason": "cancelled by ${user}"}}
+		]
+	}`, string(data))
+}
+
+func TestSinks(t *testing.T) {
+	assert.Equal(t, "sqs:orders", SqsSink("orders").String())
+	assert.Equal(t, "conductor:process_order:order_created", ConductorSink("process_order", "order_created").String())
+	assert.Equal(t, "amqp:orders", AmqpSink("orders").String())
+	assert.Equal(t, "amqp_exchange:orders", AmqpExchangeSink("orders").String())
+	assert.Equal(t, "nats", NatsSink("orders.created").Type())
+	assert.Error(t, Sink("orders").Validate())
+	assert.Error(t, KafkaSink("").Validate())
+}
+
+func TestConductorSink(t *testing.T) {
+	workflowDef := workflow.NewConductorWorkflow(nil).
+		Name("process_order").
+		Add(workflow.NewConductorEventTask("notify_ref", "order_created")).
+		ToWorkflowDef()
+	// the server publishes the events to the sink of the task qualified with the name of the workflow
+	sink := strings.Replace(workflowDef.Tasks[0].Sink, ":", ":"+workflowDef.Name+":", 1)
+	assert.Equal(t, Sink(sink), ConductorSink("process_order", "order_created"))
+}
+
+func TestEventHandlerValidate(t *testing.T) {
+	err := NewEventHandler("", Sink("orders")).
+		Condition("($.type == 'created' &&").
+		Action(
+			StartWorkflow("", 0).Input("orderId", []interface{}{"${orderId"}),
+			CompleteTask("${workflowId}", ""),
+			UpdateWorkflowVariables(""),
+			TerminateWorkflow("${}", ""),
+		).Validate()
+	assert.EqualError(t, err, "event handler  is invalid: "+strings.Join([]string{
+		"name is required",
+		`invalid event "orders", expected <type>:<name>, e.g. kafka:orders`,
+		`condition "($.type == 'created' &&" has an unclosed (`,
+		`condition "($.type == 'created' &&" ends with the operator &&`,
+		"start_workflow requires the name of the workflow",
+		`start_workflow input "${orderId" has an unclosed ${`,
+		"complete_task requires the task id or the workflow id and task reference name",
+		"update_workflow_variables requires the workflow id",
+		"update_workflow_variables requires at least one variable",
+		`terminate_workflow workflowId "${}" has an empty ${}`,
+	}, "; "))
+
+	err = NewEventHandler("templates", SqsSink("orders")).Condition("${type} == 'created'").Validate()
+	assert.EqualError(t, err, "event handler templates is invalid: "+
+		`condition "${type} == 'created'" refers to the payload as $.name, not ${name}; at least one action is required`)
+	assert.NoError(t, newOrderHandler().Validate())
+	assert.NoError(t, newOrderHandler().Condition(`$.note == "a (b"`).Validate())
+}
+
+func TestSync(t *testing.T) {
+	unchanged, _ := newOrderHandler().ToEventHandler()
+	changed, _ := NewEventHandler("payment_failed", SqsSink("payments")).Action(TerminateWorkflow("${workflowId}", "")).ToEventHandler()
+	changed.Active = false
+	existing := []model.EventHandler{*unchanged, *changed, {Name: "obsolete", Event: "sqs:old"}}
+	calls := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && r.URL.Path == "/event" {
+			json.NewEncoder(w).Encode(existing)
+			return
+		}
+		var handler model.EventHandler
+		json.NewDecoder(r.Body).Decode(&handler)
+		calls = append(calls, r.Method+" "+r.URL.Path+" "+handler.Name)
+	}))
+	defer server.Close()
+	eventHandlerClient := client.NewEventHandlerClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+
+	handlers := []*EventHandler{
+		newOrderHandler(),
+		NewEventHandler("payment_failed", SqsSink("payments")).Action(TerminateWorkflow("${workflowId}", "")),
+		NewEventHandler("shipment", NatsSink("shipments")).Action(CompleteTask("${workflowId}", "ship_ref")),
+	}
+	result, err := Sync(context.Background(), eventHandlerClient, handlers, SyncOptions{Prune: true, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &SyncResult{Created: []string{"shipment"}, Updated: []string{"payment_failed"}, Deleted: []string{"obsolete"}, Unchanged: []string{"order_created"}}, result)
+	assert.Empty(t, calls)
+
+	_, err = Sync(context.Background(), eventHandlerClient, handlers, SyncOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"PUT /event payment_failed", "POST /event shipment", "DELETE /event/obsolete "}, calls)
+}
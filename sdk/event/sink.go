@@ -0,0 +1,73 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package event
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Sink the queue an event handler listens to, in the same format as the sinks of the EVENT tasks,
+// e.g. workflow.NewSqsEventTask("notify", "orders") publishes to SqsSink("orders")
+type Sink string
+
+// SqsSink the SQS queue
+func SqsSink(queueName string) Sink {
+	return newSink("sqs", queueName)
+}
+
+// ConductorSink the internal queue of the server the events of the workflow are published to.  The server adds the
+// name of the workflow to the sink of the EVENT tasks, e.g. ConductorSink("process_order", "order_created") for the
+// events published by workflow.NewConductorEventTask(ref, "order_created") in the process_order workflow
+func ConductorSink(workflowName string, eventName string) Sink {
+	return newSink("conductor", workflowName+":"+eventName)
+}
+
+// KafkaSink the Kafka topic
+func KafkaSink(topic string) Sink {
+	return newSink("kafka", topic)
+}
+
+// AmqpSink the AMQP queue
+func AmqpSink(queueName string) Sink {
+	return newSink("amqp", queueName)
+}
+
+// AmqpExchangeSink the AMQP exchange
+func AmqpExchangeSink(exchange string) Sink {
+	return newSink("amqp_exchange", exchange)
+}
+
+// NatsSink the NATS subject
+func NatsSink(subject string) Sink {
+	return newSink("nats", subject)
+}
+
+func newSink(queueType string, name string) Sink {
+	return Sink(queueType + ":" + name)
+}
+
+func (sink Sink) String() string {
+	return string(sink)
+}
+
+// Type the type of the queue, e.g. kafka
+func (sink Sink) Type() string {
+	return strings.SplitN(string(sink), ":", 2)[0]
+}
+
+// Validate checks the sink has the <type>:<name> format
+func (sink Sink) Validate() error {
+	parts := strings.SplitN(string(sink), ":", 2)
+	if len(parts) != 2 || strings.TrimSpace(parts[0]) == "" || strings.TrimSpace(parts[1]) == "" {
+		return fmt.Errorf("invalid event %q, expected <type>:<name>, e.g. kafka:orders", string(sink))
+	}
+	return nil
+}
@@ -0,0 +1,110 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package event
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// SyncOptions controls how Sync reconciles the event handlers on the server
+type SyncOptions struct {
+	// Prune removes the handlers on the server that are not part of the desired handlers
+	Prune bool
+	// DryRun computes the changes without applying them
+	DryRun bool
+}
+
+// SyncResult the names of the handlers by the change applied, or to be applied when DryRun is set
+type SyncResult struct {
+	Created   []string `json:"created"`
+	Updated   []string `json:"updated"`
+	Deleted   []string `json:"deleted"`
+	Unchanged []string `json:"unchanged"`
+}
+
+func (result *SyncResult) HasChanges() bool {
+	return len(result.Created)+len(result.Updated)+len(result.Deleted) > 0
+}
+
+// Sync reconciles the desired event handlers with the handlers on the server: the missing handlers are added, the
+// handlers that differ are updated and, with Prune, the handlers not desired are removed.
+// All the handlers are validated offline before any change is made
+func Sync(ctx context.Context, eventHandlerClient client.EventHandlerClient, handlers []*EventHandler, options SyncOptions) (*SyncResult, error) {
+	desired := make(map[string]*model.EventHandler, len(handlers))
+	names := make([]string, 0, len(handlers))
+	for _, handler := range handlers {
+		eventHandler, err := handler.ToEventHandler()
+		if err != nil {
+			return nil, err
+		}
+		desired[eventHandler.Name] = eventHandler
+		names = append(names, eventHandler.Name)
+	}
+	sort.Strings(names)
+
+	existingHandlers, _, err := eventHandlerClient.GetEventHandlers(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]model.EventHandler, len(existingHandlers))
+	for _, handler := range existingHandlers {
+		existing[handler.Name] = handler
+	}
+
+	result := &SyncResult{Created: []string{}, Updated: []string{}, Deleted: []string{}, Unchanged: []string{}}
+	for _, name := range names {
+		eventHandler := desired[name]
+		current, found := existing[name]
+		switch {
+		case !found:
+			result.Created = append(result.Created, name)
+			if !options.DryRun {
+				if _, err := eventHandlerClient.AddEventHandler(ctx, *eventHandler); err != nil {
+					return result, err
+				}
+			}
+		case eventHandlerEquals(eventHandler, &current):
+			result.Unchanged = append(result.Unchanged, name)
+		default:
+			result.Updated = append(result.Updated, name)
+			if !options.DryRun {
+				if _, err := eventHandlerClient.UpdateEventHandler(ctx, *eventHandler); err != nil {
+					return result, err
+				}
+			}
+		}
+	}
+	if options.Prune {
+		for _, handler := range existingHandlers {
+			if _, isDesired := desired[handler.Name]; isDesired {
+				continue
+			}
+			result.Deleted = append(result.Deleted, handler.Name)
+			if !options.DryRun {
+				if _, err := eventHandlerClient.RemoveEventHandler(ctx, handler.Name); err != nil {
+					return result, err
+				}
+			}
+		}
+		sort.Strings(result.Deleted)
+	}
+	return result, nil
+}
+
+// eventHandlerEquals compares the JSON representation of the handlers, ignoring the empty values
+func eventHandlerEquals(desired *model.EventHandler, current *model.EventHandler) bool {
+	return reflect.DeepEqual(internal.ToProperties(desired, internal.IsEmpty), internal.ToProperties(current, internal.IsEmpty))
+}
@@ -0,0 +1,107 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package humantask
+
+import (
+	"context"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model/human"
+)
+
+const defaultPageSize = 50
+
+// TaskIterator iterates over the results of a search, fetching the pages as needed
+//
+//	tasks := worklist.Inbox(ctx, humantask.ExternalUser("jane"))
+//	for tasks.Next() {
+//		task := tasks.Task()
+//	}
+//	if err := tasks.Err(); err != nil {
+//	}
+type TaskIterator struct {
+	ctx       context.Context
+	client    client.HumanTaskClient
+	search    human.HumanTaskSearch
+	page      []human.HumanTaskEntry
+	index     int
+	totalHits int64
+	done      bool
+	err       error
+}
+
+func newTaskIterator(ctx context.Context, humanTaskClient client.HumanTaskClient, search human.HumanTaskSearch) *TaskIterator {
+	if search.Size <= 0 {
+		search.Size = defaultPageSize
+	}
+	return &TaskIterator{
+		ctx:    ctx,
+		client: humanTaskClient,
+		search: search,
+		index:  -1,
+	}
+}
+
+// Next advances to the next task, returns false when there are no more tasks or the search failed, see Err
+func (it *TaskIterator) Next() bool {
+	if it.err != nil {
+		return false
+	}
+	it.index++
+	for it.index >= len(it.page) {
+		if it.done {
+			return false
+		}
+		if !it.fetch() {
+			return false
+		}
+	}
+	return true
+}
+
+// Task the current task
+func (it *TaskIterator) Task() human.HumanTaskEntry {
+	return it.page[it.index]
+}
+
+// Err the error of the search, if any
+func (it *TaskIterator) Err() error {
+	return it.err
+}
+
+// TotalHits number of tasks matching the search, known once the first page is fetched
+func (it *TaskIterator) TotalHits() int64 {
+	return it.totalHits
+}
+
+// All collects the remaining tasks
+func (it *TaskIterator) All() ([]human.HumanTaskEntry, error) {
+	tasks := make([]human.HumanTaskEntry, 0)
+	for it.Next() {
+		tasks = append(tasks, it.Task())
+	}
+	return tasks, it.Err()
+}
+
+func (it *TaskIterator) fetch() bool {
+	result, _, err := it.client.Search(it.ctx, it.search)
+	if err != nil {
+		it.err = err
+		return false
+	}
+	it.page = result.Results
+	it.index = 0
+	it.totalHits = result.TotalHits
+	it.search.Start += int32(len(result.Results))
+	if len(result.Results) < int(it.search.Size) || (result.TotalHits > 0 && int64(it.search.Start) >= result.TotalHits) {
+		it.done = true
+	}
+	return len(result.Results) > 0
+}
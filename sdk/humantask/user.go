@@ -0,0 +1,56 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package humantask
+
+import "github.com/conductor-sdk/conductor-go/sdk/model/human"
+
+// User types of human.HumanTaskUser
+const (
+	ExternalUserType   = "EXTERNAL_USER"
+	ExternalGroupType  = "EXTERNAL_GROUP"
+	ConductorUserType  = "CONDUCTOR_USER"
+	ConductorGroupType = "CONDUCTOR_GROUP"
+)
+
+// States of human.HumanTaskEntry
+const (
+	PendingState    = "PENDING"
+	AssignedState   = "ASSIGNED"
+	InProgressState = "IN_PROGRESS"
+	CompletedState  = "COMPLETED"
+	TimedOutState   = "TIMED_OUT"
+	DeletedState    = "DELETED"
+)
+
+// Search types of human.HumanTaskSearch, inbox searches the tasks of the current user
+const (
+	InboxSearch = "INBOX"
+	AdminSearch = "ADMIN"
+)
+
+// ExternalUser user of an external identity provider, e.g. the users of an approval UI
+func ExternalUser(id string) human.HumanTaskUser {
+	return human.HumanTaskUser{User: id, UserType: ExternalUserType}
+}
+
+// ExternalGroup group of an external identity provider
+func ExternalGroup(id string) human.HumanTaskUser {
+	return human.HumanTaskUser{User: id, UserType: ExternalGroupType}
+}
+
+// ConductorUser user of the Conductor server
+func ConductorUser(id string) human.HumanTaskUser {
+	return human.HumanTaskUser{User: id, UserType: ConductorUserType}
+}
+
+// ConductorGroup group of the Conductor server
+func ConductorGroup(id string) human.HumanTaskUser {
+	return human.HumanTaskUser{User: id, UserType: ConductorGroupType}
+}
@@ -0,0 +1,164 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package humantask
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/model/human"
+	"github.com/conductor-sdk/conductor-go/sdk/schema"
+)
+
+// Worklist claim and complete flow of the human tasks, e.g. for the approval UIs.
+// The outputs are validated against the JSON schema of the user form template of the task before being sent
+type Worklist struct {
+	client    client.HumanTaskClient
+	mutex     sync.Mutex
+	templates map[string]map[string]interface{}
+}
+
+func NewWorklist(humanTaskClient client.HumanTaskClient) *Worklist {
+	return &Worklist{
+		client:    humanTaskClient,
+		templates: make(map[string]map[string]interface{}),
+	}
+}
+
+// Search the tasks, the pages are fetched as the iterator advances.  Start and Size of the search set the first task
+// and the page size
+func (w *Worklist) Search(ctx context.Context, search human.HumanTaskSearch) *TaskIterator {
+	return newTaskIterator(ctx, w.client, search)
+}
+
+// Inbox the open tasks assigned to the user, either waiting to be claimed or in progress
+func (w *Worklist) Inbox(ctx context.Context, user human.HumanTaskUser) *TaskIterator {
+	return w.Search(ctx, human.HumanTaskSearch{
+		SearchType: InboxSearch,
+		Assignees:  []human.HumanTaskUser{user},
+		States:     []string{AssignedState, InProgressState},
+	})
+}
+
+// Get the task along with its template
+func (w *Worklist) Get(ctx context.Context, taskId string) (*human.HumanTaskEntry, error) {
+	task, _, err := w.client.GetTask1(ctx, taskId, &client.HumanTaskApiGetTask1Opts{WithTemplate: optional.NewBool(true)})
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// Claim the task for the user of the client
+func (w *Worklist) Claim(ctx context.Context, taskId string) (*human.HumanTaskEntry, error) {
+	task, _, err := w.client.ClaimTask(ctx, taskId, &client.HumanTaskApiClaimTaskOpts{WithTemplate: optional.NewBool(true)})
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// ClaimAs claims the task on behalf of the external user
+func (w *Worklist) ClaimAs(ctx context.Context, taskId string, externalUserId string) (*human.HumanTaskEntry, error) {
+	task, _, err := w.client.AssignAndClaim(ctx, taskId, externalUserId, &client.HumanTaskApiAssignAndClaimOpts{WithTemplate: optional.NewBool(true)})
+	if err != nil {
+		return nil, err
+	}
+	return &task, nil
+}
+
+// Complete the task with the output, a struct serializable to a JSON map or a map.
+// Returns a *schema.ValidationError if the output does not match the form of the task
+func (w *Worklist) Complete(ctx context.Context, taskId string, output interface{}) error {
+	outputData, err := w.Validate(ctx, taskId, output)
+	if err != nil {
+		return err
+	}
+	_, err = w.client.UpdateTaskOutput(ctx, outputData, taskId, &client.HumanTaskApiUpdateTaskOutputOpts{Complete: optional.NewBool(true)})
+	return err
+}
+
+// Validate the output against the JSON schema of the form of the task, returns the output as a map
+func (w *Worklist) Validate(ctx context.Context, taskId string, output interface{}) (map[string]interface{}, error) {
+	outputData, err := model.ConvertToMap(output)
+	if err != nil {
+		return nil, err
+	}
+	if outputData == nil {
+		outputData = map[string]interface{}{}
+	}
+	task, err := w.Get(ctx, taskId)
+	if err != nil {
+		return nil, err
+	}
+	formSchema, err := w.formSchema(ctx, task)
+	if err != nil {
+		return nil, err
+	}
+	if len(formSchema) == 0 {
+		return outputData, nil
+	}
+	if err := schema.Validate(formSchema, outputData); err != nil {
+		return nil, err
+	}
+	return outputData, nil
+}
+
+// Release the claimed task so that other assignees can claim it
+func (w *Worklist) Release(ctx context.Context, taskId string) error {
+	_, err := w.client.ReleaseTask(ctx, taskId)
+	return err
+}
+
+// Reassign the task to the assignees, each one having slaMinutes to complete it before the next one is assigned
+func (w *Worklist) Reassign(ctx context.Context, taskId string, slaMinutes int64, assignees ...human.HumanTaskUser) error {
+	if len(assignees) == 0 {
+		return fmt.Errorf("at least one assignee is required")
+	}
+	assignments := make([]human.HumanTaskAssignment, len(assignees))
+	for i := range assignees {
+		assignments[i] = human.HumanTaskAssignment{Assignee: &assignees[i], SlaMinutes: slaMinutes}
+	}
+	_, err := w.client.ReassignTask(ctx, assignments, taskId)
+	return err
+}
+
+// formSchema the JSON schema of the template of the task, fetched by name and version when the task does not include it
+func (w *Worklist) formSchema(ctx context.Context, task *human.HumanTaskEntry) (map[string]interface{}, error) {
+	definition := task.HumanTaskDef
+	if definition == nil {
+		return nil, nil
+	}
+	if definition.FullTemplate != nil && len(definition.FullTemplate.JsonSchema) > 0 {
+		return definition.FullTemplate.JsonSchema, nil
+	}
+	if definition.UserFormTemplate == nil || definition.UserFormTemplate.Name == "" {
+		return nil, nil
+	}
+	key := fmt.Sprintf("%s:%d", definition.UserFormTemplate.Name, definition.UserFormTemplate.Version)
+	w.mutex.Lock()
+	formSchema, ok := w.templates[key]
+	w.mutex.Unlock()
+	if ok {
+		return formSchema, nil
+	}
+	template, _, err := w.client.GetUserFormTemplate(ctx, definition.UserFormTemplate.Name, definition.UserFormTemplate.Version)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the template %s: %w", key, err)
+	}
+	w.mutex.Lock()
+	w.templates[key] = template.JsonSchema
+	w.mutex.Unlock()
+	return template.JsonSchema, nil
+}
@@ -0,0 +1,148 @@
+package humantask
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model/human"
+	"github.com/conductor-sdk/conductor-go/sdk/schema"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+type Approval struct {
+	Approved bool   `json:"approved"`
+	Comment  string `json:"comment,omitempty"`
+}
+
+type fakeServer struct {
+	tasks     []human.HumanTaskEntry
+	searches  []human.HumanTaskSearch
+	requests  []string
+	bodies    map[string]interface{}
+	templates int
+}
+
+func (s *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	request := r.Method + " " + r.URL.Path
+	if r.URL.RawQuery != "" {
+		request += "?" + r.URL.RawQuery
+	}
+	s.requests = append(s.requests, request)
+	switch {
+	case r.URL.Path == "/human/tasks/search":
+		var search human.HumanTaskSearch
+		json.NewDecoder(r.Body).Decode(&search)
+		s.searches = append(s.searches, search)
+		end := int(search.Start + search.Size)
+		if end > len(s.tasks) {
+			end = len(s.tasks)
+		}
+		json.NewEncoder(w).Encode(human.HumanTaskSearchResult{Results: s.tasks[search.Start:end], TotalHits: int64(len(s.tasks))})
+	case r.URL.Path == "/human/template/approval_form/2":
+		s.templates++
+		json.NewEncoder(w).Encode(human.HumanTaskTemplate{Name: "approval_form", Version: 2, JsonSchema: schema.Generate(Approval{})})
+	case r.URL.Path == "/human/tasks/inline" || r.URL.Path == "/human/tasks/inline/claim":
+		json.NewEncoder(w).Encode(human.HumanTaskEntry{TaskId: "inline", HumanTaskDef: &human.HumanTaskDefinition{
+			FullTemplate: &human.HumanTaskTemplate{JsonSchema: map[string]interface{}{"type": "object", "required": []string{"comment"}}},
+		}})
+	case r.Method == http.MethodGet && len(r.URL.Path) > len("/human/tasks/"):
+		json.NewEncoder(w).Encode(human.HumanTaskEntry{TaskId: "task_1", HumanTaskDef: &human.HumanTaskDefinition{
+			UserFormTemplate: &human.UserFormTemplate{Name: "approval_form", Version: 2},
+		}})
+	default:
+		var body interface{}
+		json.NewDecoder(r.Body).Decode(&body)
+		if s.bodies == nil {
+			s.bodies = make(map[string]interface{})
+		}
+		s.bodies[request] = body
+		json.NewEncoder(w).Encode(human.HumanTaskEntry{TaskId: "task_1"})
+	}
+}
+
+func newTestWorklist(t *testing.T, fake *fakeServer) *Worklist {
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return NewWorklist(client.NewHumanTaskClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))))
+}
+
+func TestInboxPagination(t *testing.T) {
+	fake := &fakeServer{}
+	for _, id := range []string{"1", "2", "3", "4", "5"} {
+		fake.tasks = append(fake.tasks, human.HumanTaskEntry{TaskId: id})
+	}
+	worklist := newTestWorklist(t, fake)
+
+	tasks := worklist.Search(context.Background(), human.HumanTaskSearch{Size: 2})
+	ids := make([]string, 0)
+	for tasks.Next() {
+		ids = append(ids, tasks.Task().TaskId)
+	}
+	assert.NoError(t, tasks.Err())
+	assert.Equal(t, []string{"1", "2", "3", "4", "5"}, ids)
+	assert.Equal(t, int64(5), tasks.TotalHits())
+	assert.Len(t, fake.searches, 3)
+
+	fake.searches = nil
+	inbox, err := worklist.Inbox(context.Background(), ExternalUser("jane")).All()
+	assert.NoError(t, err)
+	assert.Len(t, inbox, 5)
+	assert.Equal(t, human.HumanTaskSearch{
+		SearchType: InboxSearch,
+		Assignees:  []human.HumanTaskUser{{User: "jane", UserType: ExternalUserType}},
+		States:     []string{AssignedState, InProgressState},
+		Size:       defaultPageSize,
+	}, fake.searches[0])
+}
+
+func TestSearchError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer server.Close()
+	worklist := NewWorklist(client.NewHumanTaskClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))))
+	tasks := worklist.Inbox(context.Background(), ExternalUser("jane"))
+	assert.False(t, tasks.Next())
+	assert.Error(t, tasks.Err())
+}
+
+func TestClaimCompleteFlow(t *testing.T) {
+	fake := &fakeServer{}
+	worklist := newTestWorklist(t, fake)
+	ctx := context.Background()
+
+	_, err := worklist.Claim(ctx, "task_1")
+	assert.NoError(t, err)
+	_, err = worklist.ClaimAs(ctx, "task_1", "jane")
+	assert.NoError(t, err)
+
+	err = worklist.Complete(ctx, "task_1", map[string]interface{}{"comment": 42})
+	var validationErr *schema.ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Len(t, validationErr.Problems, 2)
+
+	assert.NoError(t, worklist.Complete(ctx, "task_1", Approval{Approved: true, Comment: "ok"}))
+	assert.Equal(t, 1, fake.templates)
+	assert.Equal(t, map[string]interface{}{"approved": true, "comment": "ok"}, fake.bodies["PUT /human/tasks/task_1/update?complete=true"])
+
+	assert.Error(t, worklist.Complete(ctx, "inline", map[string]interface{}{}))
+
+	assert.NoError(t, worklist.Release(ctx, "task_1"))
+	assert.NoError(t, worklist.Reassign(ctx, "task_1", 30, ExternalUser("john"), ExternalGroup("approvers")))
+	assert.Equal(t, []interface{}{
+		map[string]interface{}{"assignee": map[string]interface{}{"user": "john", "userType": "EXTERNAL_USER"}, "slaMinutes": float64(30)},
+		map[string]interface{}{"assignee": map[string]interface{}{"user": "approvers", "userType": "EXTERNAL_GROUP"}, "slaMinutes": float64(30)},
+	}, fake.bodies["POST /human/tasks/task_1/reassign"])
+	assert.EqualError(t, worklist.Reassign(ctx, "task_1", 30), "at least one assignee is required")
+
+	assert.Contains(t, fake.requests, "POST /human/tasks/task_1/claim?withTemplate=true")
+	assert.Contains(t, fake.requests, "POST /human/tasks/task_1/externalUser/jane?withTemplate=true")
+	assert.Contains(t, fake.requests, "POST /human/tasks/task_1/release")
+}
@@ -0,0 +1,208 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package integrations
+
+import (
+	"github.com/conductor-sdk/conductor-go/sdk/model/integration"
+)
+
+// The categories of the integration providers
+const (
+	AIModelCategory       = "AI_MODEL"
+	VectorDBCategory      = "VECTOR_DB"
+	MessageBrokerCategory = "MESSAGE_BROKER"
+	RelationalDBCategory  = "RELATIONAL_DB"
+)
+
+// Config the configuration of an integration provider of a given type, see GetIntegrationProviderDefs for the types
+// and their fields supported by the server
+type Config interface {
+	// Type the type of the provider, e.g. openai
+	Type() string
+	Category() string
+	// Configuration the values of the fields of the provider, the empty values are left out
+	Configuration() map[integration.ConfigKey]interface{}
+}
+
+type OpenAI struct {
+	APIKey string
+	// Endpoint optional, the OpenAI API by default
+	Endpoint string
+}
+
+func (config OpenAI) Type() string     { return "openai" }
+func (config OpenAI) Category() string { return AIModelCategory }
+func (config OpenAI) Configuration() map[integration.ConfigKey]interface{} {
+	return values(integration.APIKey, config.APIKey, integration.Endpoint, config.Endpoint)
+}
+
+type AzureOpenAI struct {
+	APIKey string
+	// Endpoint the endpoint of the Azure OpenAI resource, e.g. https://my-resource.openai.azure.com
+	Endpoint string
+}
+
+func (config AzureOpenAI) Type() string     { return "azure_openai" }
+func (config AzureOpenAI) Category() string { return AIModelCategory }
+func (config AzureOpenAI) Configuration() map[integration.ConfigKey]interface{} {
+	return values(integration.APIKey, config.APIKey, integration.Endpoint, config.Endpoint)
+}
+
+type Anthropic struct {
+	APIKey string
+	// Endpoint optional, the Anthropic API by default
+	Endpoint string
+}
+
+func (config Anthropic) Type() string     { return "anthropic" }
+func (config Anthropic) Category() string { return AIModelCategory }
+func (config Anthropic) Configuration() map[integration.ConfigKey]interface{} {
+	return values(integration.APIKey, config.APIKey, integration.Endpoint, config.Endpoint)
+}
+
+type VertexAI struct {
+	ProjectName string
+	Region      string
+	// Publisher optional, the publisher of the models, google by default
+	Publisher string
+	// ServiceAccountCredentials the JSON key of the service account
+	ServiceAccountCredentials string
+}
+
+func (config VertexAI) Type() string     { return "vertex_ai" }
+func (config VertexAI) Category() string { return AIModelCategory }
+func (config VertexAI) Configuration() map[integration.ConfigKey]interface{} {
+	return values(
+		integration.ProjectName, config.ProjectName,
+		integration.Region, config.Region,
+		integration.Publisher, config.Publisher,
+		integration.ServiceAccountCredentials, config.ServiceAccountCredentials,
+	)
+}
+
+type Pinecone struct {
+	APIKey      string
+	Environment string
+	ProjectName string
+}
+
+func (config Pinecone) Type() string     { return "pineconedb" }
+func (config Pinecone) Category() string { return VectorDBCategory }
+func (config Pinecone) Configuration() map[integration.ConfigKey]interface{} {
+	return values(
+		integration.APIKey, config.APIKey,
+		integration.Environment, config.Environment,
+		integration.ProjectName, config.ProjectName,
+	)
+}
+
+type PostgresVector struct {
+	// DatasourceURL the JDBC URL of the database, e.g. jdbc:postgresql://localhost:5432/vectors
+	DatasourceURL string
+	User          string
+	Password      string
+}
+
+func (config PostgresVector) Type() string     { return "pgvectordb" }
+func (config PostgresVector) Category() string { return VectorDBCategory }
+func (config PostgresVector) Configuration() map[integration.ConfigKey]interface{} {
+	return values(
+		integration.DatasourceURL, config.DatasourceURL,
+		integration.User, config.User,
+		integration.Password, config.Password,
+	)
+}
+
+type Kafka struct {
+	// BootstrapServers the comma separated host:port of the brokers, the server stores them as the api key
+	BootstrapServers string
+	// AuthenticationType optional, e.g. NONE, PASSWORD_IN_JAAS_CONFIG, SASL_SSL
+	AuthenticationType string
+	User               string
+	Password           string
+	// ConnectionType optional, e.g. PLAINTEXT, SSL
+	ConnectionType           string
+	SchemaRegistryURL        string
+	SchemaRegistryAuthType   string
+	SchemaRegistryApiKey     string
+	SchemaRegistryApiSecret  string
+	ValueSubjectNameStrategy string
+}
+
+func (config Kafka) Type() string     { return "kafka" }
+func (config Kafka) Category() string { return MessageBrokerCategory }
+func (config Kafka) Configuration() map[integration.ConfigKey]interface{} {
+	return values(
+		integration.APIKey, config.BootstrapServers,
+		integration.AuthenticationType, config.AuthenticationType,
+		integration.User, config.User,
+		integration.Password, config.Password,
+		integration.ConnectionType, config.ConnectionType,
+		integration.SchemaRegistryURL, config.SchemaRegistryURL,
+		integration.SchemaRegistryAuthType, config.SchemaRegistryAuthType,
+		integration.SchemaRegistryApiKey, config.SchemaRegistryApiKey,
+		integration.SchemaRegistryApiSecret, config.SchemaRegistryApiSecret,
+		integration.ValueSubjectNameStrategy, config.ValueSubjectNameStrategy,
+	)
+}
+
+type RelationalDB struct {
+	// DatasourceURL the JDBC URL of the database, e.g. jdbc:mysql://localhost:3306/orders
+	DatasourceURL string
+	// JdbcDriver the class of the driver, e.g. com.mysql.cj.jdbc.Driver
+	JdbcDriver string
+	User       string
+	Password   string
+}
+
+func (config RelationalDB) Type() string     { return "relational_db" }
+func (config RelationalDB) Category() string { return RelationalDBCategory }
+func (config RelationalDB) Configuration() map[integration.ConfigKey]interface{} {
+	return values(
+		integration.DatasourceURL, config.DatasourceURL,
+		integration.JdbcDriver, config.JdbcDriver,
+		integration.User, config.User,
+		integration.Password, config.Password,
+	)
+}
+
+// Custom the configuration of a provider without a typed configuration
+type Custom struct {
+	ProviderType     string
+	ProviderCategory string
+	Values           map[integration.ConfigKey]interface{}
+}
+
+func (config Custom) Type() string     { return config.ProviderType }
+func (config Custom) Category() string { return config.ProviderCategory }
+func (config Custom) Configuration() map[integration.ConfigKey]interface{} {
+	configuration := make(map[integration.ConfigKey]interface{}, len(config.Values))
+	for key, value := range config.Values {
+		if !isEmpty(value) {
+			configuration[key] = value
+		}
+	}
+	return configuration
+}
+
+// values the map of the key value pairs without the empty values
+func values(pairs ...interface{}) map[integration.ConfigKey]interface{} {
+	configuration := make(map[integration.ConfigKey]interface{}, len(pairs)/2)
+	for i := 0; i+1 < len(pairs); i += 2 {
+		if !isEmpty(pairs[i+1]) {
+			configuration[pairs[i].(integration.ConfigKey)] = pairs[i+1]
+		}
+	}
+	return configuration
+}
+
+func isEmpty(value interface{}) bool {
+	return value == nil || value == ""
+}
@@ -0,0 +1,126 @@
+package integrations
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/model/integration"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+var defs = []model.IntegrationDef{
+	{
+		Name:     "OpenAI",
+		Type_:    "openai",
+		Category: AIModelCategory,
+		Configuration: []model.IntegrationDefFormField{
+			{FieldName: "api_key", Label: "API Key"},
+			{FieldName: "endpoint", Label: "Endpoint", Optional: true},
+		},
+	},
+	{
+		Name:     "Kafka",
+		Type_:    "kafka",
+		Category: MessageBrokerCategory,
+		Configuration: []model.IntegrationDefFormField{
+			{FieldName: "api_key", Label: "Bootstrap Server"},
+			{FieldName: "connectionType", Label: "Connection Type", DefaultValue: "PLAINTEXT",
+				ValueOptions: []model.Option{{Value: "PLAINTEXT"}, {Value: "SSL"}}},
+			{FieldName: "user", Label: "User", Optional: true},
+			{FieldName: "password", Label: "Password", Optional: true},
+		},
+	},
+	{
+		Name:     "Relational DB",
+		Type_:    "relational_db",
+		Category: RelationalDBCategory,
+		Configuration: []model.IntegrationDefFormField{
+			{FieldName: "datasourceURL", Label: "Datasource URL"},
+			{FieldName: "jdbcDriver", Label: "JDBC Driver"},
+			{FieldName: "user", Label: "user"},
+			{FieldName: "password", Label: "Password"},
+		},
+	},
+}
+
+func TestConfiguration(t *testing.T) {
+	update := NewProvider("kafka-orders", Kafka{BootstrapServers: "broker:9092", ConnectionType: "SSL"}).
+		WithDescription("orders cluster").
+		ToIntegrationUpdate()
+	assert.Equal(t, integration.IntegrationUpdate{
+		Category:      MessageBrokerCategory,
+		Configuration: map[integration.ConfigKey]interface{}{integration.APIKey: "broker:9092", integration.ConnectionType: "SSL"},
+		Description:   "orders cluster",
+		Enabled:       true,
+		Type_:         "kafka",
+	}, update)
+
+	vertex := VertexAI{ProjectName: "ml", Region: "us-central1", ServiceAccountCredentials: "{}"}
+	assert.Equal(t, map[integration.ConfigKey]interface{}{
+		integration.ProjectName:               "ml",
+		integration.Region:                    "us-central1",
+		integration.ServiceAccountCredentials: "{}",
+	}, vertex.Configuration())
+	assert.Equal(t, "vertex_ai", vertex.Type())
+
+	custom := Custom{ProviderType: "mistral", ProviderCategory: AIModelCategory, Values: map[integration.ConfigKey]interface{}{integration.APIKey: "key", integration.Endpoint: ""}}
+	assert.Equal(t, map[integration.ConfigKey]interface{}{integration.APIKey: "key"}, custom.Configuration())
+}
+
+func TestValidate(t *testing.T) {
+	assert.NoError(t, NewProvider("openai", OpenAI{APIKey: "sk-1"}).Validate(defs))
+	assert.NoError(t, NewProvider("kafka", Kafka{BootstrapServers: "broker:9092"}).Validate(defs))
+
+	err := NewProvider("orders-db", RelationalDB{DatasourceURL: "jdbc:mysql://db/orders"}).Validate(defs)
+	var validationErr *ValidationError
+	assert.True(t, errors.As(err, &validationErr))
+	assert.Equal(t, []string{"jdbcDriver (JDBC Driver)", "user", "password (Password)"}, validationErr.Missing)
+	assert.EqualError(t, err, "integration provider orders-db (relational_db): missing required fields jdbcDriver (JDBC Driver), user, password (Password)")
+
+	err = NewProvider("kafka", Kafka{BootstrapServers: "broker:9092", ConnectionType: "TLS", SchemaRegistryURL: "http://registry"}).Validate(defs)
+	assert.EqualError(t, err, "integration provider kafka (kafka): unknown fields schemaRegistryUrl; invalid values connectionType: TLS is not one of PLAINTEXT, SSL")
+
+	err = NewProvider("claude", Anthropic{APIKey: "key"}).Validate(defs)
+	assert.EqualError(t, err, "integration provider claude: the server does not support the type anthropic")
+	assert.EqualError(t, NewProvider("", OpenAI{}).Validate(defs), "the name of the integration provider is required")
+}
+
+func TestSave(t *testing.T) {
+	var saved []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet && r.URL.Path == "/integrations/def" {
+			json.NewEncoder(w).Encode(defs)
+			return
+		}
+		var update integration.IntegrationUpdate
+		json.NewDecoder(r.Body).Decode(&update)
+		saved = append(saved, r.Method+" "+r.URL.Path+" "+update.Type_)
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	integrationClient := client.NewIntegrationClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+
+	err := Save(context.Background(), integrationClient,
+		NewProvider("openai", OpenAI{APIKey: "sk-1"}),
+		NewProvider("orders-db", RelationalDB{DatasourceURL: "jdbc:mysql://db/orders"}),
+		NewProvider("events", Kafka{}),
+	)
+	assert.ErrorContains(t, err, "integration provider orders-db (relational_db): missing required fields")
+	assert.ErrorContains(t, err, "integration provider events (kafka): missing required fields api_key (Bootstrap Server)")
+	assert.Empty(t, saved)
+
+	err = Save(context.Background(), integrationClient,
+		NewProvider("openai", OpenAI{APIKey: "sk-1"}),
+		NewProvider("events", Kafka{BootstrapServers: "broker:9092"}),
+	)
+	assert.NoError(t, err)
+	assert.Equal(t, []string{"POST /integrations/provider/openai openai", "POST /integrations/provider/events kafka"}, saved)
+}
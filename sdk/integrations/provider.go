@@ -0,0 +1,180 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package integrations
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/model/integration"
+)
+
+// Provider an integration provider, e.g. the OpenAI account used by the LLM tasks, with its typed configuration
+type Provider struct {
+	Name        string
+	Description string
+	Config      Config
+}
+
+func NewProvider(name string, config Config) *Provider {
+	return &Provider{Name: name, Config: config}
+}
+
+func (provider *Provider) WithDescription(description string) *Provider {
+	provider.Description = description
+	return provider
+}
+
+// ToIntegrationUpdate the request saving the provider, enabled
+func (provider *Provider) ToIntegrationUpdate() integration.IntegrationUpdate {
+	return integration.IntegrationUpdate{
+		Category:      provider.Config.Category(),
+		Configuration: provider.Config.Configuration(),
+		Description:   provider.Description,
+		Enabled:       true,
+		Type_:         provider.Config.Type(),
+	}
+}
+
+// ValidationError the problems of the configuration of a provider against the form fields of its type
+type ValidationError struct {
+	Provider string
+	Type     string
+	// Missing the required fields without a value, as name (label)
+	Missing []string
+	// Unknown the fields that are not part of the type
+	Unknown []string
+	// Invalid the values that are not one of the options of their field
+	Invalid []string
+}
+
+func (err *ValidationError) Error() string {
+	var problems []string
+	if len(err.Missing) > 0 {
+		problems = append(problems, "missing required fields "+strings.Join(err.Missing, ", "))
+	}
+	if len(err.Unknown) > 0 {
+		problems = append(problems, "unknown fields "+strings.Join(err.Unknown, ", "))
+	}
+	if len(err.Invalid) > 0 {
+		problems = append(problems, "invalid values "+strings.Join(err.Invalid, ", "))
+	}
+	return fmt.Sprintf("integration provider %s (%s): %s", err.Provider, err.Type, strings.Join(problems, "; "))
+}
+
+// Validate checks the configuration against the definition of its type, as returned by GetIntegrationProviderDefs:
+// the required fields without a default value must be set, the fields must be part of the definition and the values
+// of the fields with options must be one of the options.  Returns a *ValidationError listing all the problems
+func (provider *Provider) Validate(defs []model.IntegrationDef) error {
+	if provider.Name == "" {
+		return errors.New("the name of the integration provider is required")
+	}
+	if provider.Config == nil {
+		return fmt.Errorf("integration provider %s: the configuration is required", provider.Name)
+	}
+	def, found := findDef(defs, provider.Config.Type())
+	if !found {
+		return fmt.Errorf("integration provider %s: the server does not support the type %s", provider.Name, provider.Config.Type())
+	}
+	configuration := provider.Config.Configuration()
+	validationErr := &ValidationError{Provider: provider.Name, Type: provider.Config.Type()}
+	fields := make(map[integration.ConfigKey]bool, len(def.Configuration))
+	for _, field := range def.Configuration {
+		key := integration.ConfigKey(field.FieldName)
+		fields[key] = true
+		value, isSet := configuration[key]
+		if !isSet {
+			if !field.Optional && field.DefaultValue == "" {
+				validationErr.Missing = append(validationErr.Missing, describe(field))
+			}
+			continue
+		}
+		if len(field.ValueOptions) > 0 && !isOption(field.ValueOptions, value) {
+			validationErr.Invalid = append(validationErr.Invalid, fmt.Sprintf("%s: %v is not one of %s", field.FieldName, value, options(field.ValueOptions)))
+		}
+	}
+	for key := range configuration {
+		if !fields[key] {
+			validationErr.Unknown = append(validationErr.Unknown, string(key))
+		}
+	}
+	sort.Strings(validationErr.Unknown)
+	if len(validationErr.Missing)+len(validationErr.Unknown)+len(validationErr.Invalid) > 0 {
+		return validationErr
+	}
+	return nil
+}
+
+// Save validates the providers against the definitions of the server and saves them.  Nothing is saved when one of
+// the providers is invalid
+func Save(ctx context.Context, integrationClient client.IntegrationClient, providers ...*Provider) error {
+	defs, _, err := integrationClient.GetIntegrationProviderDefs(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to get the integration provider definitions: %w", err)
+	}
+	var problems []error
+	for _, provider := range providers {
+		if err := provider.Validate(defs); err != nil {
+			problems = append(problems, err)
+		}
+	}
+	if len(problems) > 0 {
+		return errors.Join(problems...)
+	}
+	for _, provider := range providers {
+		if _, err := integrationClient.SaveIntegrationProvider(ctx, provider.ToIntegrationUpdate(), provider.Name); err != nil {
+			return fmt.Errorf("failed to save integration provider %s: %w", provider.Name, err)
+		}
+	}
+	return nil
+}
+
+func findDef(defs []model.IntegrationDef, providerType string) (model.IntegrationDef, bool) {
+	for _, def := range defs {
+		if def.Type_ == providerType {
+			return def, true
+		}
+	}
+	for _, def := range defs {
+		if def.Type_ == "" && def.Name == providerType {
+			return def, true
+		}
+	}
+	return model.IntegrationDef{}, false
+}
+
+func describe(field model.IntegrationDefFormField) string {
+	if field.Label == "" || field.Label == field.FieldName {
+		return field.FieldName
+	}
+	return field.FieldName + " (" + field.Label + ")"
+}
+
+func isOption(valueOptions []model.Option, value interface{}) bool {
+	for _, option := range valueOptions {
+		if option.Value == fmt.Sprint(value) {
+			return true
+		}
+	}
+	return false
+}
+
+func options(valueOptions []model.Option) string {
+	values := make([]string, 0, len(valueOptions))
+	for _, option := range valueOptions {
+		values = append(values, option.Value)
+	}
+	return strings.Join(values, ", ")
+}
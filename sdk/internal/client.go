@@ -0,0 +1,26 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package internal
+
+import (
+	"errors"
+	"net/http"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+)
+
+// IsNotFound returns true if the server responded 404, whether the API client returned the response or only the error
+func IsNotFound(response *http.Response, err error) bool {
+	if response != nil && response.StatusCode == http.StatusNotFound {
+		return true
+	}
+	var swaggerErr client.GenericSwaggerError
+	return errors.As(err, &swaggerErr) && swaggerErr.StatusCode() == http.StatusNotFound
+}
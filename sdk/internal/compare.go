@@ -0,0 +1,127 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package internal
+
+import (
+	"encoding/json"
+	"fmt"
+)
+
+// DefaultZoneId the zone used by the server when the schedule has none
+const DefaultZoneId = "UTC"
+
+// ScheduleProperties the properties of a model.SaveScheduleRequest or model.WorkflowScheduleModel compared with the
+// server, without the zero values.  The zone defaults to the one of the server, and only the name, version, correlation
+// id, input, task to domain and priority of the request starting the workflow are compared, the input as a whole
+func ScheduleProperties(schedule interface{}) map[string]interface{} {
+	properties := jsonObject(schedule)
+	comparable := selectProperties(properties, "cronExpression", "description", "paused", "runCatchupScheduleInstances",
+		"scheduleStartTime", "scheduleEndTime")
+	comparable["zoneId"] = DefaultZoneId
+	if zoneId, found := properties["zoneId"]; found && !IsZero(zoneId) {
+		comparable["zoneId"] = zoneId
+	}
+	if request, isObject := properties["startWorkflowRequest"].(map[string]interface{}); isObject {
+		comparable["startWorkflowRequest"] = selectProperties(request, "name", "version", "correlationId", "input",
+			"taskToDomain", "priority")
+	}
+	return comparable
+}
+
+// selectProperties the selected properties that are not zero
+func selectProperties(properties map[string]interface{}, selected ...string) map[string]interface{} {
+	result := make(map[string]interface{}, len(selected))
+	for _, property := range selected {
+		if value, found := properties[property]; found && !IsZero(value) {
+			result[property] = value
+		}
+	}
+	return result
+}
+
+// ToProperties the JSON properties of the value without the ignored properties and the empty values, as defined by
+// isEmpty, e.g. IsEmpty or IsZero
+func ToProperties(value interface{}, isEmpty func(value interface{}) bool, ignored ...string) map[string]interface{} {
+	properties := jsonObject(value)
+	for _, property := range ignored {
+		delete(properties, property)
+	}
+	return WithoutEmptyValues(properties, isEmpty).(map[string]interface{})
+}
+
+// WithoutEmptyValues the JSON value without the empty values of its objects, recursively
+func WithoutEmptyValues(value interface{}, isEmpty func(value interface{}) bool) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		result := make(map[string]interface{}, len(typed))
+		for key, item := range typed {
+			item = WithoutEmptyValues(item, isEmpty)
+			if isEmpty(item) {
+				continue
+			}
+			result[key] = item
+		}
+		return result
+	case []interface{}:
+		result := make([]interface{}, len(typed))
+		for i, item := range typed {
+			result[i] = WithoutEmptyValues(item, isEmpty)
+		}
+		return result
+	}
+	return value
+}
+
+// jsonObject the JSON properties of the value, empty when it is not an object
+func jsonObject(value interface{}) map[string]interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	var properties map[string]interface{}
+	if err := json.Unmarshal(data, &properties); err != nil || properties == nil {
+		return map[string]interface{}{}
+	}
+	return properties
+}
+
+// IsEmpty returns true for the empty JSON values: null, "", false, {} and []
+func IsEmpty(value interface{}) bool {
+	switch typed := value.(type) {
+	case nil:
+		return true
+	case string:
+		return typed == ""
+	case bool:
+		return !typed
+	case map[string]interface{}:
+		return len(typed) == 0
+	case []interface{}:
+		return len(typed) == 0
+	}
+	return false
+}
+
+// IsZero returns true for the empty JSON values and 0, the zero values of the Go types
+func IsZero(value interface{}) bool {
+	return IsEmpty(value) || value == float64(0)
+}
+
+// FormatValue the JSON representation of the value in the reports of the changes, (none) when unset
+func FormatValue(value interface{}) string {
+	if value == nil {
+		return "(none)"
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
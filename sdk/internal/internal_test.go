@@ -0,0 +1,77 @@
+package internal
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFormatValue(t *testing.T) {
+	assert.Equal(t, "(none)", FormatValue(nil))
+	assert.Equal(t, `{"team":"payments"}`, FormatValue(map[string]string{"team": "payments"}))
+}
+
+func TestScheduleProperties(t *testing.T) {
+	input := map[string]interface{}{"notify": false, "retries": 0}
+	request := ScheduleProperties(&model.SaveScheduleRequest{
+		Name:           "nightly",
+		CronExpression: "0 0 0 * * ?",
+		StartWorkflowRequest: &model.StartWorkflowRequest{Name: "charge", Version: 2, Input: input,
+			TaskToDomain: map[string]string{}, IdempotencyKey: "nightly"},
+	})
+	assert.Equal(t, map[string]interface{}{
+		"cronExpression": "0 0 0 * * ?",
+		"zoneId":         "UTC",
+		"startWorkflowRequest": map[string]interface{}{"name": "charge", "version": float64(2),
+			"input": map[string]interface{}{"notify": false, "retries": float64(0)}},
+	}, request)
+	assert.Equal(t, request, ScheduleProperties(&model.WorkflowScheduleModel{
+		Name:                 "nightly",
+		CronExpression:       "0 0 0 * * ?",
+		ZoneId:               "UTC",
+		CreateTime:           1700000000000,
+		StartWorkflowRequest: &model.StartWorkflowRequest{Name: "charge", Version: 2, Input: input},
+	}))
+	// the input is compared as a whole
+	assert.NotEqual(t, request, ScheduleProperties(&model.WorkflowScheduleModel{
+		CronExpression:       "0 0 0 * * ?",
+		StartWorkflowRequest: &model.StartWorkflowRequest{Name: "charge", Version: 2, Input: map[string]interface{}{}},
+	}))
+}
+
+func TestWithoutEmptyValues(t *testing.T) {
+	value := map[string]interface{}{
+		"name":        "charge",
+		"description": "",
+		"active":      false,
+		"priority":    float64(0),
+		"input":       map[string]interface{}{"key": nil},
+		"tags":        []interface{}{},
+		"tasks":       []interface{}{map[string]interface{}{"retry": float64(1), "optional": false}},
+	}
+	assert.Equal(t, map[string]interface{}{"name": "charge", "priority": float64(0), "tasks": []interface{}{map[string]interface{}{"retry": float64(1)}}},
+		WithoutEmptyValues(value, IsEmpty))
+	assert.Equal(t, map[string]interface{}{"name": "charge", "tasks": []interface{}{map[string]interface{}{"retry": float64(1)}}},
+		WithoutEmptyValues(value, IsZero))
+}
+
+func TestSleep(t *testing.T) {
+	assert.NoError(t, Sleep(context.Background(), time.Millisecond))
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, context.Canceled, Sleep(ctx, time.Hour))
+	assert.Equal(t, context.Canceled, Sleep(ctx, 0))
+}
+
+func TestIsNotFound(t *testing.T) {
+	assert.True(t, IsNotFound(&http.Response{StatusCode: http.StatusNotFound}, nil))
+	assert.True(t, IsNotFound(nil, client.NewGenericSwaggerError(nil, "not found", nil, http.StatusNotFound)))
+	assert.False(t, IsNotFound(&http.Response{StatusCode: http.StatusOK}, nil))
+	assert.False(t, IsNotFound(nil, client.NewGenericSwaggerError(nil, "unavailable", nil, http.StatusServiceUnavailable)))
+	assert.False(t, IsNotFound(nil, nil))
+}
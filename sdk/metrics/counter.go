@@ -80,6 +80,25 @@ var counterTemplates = map[MetricName]*MetricDetails{
 			WORKFLOW_TYPE,
 		},
 	),
+	LLM_TOKENS_USED: NewMetricDetails(
+		LLM_TOKENS_USED,
+		LLM_TOKENS_USED_DOC,
+		[]MetricLabel{
+			INTEGRATION,
+			MODEL,
+			WORKFLOW_TYPE,
+			TOKEN_TYPE,
+		},
+	),
+	LLM_COST: NewMetricDetails(
+		LLM_COST,
+		LLM_COST_DOC,
+		[]MetricLabel{
+			INTEGRATION,
+			MODEL,
+			WORKFLOW_TYPE,
+		},
+	),
 }
 
 func IncrementTaskPoll(taskType string) {
@@ -165,6 +184,32 @@ func IncrementWorkflowStartError(workflowType string, err error) {
 	)
 }
 
+// AddLlmTokensUsed adds the tokens used by a LLM call, tokenType is prompt or completion
+func AddLlmTokensUsed(integration string, model string, workflowType string, tokenType string, tokens float64) {
+	addCounter(
+		LLM_TOKENS_USED,
+		[]string{
+			integration,
+			model,
+			workflowType,
+			tokenType,
+		},
+		tokens,
+	)
+}
+
+func AddLlmCost(integration string, model string, workflowType string, cost float64) {
+	addCounter(
+		LLM_COST,
+		[]string{
+			integration,
+			model,
+			workflowType,
+		},
+		cost,
+	)
+}
+
 func incrementCounter(metricName MetricName, labelValues []string) {
 	// We skip incrementing if metrics collection is not yet enabled
 	if !collectionEnabled {
@@ -177,6 +222,18 @@ func incrementCounter(metricName MetricName, labelValues []string) {
 	}
 }
 
+func addCounter(metricName MetricName, labelValues []string, value float64) {
+	// We skip adding if metrics collection is not yet enabled, counters only go up
+	if !collectionEnabled || value <= 0 {
+		return
+	}
+
+	counter := getCounter(metricName, labelValues)
+	if *counter != nil {
+		(*counter).Add(value)
+	}
+}
+
 func newCounter(metricDetails *MetricDetails) *prometheus.CounterVec {
 	return prometheus.NewCounterVec(
 		prometheus.CounterOpts{
@@ -13,6 +13,8 @@ type MetricDocumentation string
 
 const (
 	EXTERNAL_PAYLOAD_USED_DOC     MetricDocumentation = "Incremented each time external payload storage is used"
+	LLM_COST_DOC                  MetricDocumentation = "Cost of the LLM calls, in the currency of the price table"
+	LLM_TOKENS_USED_DOC           MetricDocumentation = "Tokens used by the LLM calls"
 	TASK_ACK_ERROR_DOC            MetricDocumentation = "Task ack has encountered an exception"
 	TASK_ACK_FAILED_DOC           MetricDocumentation = "Task ack failed"
 	TASK_EXECUTE_ERROR_DOC        MetricDocumentation = "Execution error"
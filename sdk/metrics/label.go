@@ -14,9 +14,12 @@ type MetricLabel string
 const (
 	ENTITY_NAME      MetricLabel = "entityName"
 	EXCEPTION        MetricLabel = "exception"
+	INTEGRATION      MetricLabel = "integration"
+	MODEL            MetricLabel = "model"
 	OPERATION        MetricLabel = "operation"
 	PAYLOAD_TYPE     MetricLabel = "payload_type"
 	TASK_TYPE        MetricLabel = "taskType"
+	TOKEN_TYPE       MetricLabel = "tokenType"
 	WORKFLOW_TYPE    MetricLabel = "workflowType"
 	WORKFLOW_VERSION MetricLabel = "version"
 )
@@ -14,6 +14,8 @@ type MetricName string
 //List of metrics that are collected when metrics server is enabled
 const (
 	EXTERNAL_PAYLOAD_USED     MetricName = "external_payload_used"
+	LLM_COST                  MetricName = "llm_cost"
+	LLM_TOKENS_USED           MetricName = "llm_tokens_used"
 	TASK_EXECUTE_ERROR        MetricName = "task_execute_error"
 	TASK_EXECUTE_TIME         MetricName = "task_execute_time"
 	TASK_EXECUTION_QUEUE_FULL MetricName = "task_execution_queue_full"
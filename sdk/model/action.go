@@ -10,9 +10,22 @@
 package model
 
 type Action struct {
-	Action           string         `json:"action,omitempty"`
-	StartWorkflow    *StartWorkflow `json:"start_workflow,omitempty"`
-	CompleteTask     *TaskDetails   `json:"complete_task,omitempty"`
-	FailTask         *TaskDetails   `json:"fail_task,omitempty"`
-	ExpandInlineJSON bool           `json:"expandInlineJSON,omitempty"`
+	Action                  string                   `json:"action,omitempty"`
+	StartWorkflow           *StartWorkflow           `json:"start_workflow,omitempty"`
+	CompleteTask            *TaskDetails             `json:"complete_task,omitempty"`
+	FailTask                *TaskDetails             `json:"fail_task,omitempty"`
+	ExpandInlineJSON        bool                     `json:"expandInlineJSON,omitempty"`
+	UpdateWorkflowVariables *UpdateWorkflowVariables `json:"update_workflow_variables,omitempty"`
+	TerminateWorkflow       *TerminateWorkflow       `json:"terminate_workflow,omitempty"`
+}
+
+type UpdateWorkflowVariables struct {
+	WorkflowId  string                 `json:"workflowId,omitempty"`
+	Variables   map[string]interface{} `json:"variables,omitempty"`
+	AppendArray bool                   `json:"appendArray,omitempty"`
+}
+
+type TerminateWorkflow struct {
+	WorkflowId        string `json:"workflowId,omitempty"`
+	TerminationReason string `json:"terminationReason,omitempty"`
 }
@@ -14,3 +14,11 @@ type TaskMock struct {
 	QueueWaitTime int64                  `json:"queueWaitTime,omitempty"`
 	Status        string                 `json:"status,omitempty"`
 }
+
+// NewTaskMock creates a mock that completes the task execution with the given status and output
+func NewTaskMock(status TaskResultStatus, output map[string]interface{}) TaskMock {
+	return TaskMock{
+		Status: string(status),
+		Output: output,
+	}
+}
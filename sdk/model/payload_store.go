@@ -0,0 +1,19 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package model
+
+import "context"
+
+// PayloadStore reads and writes the payloads stored outside of the server, see settings.ExternalStorageSettings.
+// The uri is the location returned by the server for the payload, e.g. a pre-signed URL of the object in S3
+type PayloadStore interface {
+	Upload(ctx context.Context, uri string, payload []byte) error
+	Download(ctx context.Context, uri string) ([]byte, error)
+}
@@ -0,0 +1,41 @@
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+package model
+
+type SchemaType string
+
+const (
+	JsonSchema     SchemaType = "JSON"
+	AvroSchema     SchemaType = "AVRO"
+	ProtobufSchema SchemaType = "PROTOBUF"
+)
+
+// SchemaDef a versioned schema registered on the server.  Task and workflow definitions refer to the schemas by name
+// and version, see NewSchemaRef
+type SchemaDef struct {
+	OwnerApp    string                 `json:"ownerApp,omitempty"`
+	CreateTime  int64                  `json:"createTime,omitempty"`
+	UpdateTime  int64                  `json:"updateTime,omitempty"`
+	CreatedBy   string                 `json:"createdBy,omitempty"`
+	UpdatedBy   string                 `json:"updatedBy,omitempty"`
+	Name        string                 `json:"name"`
+	Version     int32                  `json:"version"`
+	Type_       SchemaType             `json:"type"`
+	Data        map[string]interface{} `json:"data,omitempty"`
+	ExternalRef string                 `json:"externalRef,omitempty"`
+}
+
+// NewSchemaRef reference to the registered schema, used as the input or output schema of the definitions
+func NewSchemaRef(name string, version int32) *SchemaDef {
+	return &SchemaDef{
+		Name:    name,
+		Version: version,
+		Type_:   JsonSchema,
+	}
+}
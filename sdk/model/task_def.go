@@ -36,4 +36,7 @@ type TaskDef struct {
 	BackoffScaleFactor          int32                  `json:"backoffScaleFactor,omitempty"`
 	Tags                        []TagObject            `json:"tags,omitempty"`
 	OverwriteTags               bool                   `json:"overwriteTags"`
+	InputSchema                 *SchemaDef             `json:"inputSchema,omitempty"`
+	OutputSchema                *SchemaDef             `json:"outputSchema,omitempty"`
+	EnforceSchema               bool                   `json:"enforceSchema,omitempty"`
 }
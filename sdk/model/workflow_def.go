@@ -32,4 +32,7 @@ type WorkflowDef struct {
 	InputTemplate                 map[string]interface{} `json:"inputTemplate,omitempty"`
 	Tags                          []TagObject            `json:"tags,omitempty"`
 	OverwriteTags                 bool                   `json:"overwriteTags"`
+	InputSchema                   *SchemaDef             `json:"inputSchema,omitempty"`
+	OutputSchema                  *SchemaDef             `json:"outputSchema,omitempty"`
+	EnforceSchema                 bool                   `json:"enforceSchema,omitempty"`
 }
@@ -0,0 +1,131 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package prompt
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// FileExtension the extension of the prompt template files loaded by LoadDir
+const FileExtension = ".prompt"
+
+const frontMatterDelimiter = "---"
+
+type frontMatter struct {
+	Name        string            `yaml:"name"`
+	Description string            `yaml:"description"`
+	Models      []string          `yaml:"models"`
+	Tags        map[string]string `yaml:"tags"`
+}
+
+// LoadDir loads the templates of all the .prompt files in the directory and its subdirectories.
+// A file holds the text of the template, optionally preceded by a YAML front matter:
+//
+//	---
+//	description: Summarizes a support ticket
+//	models:
+//	  - openai:gpt-4o
+//	tags:
+//	  team: support
+//	---
+//	Summarize the ticket ${ticket} in ${language}.
+//
+// The name of the template is the name of the file without the extension unless the front matter sets it.
+// The templates are validated and must be unique
+func LoadDir(dir string) ([]*Template, error) {
+	var files []string
+	err := filepath.WalkDir(dir, func(path string, entry fs.DirEntry, err error) error {
+		if err != nil {
+			return err
+		}
+		if !entry.IsDir() && filepath.Ext(path) == FileExtension {
+			files = append(files, path)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Strings(files)
+	templates := make([]*Template, 0, len(files))
+	for _, file := range files {
+		template, err := LoadFile(file)
+		if err != nil {
+			return nil, err
+		}
+		templates = append(templates, template)
+	}
+	if err := checkUnique(templates); err != nil {
+		return nil, err
+	}
+	return templates, nil
+}
+
+// LoadFile loads the template of a single file, see LoadDir for the format
+func LoadFile(path string) (*Template, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	name := strings.TrimSuffix(filepath.Base(path), filepath.Ext(path))
+	template, err := Parse(name, data)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	template.Source = path
+	return template, nil
+}
+
+// Parse reads the front matter and the text of a template, name is used when the front matter does not set it.
+// The trailing line breaks of the text are removed
+func Parse(name string, data []byte) (*Template, error) {
+	text := strings.ReplaceAll(string(data), "\r\n", "\n")
+	var header frontMatter
+	if strings.HasPrefix(text, frontMatterDelimiter+"\n") {
+		rest := text[len(frontMatterDelimiter)+1:]
+		end := strings.Index("\n"+rest, "\n"+frontMatterDelimiter+"\n")
+		if end < 0 && strings.HasSuffix(rest, "\n"+frontMatterDelimiter) {
+			end = len(rest) - len(frontMatterDelimiter)
+		}
+		if end < 0 {
+			return nil, fmt.Errorf("the front matter is not closed with %s", frontMatterDelimiter)
+		}
+		decoder := yaml.NewDecoder(bytes.NewReader([]byte(rest[:end])))
+		decoder.KnownFields(true)
+		if err := decoder.Decode(&header); err != nil && !errors.Is(err, io.EOF) {
+			return nil, fmt.Errorf("invalid front matter: %w", err)
+		}
+		text = strings.TrimPrefix(rest[end:], frontMatterDelimiter)
+		text = strings.TrimPrefix(text, "\n")
+	}
+	if header.Name != "" {
+		name = header.Name
+	}
+	template := NewTemplate(name, strings.TrimRight(text, "\n")).
+		WithDescription(header.Description).
+		WithModels(header.Models...)
+	for key, value := range header.Tags {
+		template.WithTag(key, value)
+	}
+	if err := template.Validate(); err != nil {
+		return nil, err
+	}
+	return template, nil
+}
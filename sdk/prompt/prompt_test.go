@@ -0,0 +1,185 @@
+package prompt
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/model/integration"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+var update = flag.Bool("update", false, "update the golden files of the rendered templates")
+
+// TestRenderGoldenFiles renders each template of testdata with the variables of the .json file of the same name and
+// compares the result with the .golden file, go test ./sdk/prompt -update rewrites the golden files
+func TestRenderGoldenFiles(t *testing.T) {
+	templates, err := LoadDir("testdata")
+	assert.NoError(t, err)
+	assert.Len(t, templates, 2)
+	for _, template := range templates {
+		t.Run(template.Name, func(t *testing.T) {
+			base := strings.TrimSuffix(template.Source, FileExtension)
+			data, err := os.ReadFile(base + ".json")
+			assert.NoError(t, err)
+			var variables map[string]interface{}
+			assert.NoError(t, json.Unmarshal(data, &variables))
+
+			rendered, err := template.Render(variables)
+			assert.NoError(t, err)
+			golden := base + ".golden"
+			if *update {
+				assert.NoError(t, os.WriteFile(golden, []byte(rendered), 0644))
+			}
+			expected, err := os.ReadFile(golden)
+			assert.NoError(t, err)
+			assert.Equal(t, string(expected), rendered)
+		})
+	}
+}
+
+func TestLoadFrontMatter(t *testing.T) {
+	template, err := LoadFile(filepath.Join("testdata", "summarize_ticket.prompt"))
+	assert.NoError(t, err)
+	assert.Equal(t, "summarize_ticket", template.Name)
+	assert.Equal(t, "Summarizes a support ticket for the agent", template.Description)
+	assert.Equal(t, []string{"openai:gpt-4o", "anthropic:claude-3-5-sonnet"}, template.Models)
+	assert.Equal(t, map[string]string{"team": "support"}, template.Tags)
+	assert.True(t, strings.HasPrefix(template.Text, "You are a support assistant"))
+	assert.True(t, strings.HasSuffix(template.Text, "${ticket}"))
+	assert.Equal(t, []string{"company", "language", "max_sentences", "ticket"}, template.Variables())
+
+	template, err = Parse("ignored", []byte("---\r\nname: greeting\r\n---\r\nHello ${name}\r\n"))
+	assert.NoError(t, err)
+	assert.Equal(t, "greeting", template.Name)
+	assert.Equal(t, "Hello ${name}", template.Text)
+
+	template, err = Parse("plain", []byte("---\n---\nHello"))
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello", template.Text)
+}
+
+func TestParseErrors(t *testing.T) {
+	_, err := Parse("open", []byte("---\ndescription: never closed\nHello"))
+	assert.EqualError(t, err, "the front matter is not closed with ---")
+	_, err = Parse("unknown", []byte("---\ntemperature: 0.2\n---\nHello"))
+	assert.ErrorContains(t, err, "invalid front matter")
+	_, err = Parse("broken", []byte("---\nmodels: [gpt-4o]\n---\nHello ${name and ${}"))
+	assert.EqualError(t, err, "prompt broken: empty variable ${}, unterminated variable, expected ${name}, model gpt-4o is not provider:model")
+	_, err = Parse("empty", []byte("---\ndescription: nothing\n---\n"))
+	assert.EqualError(t, err, "prompt empty: text is required")
+
+	dir := t.TempDir()
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "a.prompt"), []byte("---\nname: greeting\n---\nHello"), 0644))
+	assert.NoError(t, os.MkdirAll(filepath.Join(dir, "nested"), 0755))
+	assert.NoError(t, os.WriteFile(filepath.Join(dir, "nested", "greeting.prompt"), []byte("Hi"), 0644))
+	_, err = LoadDir(dir)
+	assert.ErrorContains(t, err, "prompt greeting is declared in")
+}
+
+func TestRenderMissingVariables(t *testing.T) {
+	template := NewTemplate("greeting", "Hello ${user.name}, your ${item} is ${status}. ${item}")
+	_, err := template.Render(map[string]interface{}{"user": map[string]interface{}{"id": 1}, "status": "shipped"})
+	assert.EqualError(t, err, "prompt greeting: no value for the variables user.name, item")
+
+	rendered, err := template.Render(map[string]interface{}{"user": map[string]interface{}{"name": "Ada"}, "item": 42, "status": false})
+	assert.NoError(t, err)
+	assert.Equal(t, "Hello Ada, your 42 is false. 42", rendered)
+}
+
+type fakeServer struct {
+	mutex     sync.Mutex
+	templates map[string]integration.PromptTemplate
+	calls     []string
+}
+
+func (server *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	w.Header().Set("Content-Type", "application/json")
+	if r.Method == http.MethodGet && r.URL.Path == "/prompts" {
+		templates := []integration.PromptTemplate{}
+		for _, template := range server.templates {
+			templates = append(templates, template)
+		}
+		json.NewEncoder(w).Encode(templates)
+		return
+	}
+	call := r.Method + " " + r.URL.Path
+	if r.URL.RawQuery != "" {
+		call += "?" + r.URL.RawQuery
+	}
+	if strings.HasSuffix(r.URL.Path, "/tags") {
+		var tags []model.Tag
+		json.NewDecoder(r.Body).Decode(&tags)
+		for _, tag := range tags {
+			call += " " + tag.Key + ":" + tag.Value
+		}
+	}
+	server.calls = append(server.calls, call)
+	w.Write([]byte("{}"))
+}
+
+func TestSync(t *testing.T) {
+	fake := &fakeServer{templates: map[string]integration.PromptTemplate{
+		"summarize": {
+			Name:         "summarize",
+			Description:  "Summarizes",
+			Template:     "Summarize ${text}",
+			Integrations: []string{"openai:gpt-4o"},
+			Tags:         []model.TagObject{{Key: "team", Value: "ops", Type_: "METADATA"}},
+		},
+		"classify": {Name: "classify", Template: "Classify ${text}"},
+		"obsolete": {Name: "obsolete", Template: "Unused"},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	promptClient := client.NewPromptClient(apiClient)
+	integrationClient := client.NewIntegrationClient(apiClient)
+	templates := []*Template{
+		NewTemplate("summarize", "Summarize ${text} in ${language}").
+			WithDescription("Summarizes").
+			WithModels("openai:gpt-4o", "anthropic:claude-3-5-sonnet").
+			WithTag("team", "support"),
+		NewTemplate("classify", "Classify ${text}"),
+		NewTemplate("translate", "Translate ${text}").WithModels("openai:gpt-4o"),
+	}
+
+	result, err := Sync(context.Background(), promptClient, integrationClient, templates, SyncOptions{Prune: true, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &SyncResult{
+		Created:     []string{"translate"},
+		Updated:     []string{"summarize"},
+		Deleted:     []string{"obsolete"},
+		Unchanged:   []string{"classify"},
+		Differences: map[string][]string{"summarize": {"text", "models", "tags"}},
+	}, result)
+	assert.True(t, result.HasChanges())
+	assert.Empty(t, fake.calls)
+
+	_, err = Sync(context.Background(), promptClient, integrationClient, templates, SyncOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, []string{
+		"POST /prompts/summarize?description=Summarizes&models=anthropic%3Aclaude-3-5-sonnet&models=openai%3Agpt-4o",
+		"POST /integrations/provider/anthropic/integration/claude-3-5-sonnet/prompt/summarize",
+		"DELETE /prompts/summarize/tags team:ops",
+		"PUT /prompts/summarize/tags team:support",
+		"POST /prompts/translate?description=&models=openai%3Agpt-4o",
+		"POST /integrations/provider/openai/integration/gpt-4o/prompt/translate",
+		"DELETE /prompts/obsolete",
+	}, fake.calls)
+
+	_, err = Sync(context.Background(), promptClient, nil, templates, SyncOptions{})
+	assert.EqualError(t, err, "prompt summarize: declares models but there is no integration client; prompt translate: declares models but there is no integration client")
+}
@@ -0,0 +1,208 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package prompt
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/model/integration"
+)
+
+// SyncOptions controls how Sync reconciles the templates on the server
+type SyncOptions struct {
+	// Prune deletes the templates on the server that are not part of the desired templates
+	Prune bool
+	// DryRun computes the changes without applying them
+	DryRun bool
+}
+
+// SyncResult the names of the templates by the change applied, or to be applied when DryRun is set.
+// Differences lists the properties that differ for each updated template: text, description, models or tags
+type SyncResult struct {
+	Created     []string            `json:"created"`
+	Updated     []string            `json:"updated"`
+	Deleted     []string            `json:"deleted"`
+	Unchanged   []string            `json:"unchanged"`
+	Differences map[string][]string `json:"differences"`
+}
+
+func (result *SyncResult) HasChanges() bool {
+	return len(result.Created)+len(result.Updated)+len(result.Deleted) > 0
+}
+
+// Sync reconciles the desired templates with the templates on the server: the missing templates are created, the
+// templates that differ are updated and, with Prune, the templates not desired are deleted.
+// The models and the tags are only reconciled for the templates that declare them.  The new models of a template are
+// associated with it through the integration client, which may be nil when no template declares models.
+// All the templates are validated locally before any change is made
+func Sync(ctx context.Context, promptClient client.PromptClient, integrationClient client.IntegrationClient, templates []*Template, options SyncOptions) (*SyncResult, error) {
+	desired := make(map[string]*Template, len(templates))
+	names := make([]string, 0, len(templates))
+	var problems []string
+	for _, template := range templates {
+		if err := template.Validate(); err != nil {
+			problems = append(problems, err.Error())
+			continue
+		}
+		if len(template.Models) > 0 && integrationClient == nil {
+			problems = append(problems, fmt.Sprintf("prompt %s: declares models but there is no integration client", template.Name))
+		}
+		desired[template.Name] = template
+		names = append(names, template.Name)
+	}
+	if err := checkUnique(templates); err != nil {
+		problems = append(problems, err.Error())
+	}
+	if len(problems) > 0 {
+		return nil, errors.New(strings.Join(problems, "; "))
+	}
+	sort.Strings(names)
+
+	existingTemplates, _, err := promptClient.GetMessageTemplates(ctx)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]integration.PromptTemplate, len(existingTemplates))
+	for _, template := range existingTemplates {
+		existing[template.Name] = template
+	}
+
+	result := &SyncResult{Created: []string{}, Updated: []string{}, Deleted: []string{}, Unchanged: []string{}, Differences: map[string][]string{}}
+	for _, name := range names {
+		template := desired[name]
+		current, found := existing[name]
+		var currentTemplate *Template
+		if found {
+			currentTemplate = FromPromptTemplate(current)
+			differences := Diff(currentTemplate, template)
+			if len(differences) == 0 {
+				result.Unchanged = append(result.Unchanged, name)
+				continue
+			}
+			result.Updated = append(result.Updated, name)
+			result.Differences[name] = differences
+		} else {
+			result.Created = append(result.Created, name)
+		}
+		if !options.DryRun {
+			if err := apply(ctx, promptClient, integrationClient, template, currentTemplate); err != nil {
+				return result, fmt.Errorf("failed to save prompt %s: %w", name, err)
+			}
+		}
+	}
+	if options.Prune {
+		for _, template := range existingTemplates {
+			if _, isDesired := desired[template.Name]; isDesired {
+				continue
+			}
+			result.Deleted = append(result.Deleted, template.Name)
+			if !options.DryRun {
+				if _, err := promptClient.DeleteMessageTemplate(ctx, template.Name); err != nil {
+					return result, fmt.Errorf("failed to delete prompt %s: %w", template.Name, err)
+				}
+			}
+		}
+		sort.Strings(result.Deleted)
+	}
+	return result, nil
+}
+
+// Diff the properties of the desired template that differ from the current one: text, description, models or tags.
+// The models and the tags are only compared when the desired template declares them
+func Diff(current *Template, desired *Template) []string {
+	differences := []string{}
+	if current.Text != desired.Text {
+		differences = append(differences, "text")
+	}
+	if current.Description != desired.Description {
+		differences = append(differences, "description")
+	}
+	if len(desired.Models) > 0 && strings.Join(sortedSet(current.Models), ",") != strings.Join(sortedSet(desired.Models), ",") {
+		differences = append(differences, "models")
+	}
+	if len(desired.Tags) > 0 && !tagsEqual(current.Tags, desired.Tags) {
+		differences = append(differences, "tags")
+	}
+	return differences
+}
+
+func apply(ctx context.Context, promptClient client.PromptClient, integrationClient client.IntegrationClient, template *Template, current *Template) error {
+	var optionals *client.PromptResourceApiSaveMessageTemplateOpts
+	if len(template.Models) > 0 {
+		optionals = &client.PromptResourceApiSaveMessageTemplateOpts{Models: sortedSet(template.Models)}
+	}
+	if _, err := promptClient.SaveMessageTemplate(ctx, template.Text, template.Description, template.Name, optionals); err != nil {
+		return err
+	}
+	associated := map[string]bool{}
+	if current != nil {
+		for _, m := range current.Models {
+			associated[m] = true
+		}
+	}
+	for _, m := range sortedSet(template.Models) {
+		if associated[m] {
+			continue
+		}
+		provider, modelName, _ := strings.Cut(m, ":")
+		if _, err := integrationClient.AssociatePromptWithIntegration(ctx, provider, modelName, template.Name); err != nil {
+			return fmt.Errorf("failed to associate the prompt with %s: %w", m, err)
+		}
+	}
+	if len(template.Tags) == 0 || (current != nil && tagsEqual(current.Tags, template.Tags)) {
+		return nil
+	}
+	if current != nil {
+		var removed []model.Tag
+		for key, value := range current.Tags {
+			if desiredValue, found := template.Tags[key]; !found || desiredValue != value {
+				removed = append(removed, model.Tag{Key: key, Value: value, Type_: "METADATA"})
+			}
+		}
+		if len(removed) > 0 {
+			if _, err := promptClient.DeleteTagForPromptTemplate(ctx, removed, template.Name); err != nil {
+				return err
+			}
+		}
+	}
+	_, err := promptClient.PutTagForPromptTemplate(ctx, template.tags(), template.Name)
+	return err
+}
+
+func sortedSet(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	sort.Strings(result)
+	return result
+}
+
+func tagsEqual(current map[string]string, desired map[string]string) bool {
+	if len(current) != len(desired) {
+		return false
+	}
+	for key, value := range desired {
+		if currentValue, found := current[key]; !found || currentValue != value {
+			return false
+		}
+	}
+	return true
+}
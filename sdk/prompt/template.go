@@ -0,0 +1,212 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package prompt
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/model/integration"
+)
+
+// the prompt variables, ${name}, ${user.name}
+var variable = regexp.MustCompile(`\$\{([^{}]*)\}`)
+
+// Template a prompt template of an LLM task.  The variables of the text, ${name}, are replaced with the prompt
+// variables of the task when the prompt is rendered
+type Template struct {
+	Name        string
+	Description string
+	// Models the integrations the template is used with, as provider:model, e.g. openai:gpt-4o
+	Models []string
+	Tags   map[string]string
+	Text   string
+	// Source the file the template was loaded from, if any
+	Source string
+}
+
+func NewTemplate(name string, text string) *Template {
+	return &Template{Name: name, Text: text, Models: []string{}, Tags: map[string]string{}}
+}
+
+func (template *Template) WithDescription(description string) *Template {
+	template.Description = description
+	return template
+}
+
+// WithModels the models the template is used with, as provider:model
+func (template *Template) WithModels(models ...string) *Template {
+	template.Models = append(template.Models, models...)
+	return template
+}
+
+func (template *Template) WithTag(key string, value string) *Template {
+	if template.Tags == nil {
+		template.Tags = map[string]string{}
+	}
+	template.Tags[key] = value
+	return template
+}
+
+// FromPromptTemplate the template as returned by the server
+func FromPromptTemplate(promptTemplate integration.PromptTemplate) *Template {
+	template := NewTemplate(promptTemplate.Name, promptTemplate.Template).
+		WithDescription(promptTemplate.Description).
+		WithModels(promptTemplate.Integrations...)
+	for _, tag := range promptTemplate.Tags {
+		template.WithTag(tag.Key, tag.Value)
+	}
+	return template
+}
+
+// Variables the names of the variables of the template in the order of their first use
+func (template *Template) Variables() []string {
+	seen := map[string]bool{}
+	names := []string{}
+	for _, match := range variable.FindAllStringSubmatch(template.Text, -1) {
+		name := strings.TrimSpace(match[1])
+		if !seen[name] {
+			seen[name] = true
+			names = append(names, name)
+		}
+	}
+	return names
+}
+
+// Render replaces the variables of the template with their values, as the server does for the LLM tasks.
+// Strings are used as they are, the other values are rendered as JSON.  A variable such as ${user.name} is looked up
+// as is first and then as the name field of the user variable.
+// Returns an error listing all the variables without a value
+func (template *Template) Render(variables map[string]interface{}) (string, error) {
+	var missing []string
+	rendered := variable.ReplaceAllStringFunc(template.Text, func(match string) string {
+		name := strings.TrimSpace(match[2 : len(match)-1])
+		value, found := lookup(variables, name)
+		if !found {
+			missing = append(missing, name)
+			return match
+		}
+		return format(value)
+	})
+	if len(missing) > 0 {
+		return "", fmt.Errorf("prompt %s: no value for the variables %s", template.Name, strings.Join(unique(missing), ", "))
+	}
+	return rendered, nil
+}
+
+func lookup(variables map[string]interface{}, name string) (interface{}, bool) {
+	if value, found := variables[name]; found {
+		return value, true
+	}
+	var current interface{} = variables
+	for _, field := range strings.Split(name, ".") {
+		object, isObject := current.(map[string]interface{})
+		if !isObject {
+			return nil, false
+		}
+		value, found := object[field]
+		if !found {
+			return nil, false
+		}
+		current = value
+	}
+	return current, true
+}
+
+func format(value interface{}) string {
+	switch value := value.(type) {
+	case nil:
+		return ""
+	case string:
+		return value
+	case fmt.Stringer:
+		return value.String()
+	}
+	data, err := json.Marshal(value)
+	if err != nil {
+		return fmt.Sprint(value)
+	}
+	return string(data)
+}
+
+func unique(values []string) []string {
+	seen := map[string]bool{}
+	result := []string{}
+	for _, value := range values {
+		if !seen[value] {
+			seen[value] = true
+			result = append(result, value)
+		}
+	}
+	return result
+}
+
+// Validate checks the name, the text, the variables and the models of the template
+func (template *Template) Validate() error {
+	var problems []string
+	if template.Name == "" {
+		problems = append(problems, "name is required")
+	}
+	if strings.TrimSpace(template.Text) == "" {
+		problems = append(problems, "text is required")
+	}
+	for _, name := range template.Variables() {
+		if name == "" {
+			problems = append(problems, "empty variable ${}")
+		}
+	}
+	if opened, closed := strings.Count(template.Text, "${"), len(variable.FindAllString(template.Text, -1)); opened != closed {
+		problems = append(problems, "unterminated variable, expected ${name}")
+	}
+	for _, m := range template.Models {
+		if provider, modelName, found := strings.Cut(m, ":"); !found || provider == "" || modelName == "" {
+			problems = append(problems, fmt.Sprintf("model %s is not provider:model", m))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("prompt %s: %s", template.Name, strings.Join(problems, ", "))
+	}
+	return nil
+}
+
+// tags the tags of the template sorted by key, as sent to the server
+func (template *Template) tags() []model.Tag {
+	keys := make([]string, 0, len(template.Tags))
+	for key := range template.Tags {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	tags := make([]model.Tag, 0, len(keys))
+	for _, key := range keys {
+		tags = append(tags, model.Tag{Key: key, Value: template.Tags[key], Type_: "METADATA"})
+	}
+	return tags
+}
+
+func checkUnique(templates []*Template) error {
+	sources := map[string]string{}
+	var problems []string
+	for _, template := range templates {
+		if source, found := sources[template.Name]; found {
+			problems = append(problems, fmt.Sprintf("prompt %s is declared in %s and %s", template.Name, source, template.Source))
+			continue
+		}
+		sources[template.Name] = template.Source
+	}
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	return nil
+}
@@ -0,0 +1,235 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package rbac
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"sort"
+	"strings"
+)
+
+type Access string
+
+const (
+	Create  Access = "CREATE"
+	Read    Access = "READ"
+	Update  Access = "UPDATE"
+	Execute Access = "EXECUTE"
+	Delete  Access = "DELETE"
+)
+
+// accesses in the order they are reported
+var accesses = []Access{Create, Read, Update, Execute, Delete}
+
+func (access Access) order() int {
+	for i, candidate := range accesses {
+		if candidate == access {
+			return i
+		}
+	}
+	return len(accesses)
+}
+
+func sortAccess(access []Access) {
+	sort.Slice(access, func(i, j int) bool {
+		if access[i].order() != access[j].order() {
+			return access[i].order() < access[j].order()
+		}
+		return access[i] < access[j]
+	})
+}
+
+// Subject the user, group or role the access is granted to
+type Subject struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+// User the user by id, e.g. the email of the user or app:<id> for an application
+func User(id string) Subject {
+	return Subject{Type: "USER", Id: id}
+}
+
+func Group(id string) Subject {
+	return Subject{Type: "GROUP", Id: id}
+}
+
+func Role(name string) Subject {
+	return Subject{Type: "ROLE", Id: name}
+}
+
+func (subject Subject) String() string {
+	return subject.Type + " " + subject.Id
+}
+
+// Target the resource the access is granted on.  The id of the targets listed by the reconciler may be a pattern,
+// e.g. WorkflowDef("charge_*"), matching the names as path.Match does
+type Target struct {
+	Type string `json:"type"`
+	Id   string `json:"id"`
+}
+
+func WorkflowDef(name string) Target {
+	return Target{Type: "WORKFLOW_DEF", Id: name}
+}
+
+func TaskDef(name string) Target {
+	return Target{Type: "TASK_DEF", Id: name}
+}
+
+func Schedule(name string) Target {
+	return Target{Type: "WORKFLOW_SCHEDULE", Id: name}
+}
+
+func Application(id string) Target {
+	return Target{Type: "APPLICATION", Id: id}
+}
+
+func Secret(name string) Target {
+	return Target{Type: "SECRET_NAME", Id: name}
+}
+
+func EnvVariable(name string) Target {
+	return Target{Type: "ENV_VARIABLE", Id: name}
+}
+
+func Domain(name string) Target {
+	return Target{Type: "DOMAIN", Id: name}
+}
+
+func IntegrationProvider(name string) Target {
+	return Target{Type: "INTEGRATION_PROVIDER", Id: name}
+}
+
+func Prompt(name string) Target {
+	return Target{Type: "PROMPT", Id: name}
+}
+
+// Tag all the resources with the tag
+func Tag(key string, value string) Target {
+	return Target{Type: "TAG", Id: key + ":" + value}
+}
+
+func (target Target) String() string {
+	return target.Type + " " + target.Id
+}
+
+// IsPattern returns true if the id contains the wildcards *, ? or [
+func (target Target) IsPattern() bool {
+	return strings.ContainsAny(target.Id, "*?[")
+}
+
+// Matches returns true if the name matches the id of the target, exactly or as a pattern
+func (target Target) Matches(name string) bool {
+	if !target.IsPattern() {
+		return target.Id == name
+	}
+	matched, err := path.Match(target.Id, name)
+	return err == nil && matched
+}
+
+// Rule grants the access to the subjects on the targets, e.g.
+//
+//	rbac.Allow(rbac.Group("payments")).To(rbac.Execute, rbac.Read).On(rbac.WorkflowDef("charge_*"))
+type Rule struct {
+	subjects []Subject
+	access   []Access
+	targets  []Target
+}
+
+func Allow(subjects ...Subject) *Rule {
+	return &Rule{subjects: subjects}
+}
+
+func (rule *Rule) To(access ...Access) *Rule {
+	rule.access = append(rule.access, access...)
+	return rule
+}
+
+func (rule *Rule) On(targets ...Target) *Rule {
+	rule.targets = append(rule.targets, targets...)
+	return rule
+}
+
+// Validate checks the rule offline
+func (rule *Rule) Validate() error {
+	var problems []string
+	if len(rule.subjects) == 0 {
+		problems = append(problems, "at least one subject is required")
+	}
+	for _, subject := range rule.subjects {
+		if subject.Type == "" || subject.Id == "" {
+			problems = append(problems, fmt.Sprintf("invalid subject %q", subject.String()))
+		}
+	}
+	if len(rule.access) == 0 {
+		problems = append(problems, "at least one access is required")
+	}
+	for _, access := range rule.access {
+		if access.order() == len(accesses) {
+			problems = append(problems, fmt.Sprintf("unknown access %s", access))
+		}
+	}
+	if len(rule.targets) == 0 {
+		problems = append(problems, "at least one target is required")
+	}
+	for _, target := range rule.targets {
+		if target.Type == "" || target.Id == "" {
+			problems = append(problems, fmt.Sprintf("invalid target %q", target.String()))
+		} else if _, err := path.Match(target.Id, ""); err != nil {
+			problems = append(problems, fmt.Sprintf("invalid pattern %s", target.Id))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return errors.New(strings.Join(problems, ", "))
+}
+
+// Policy the complete access of the subjects it names on the targets it names, see Reconciler
+type Policy struct {
+	rules []*Rule
+}
+
+func NewPolicy(rules ...*Rule) *Policy {
+	return &Policy{rules: rules}
+}
+
+func (policy *Policy) Add(rules ...*Rule) *Policy {
+	policy.rules = append(policy.rules, rules...)
+	return policy
+}
+
+// Validate checks all the rules offline
+func (policy *Policy) Validate() error {
+	var problems []string
+	for i, rule := range policy.rules {
+		if err := rule.Validate(); err != nil {
+			problems = append(problems, fmt.Sprintf("rule %d: %s", i+1, err))
+		}
+	}
+	if len(problems) == 0 {
+		return nil
+	}
+	return fmt.Errorf("invalid policy: %s", strings.Join(problems, "; "))
+}
+
+// subjects all the subjects named by the rules
+func (policy *Policy) subjects() map[Subject]bool {
+	subjects := map[Subject]bool{}
+	for _, rule := range policy.rules {
+		for _, subject := range rule.subjects {
+			subjects[subject] = true
+		}
+	}
+	return subjects
+}
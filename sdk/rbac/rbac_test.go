@@ -0,0 +1,106 @@
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	rbacmodel "github.com/conductor-sdk/conductor-go/sdk/model/rbac"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRuleValidate(t *testing.T) {
+	assert.NoError(t, Allow(Group("payments")).To(Execute, Read).On(WorkflowDef("charge_*")).Validate())
+	err := Allow().To("OWN").On(Target{Type: "WORKFLOW_DEF", Id: "[charge"}).Validate()
+	assert.EqualError(t, err, "at least one subject is required, unknown access OWN, invalid pattern [charge")
+
+	err = NewPolicy(Allow(User("bob@example.com")).To(Read)).Validate()
+	assert.EqualError(t, err, "invalid policy: rule 1: at least one target is required")
+}
+
+func TestTargetMatches(t *testing.T) {
+	assert.True(t, WorkflowDef("charge_*").Matches("charge_card"))
+	assert.False(t, WorkflowDef("charge_*").Matches("refund_card"))
+	assert.True(t, WorkflowDef("charge_card").Matches("charge_card"))
+	assert.False(t, WorkflowDef("charge_card").IsPattern())
+	assert.Equal(t, Target{Type: "TAG", Id: "team:payments"}, Tag("team", "payments"))
+}
+
+type fakeAuthorizationServer struct {
+	permissions map[string]map[string][]rbacmodel.SubjectRef
+	calls       []string
+}
+
+func (server *fakeAuthorizationServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	w.Header().Set("Content-Type", "application/json")
+	switch {
+	case r.Method == http.MethodGet && r.URL.Path == "/metadata/workflow":
+		json.NewEncoder(w).Encode([]model.WorkflowDef{{Name: "charge_card", Version: 1}, {Name: "charge_card", Version: 2}, {Name: "charge_refund"}, {Name: "shipping"}})
+	case r.Method == http.MethodGet && strings.HasPrefix(r.URL.Path, "/auth/authorization/"):
+		permissions := server.permissions[strings.TrimPrefix(r.URL.Path, "/auth/authorization/")]
+		if permissions == nil {
+			permissions = map[string][]rbacmodel.SubjectRef{}
+		}
+		json.NewEncoder(w).Encode(permissions)
+	default:
+		var request rbacmodel.AuthorizationRequest
+		json.NewDecoder(r.Body).Decode(&request)
+		server.calls = append(server.calls, r.Method+" "+request.Subject.Type_+" "+request.Subject.Id+" "+
+			strings.Join(request.Access, ",")+" "+request.Target.Type_+" "+request.Target.Id)
+	}
+}
+
+func TestReconcile(t *testing.T) {
+	fake := &fakeAuthorizationServer{permissions: map[string]map[string][]rbacmodel.SubjectRef{
+		"WORKFLOW_DEF/charge_card": {
+			"READ":   {{Type_: "GROUP", Id: "payments"}, {Type_: "USER", Id: "admin@example.com"}},
+			"UPDATE": {{Type_: "GROUP", Id: "payments"}, {Type_: "USER", Id: "admin@example.com"}},
+		},
+		"WORKFLOW_DEF/charge_refund": {
+			"READ":    {{Type_: "GROUP", Id: "payments"}},
+			"EXECUTE": {{Type_: "GROUP", Id: "payments"}},
+		},
+		"SECRET_NAME/stripe_key": {
+			"READ": {{Type_: "GROUP", Id: "support"}},
+		},
+	}}
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	reconciler := NewReconciler(client.NewAuthorizationClient(apiClient)).WithMetadataClient(client.NewMetadataClient(apiClient))
+
+	policy := NewPolicy(
+		Allow(Group("payments")).To(Execute, Read).On(WorkflowDef("charge_*")),
+		Allow(Group("payments"), Role("ops")).To(Read).On(Secret("stripe_key")),
+	)
+	result, err := reconciler.Reconcile(context.Background(), policy, Options{DryRun: true})
+	assert.NoError(t, err)
+	assert.Empty(t, fake.calls)
+	assert.Equal(t, `+ GROUP payments READ on SECRET_NAME stripe_key
++ ROLE ops READ on SECRET_NAME stripe_key
++ GROUP payments EXECUTE on WORKFLOW_DEF charge_card
+- GROUP payments UPDATE on WORKFLOW_DEF charge_card
+3 to grant, 1 to revoke
+`, result.String())
+
+	result, err = reconciler.Reconcile(context.Background(), policy, Options{Exclusive: true})
+	assert.NoError(t, err)
+	assert.True(t, result.HasChanges())
+	assert.Equal(t, []string{
+		"POST GROUP payments READ SECRET_NAME stripe_key",
+		"POST ROLE ops READ SECRET_NAME stripe_key",
+		"POST GROUP payments EXECUTE WORKFLOW_DEF charge_card",
+		"DELETE GROUP support READ SECRET_NAME stripe_key",
+		"DELETE GROUP payments UPDATE WORKFLOW_DEF charge_card",
+		"DELETE USER admin@example.com READ,UPDATE WORKFLOW_DEF charge_card",
+	}, fake.calls)
+
+	_, err = NewReconciler(client.NewAuthorizationClient(apiClient)).Reconcile(context.Background(), policy, Options{DryRun: true})
+	assert.EqualError(t, err, "cannot resolve WORKFLOW_DEF charge_*, no lister for the WORKFLOW_DEF targets")
+}
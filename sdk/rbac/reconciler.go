@@ -0,0 +1,303 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package rbac
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	rbacmodel "github.com/conductor-sdk/conductor-go/sdk/model/rbac"
+)
+
+// TargetLister lists the names of the targets of a type, used to resolve the patterns of the policy
+type TargetLister func(ctx context.Context) ([]string, error)
+
+// Options controls how Reconcile changes the access
+type Options struct {
+	// DryRun computes the changes without applying them
+	DryRun bool
+	// Exclusive revokes the access granted on the targets of the policy to the subjects the policy does not name
+	Exclusive bool
+}
+
+// Grant the access of a subject on a target
+type Grant struct {
+	Subject Subject  `json:"subject"`
+	Target  Target   `json:"target"`
+	Access  []Access `json:"access"`
+}
+
+func (grant Grant) String() string {
+	access := make([]string, len(grant.Access))
+	for i, a := range grant.Access {
+		access[i] = string(a)
+	}
+	return fmt.Sprintf("%s %s on %s", grant.Subject, strings.Join(access, ","), grant.Target)
+}
+
+// Result the access granted and revoked, or to be granted and revoked when DryRun is set
+type Result struct {
+	Granted []Grant `json:"granted"`
+	Revoked []Grant `json:"revoked"`
+	DryRun  bool    `json:"dryRun"`
+}
+
+func (result *Result) HasChanges() bool {
+	return len(result.Granted)+len(result.Revoked) > 0
+}
+
+// String the changes, one per line, e.g. + GROUP payments READ,EXECUTE on WORKFLOW_DEF charge_card
+func (result *Result) String() string {
+	var builder strings.Builder
+	for _, grant := range result.Granted {
+		fmt.Fprintf(&builder, "+ %s\n", grant)
+	}
+	for _, grant := range result.Revoked {
+		fmt.Fprintf(&builder, "- %s\n", grant)
+	}
+	fmt.Fprintf(&builder, "%d to grant, %d to revoke\n", len(result.Granted), len(result.Revoked))
+	return builder.String()
+}
+
+// Reconciler makes the access on the server match a policy.  For each target of the policy the access of the subjects
+// named by the policy is exactly the access of the policy: the missing access is granted and the other access revoked.
+// Targets whose id is a pattern are resolved with the lister of their type
+type Reconciler struct {
+	authorizationClient client.AuthorizationClient
+	listers             map[string]TargetLister
+}
+
+func NewReconciler(authorizationClient client.AuthorizationClient) *Reconciler {
+	return &Reconciler{
+		authorizationClient: authorizationClient,
+		listers:             map[string]TargetLister{},
+	}
+}
+
+// WithTargetLister resolves the patterns of the targets of the type with the lister
+func (reconciler *Reconciler) WithTargetLister(targetType string, lister TargetLister) *Reconciler {
+	reconciler.listers[targetType] = lister
+	return reconciler
+}
+
+// WithMetadataClient resolves the patterns of the workflow and task definitions
+func (reconciler *Reconciler) WithMetadataClient(metadataClient client.MetadataClient) *Reconciler {
+	reconciler.WithTargetLister("WORKFLOW_DEF", func(ctx context.Context) ([]string, error) {
+		workflowDefs, _, err := metadataClient.GetAll(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(workflowDefs))
+		for _, workflowDef := range workflowDefs {
+			names = append(names, workflowDef.Name)
+		}
+		return names, nil
+	})
+	return reconciler.WithTargetLister("TASK_DEF", func(ctx context.Context) ([]string, error) {
+		taskDefs, _, err := metadataClient.GetTaskDefs(ctx)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(taskDefs))
+		for _, taskDef := range taskDefs {
+			names = append(names, taskDef.Name)
+		}
+		return names, nil
+	})
+}
+
+// WithSchedulerClient resolves the patterns of the schedules
+func (reconciler *Reconciler) WithSchedulerClient(schedulerClient client.SchedulerClient) *Reconciler {
+	return reconciler.WithTargetLister("WORKFLOW_SCHEDULE", func(ctx context.Context) ([]string, error) {
+		schedules, _, err := schedulerClient.GetAllSchedules(ctx, nil)
+		if err != nil {
+			return nil, err
+		}
+		names := make([]string, 0, len(schedules))
+		for _, schedule := range schedules {
+			names = append(names, schedule.Name)
+		}
+		return names, nil
+	})
+}
+
+// Reconcile computes the minimal changes turning the access on the targets of the policy into the access of the
+// policy and, unless DryRun is set, grants the missing access before revoking the extra one.
+// The policy is validated and its patterns resolved before any change is made
+func (reconciler *Reconciler) Reconcile(ctx context.Context, policy *Policy, options Options) (*Result, error) {
+	if err := policy.Validate(); err != nil {
+		return nil, err
+	}
+	desired, err := reconciler.resolve(ctx, policy)
+	if err != nil {
+		return nil, err
+	}
+	managed := policy.subjects()
+	result := &Result{Granted: []Grant{}, Revoked: []Grant{}, DryRun: options.DryRun}
+	for _, target := range sortedTargets(desired) {
+		current, err := reconciler.currentAccess(ctx, target)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the access on %s: %w", target, err)
+		}
+		subjects := map[Subject]bool{}
+		for subject := range desired[target] {
+			subjects[subject] = true
+		}
+		for subject := range current {
+			if managed[subject] || options.Exclusive {
+				subjects[subject] = true
+			}
+		}
+		for _, subject := range sortedSubjects(subjects) {
+			if grant := difference(desired[target][subject], current[subject]); len(grant) > 0 {
+				result.Granted = append(result.Granted, Grant{Subject: subject, Target: target, Access: grant})
+			}
+			if revoke := difference(current[subject], desired[target][subject]); len(revoke) > 0 {
+				result.Revoked = append(result.Revoked, Grant{Subject: subject, Target: target, Access: revoke})
+			}
+		}
+	}
+	if options.DryRun {
+		return result, nil
+	}
+	for _, grant := range result.Granted {
+		if _, err := reconciler.authorizationClient.GrantPermissions(ctx, grant.toAuthorizationRequest()); err != nil {
+			return result, fmt.Errorf("failed to grant %s: %w", grant, err)
+		}
+	}
+	for _, grant := range result.Revoked {
+		if _, err := reconciler.authorizationClient.RemovePermissions(ctx, grant.toAuthorizationRequest()); err != nil {
+			return result, fmt.Errorf("failed to revoke %s: %w", grant, err)
+		}
+	}
+	return result, nil
+}
+
+// resolve the access of each subject on each target, the patterns are replaced by the matching targets
+func (reconciler *Reconciler) resolve(ctx context.Context, policy *Policy) (map[Target]map[Subject]map[Access]bool, error) {
+	names := map[string][]string{}
+	desired := map[Target]map[Subject]map[Access]bool{}
+	for _, rule := range policy.rules {
+		var targets []Target
+		for _, target := range rule.targets {
+			if !target.IsPattern() {
+				targets = append(targets, target)
+				continue
+			}
+			if _, listed := names[target.Type]; !listed {
+				lister, found := reconciler.listers[target.Type]
+				if !found {
+					return nil, fmt.Errorf("cannot resolve %s, no lister for the %s targets", target, target.Type)
+				}
+				listed, err := lister(ctx)
+				if err != nil {
+					return nil, fmt.Errorf("failed to list the %s targets: %w", target.Type, err)
+				}
+				names[target.Type] = listed
+			}
+			for _, name := range names[target.Type] {
+				if target.Matches(name) {
+					targets = append(targets, Target{Type: target.Type, Id: name})
+				}
+			}
+		}
+		for _, target := range targets {
+			if desired[target] == nil {
+				desired[target] = map[Subject]map[Access]bool{}
+			}
+			for _, subject := range rule.subjects {
+				if desired[target][subject] == nil {
+					desired[target][subject] = map[Access]bool{}
+				}
+				for _, access := range rule.access {
+					desired[target][subject][access] = true
+				}
+			}
+		}
+	}
+	return desired, nil
+}
+
+// currentAccess the access granted on the target by subject, GetPermissions returns the subjects by access
+func (reconciler *Reconciler) currentAccess(ctx context.Context, target Target) (map[Subject]map[Access]bool, error) {
+	permissions, _, err := reconciler.authorizationClient.GetPermissions(ctx, target.Type, target.Id)
+	if err != nil {
+		return nil, err
+	}
+	data, err := json.Marshal(permissions)
+	if err != nil {
+		return nil, err
+	}
+	var subjectsByAccess map[string][]rbacmodel.SubjectRef
+	if err := json.Unmarshal(data, &subjectsByAccess); err != nil {
+		return nil, fmt.Errorf("unexpected permissions %s: %w", string(data), err)
+	}
+	current := map[Subject]map[Access]bool{}
+	for access, subjects := range subjectsByAccess {
+		for _, ref := range subjects {
+			subject := Subject{Type: ref.Type_, Id: ref.Id}
+			if current[subject] == nil {
+				current[subject] = map[Access]bool{}
+			}
+			current[subject][Access(access)] = true
+		}
+	}
+	return current, nil
+}
+
+func (grant Grant) toAuthorizationRequest() rbacmodel.AuthorizationRequest {
+	access := make([]string, len(grant.Access))
+	for i, a := range grant.Access {
+		access[i] = string(a)
+	}
+	return rbacmodel.AuthorizationRequest{
+		Access:  access,
+		Subject: &rbacmodel.SubjectRef{Type_: grant.Subject.Type, Id: grant.Subject.Id},
+		Target:  &rbacmodel.TargetRef{Type_: grant.Target.Type, Id: grant.Target.Id},
+	}
+}
+
+// difference the access in from and not in to
+func difference(from map[Access]bool, to map[Access]bool) []Access {
+	var result []Access
+	for access := range from {
+		if !to[access] {
+			result = append(result, access)
+		}
+	}
+	sortAccess(result)
+	return result
+}
+
+func sortedTargets[V any](values map[Target]V) []Target {
+	targets := make([]Target, 0, len(values))
+	for target := range values {
+		targets = append(targets, target)
+	}
+	sort.Slice(targets, func(i, j int) bool {
+		return targets[i].String() < targets[j].String()
+	})
+	return targets
+}
+
+func sortedSubjects(values map[Subject]bool) []Subject {
+	subjects := make([]Subject, 0, len(values))
+	for subject := range values {
+		subjects = append(subjects, subject)
+	}
+	sort.Slice(subjects, func(i, j int) bool {
+		return subjects[i].String() < subjects[j].String()
+	})
+	return subjects
+}
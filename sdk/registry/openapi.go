@@ -0,0 +1,281 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"gopkg.in/yaml.v3"
+)
+
+const HttpServiceType = "HTTP"
+
+// the methods of a path item in the order of the OpenAPI specification
+var httpMethods = []string{"GET", "PUT", "POST", "DELETE", "OPTIONS", "HEAD", "PATCH", "TRACE"}
+
+type openAPIDocument struct {
+	OpenAPI string `yaml:"openapi"`
+	Servers []struct {
+		URL string `yaml:"url"`
+	} `yaml:"servers"`
+	Paths      map[string]map[string]yaml.Node `yaml:"paths"`
+	Components struct {
+		Parameters    map[string]openAPIParameter   `yaml:"parameters"`
+		RequestBodies map[string]openAPIRequestBody `yaml:"requestBodies"`
+		Responses     map[string]openAPIResponse    `yaml:"responses"`
+	} `yaml:"components"`
+}
+
+type openAPIOperation struct {
+	OperationId string                     `yaml:"operationId"`
+	Parameters  []openAPIParameter         `yaml:"parameters"`
+	RequestBody *openAPIRequestBody        `yaml:"requestBody"`
+	Responses   map[string]openAPIResponse `yaml:"responses"`
+}
+
+type openAPIParameter struct {
+	Ref      string         `yaml:"$ref"`
+	Name     string         `yaml:"name"`
+	In       string         `yaml:"in"`
+	Required bool           `yaml:"required"`
+	Schema   *openAPISchema `yaml:"schema"`
+}
+
+type openAPISchema struct {
+	Ref     string         `yaml:"$ref"`
+	Type    interface{}    `yaml:"type"`
+	Format  string         `yaml:"format"`
+	Default interface{}    `yaml:"default"`
+	Items   *openAPISchema `yaml:"items"`
+}
+
+type openAPIRequestBody struct {
+	Ref     string                      `yaml:"$ref"`
+	Content map[string]openAPIMediaType `yaml:"content"`
+}
+
+type openAPIResponse struct {
+	Ref     string                      `yaml:"$ref"`
+	Content map[string]openAPIMediaType `yaml:"content"`
+}
+
+type openAPIMediaType struct {
+	Schema  *openAPISchema `yaml:"schema"`
+	Example interface{}    `yaml:"example"`
+}
+
+// LoadOpenAPI reads the OpenAPI 3 document of the file, JSON or YAML, see FromOpenAPI
+func LoadOpenAPI(path string, name string) (*model.ServiceRegistry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	registry, err := FromOpenAPI(data, name)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	return registry, nil
+}
+
+// FromOpenAPI the HTTP service registry of an OpenAPI 3 document, JSON or YAML.  The service URI is the URL of the
+// first server of the document.  Each operation is a method: the path is the method name, the HTTP method the method
+// type and the operation id the operation name.  The parameters of the path and of the operation are the request
+// params, the schemas of the request body and of the successful response are the input and output types
+func FromOpenAPI(data []byte, name string) (*model.ServiceRegistry, error) {
+	var document openAPIDocument
+	if err := yaml.Unmarshal(data, &document); err != nil {
+		return nil, fmt.Errorf("invalid OpenAPI document: %w", err)
+	}
+	if !strings.HasPrefix(document.OpenAPI, "3.") {
+		return nil, fmt.Errorf("unsupported OpenAPI version %q, expected 3.x", document.OpenAPI)
+	}
+	registry := &model.ServiceRegistry{Name: name, Type_: HttpServiceType, Methods: []model.ServiceMethod{}}
+	if len(document.Servers) > 0 {
+		registry.ServiceURI = document.Servers[0].URL
+	}
+	paths := make([]string, 0, len(document.Paths))
+	for path := range document.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	for _, path := range paths {
+		item := document.Paths[path]
+		var pathParameters []openAPIParameter
+		if node, found := item["parameters"]; found {
+			if err := node.Decode(&pathParameters); err != nil {
+				return nil, fmt.Errorf("invalid parameters of path %s: %w", path, err)
+			}
+		}
+		for _, httpMethod := range httpMethods {
+			node, found := item[strings.ToLower(httpMethod)]
+			if !found {
+				continue
+			}
+			var operation openAPIOperation
+			if err := node.Decode(&operation); err != nil {
+				return nil, fmt.Errorf("invalid operation %s %s: %w", httpMethod, path, err)
+			}
+			method, err := document.toServiceMethod(path, httpMethod, pathParameters, &operation)
+			if err != nil {
+				return nil, err
+			}
+			registry.Methods = append(registry.Methods, method)
+		}
+	}
+	return registry, nil
+}
+
+func (document *openAPIDocument) toServiceMethod(path string, httpMethod string, pathParameters []openAPIParameter, operation *openAPIOperation) (model.ServiceMethod, error) {
+	method := model.ServiceMethod{
+		OperationName: operation.OperationId,
+		MethodName:    path,
+		MethodType:    httpMethod,
+		RequestParams: []model.RequestParam{},
+	}
+	if method.OperationName == "" {
+		method.OperationName = httpMethod + " " + path
+	}
+	// the parameters of the operation override the ones of the path with the same name and location
+	var parameters []openAPIParameter
+	index := map[string]int{}
+	for _, parameter := range append(append([]openAPIParameter{}, pathParameters...), operation.Parameters...) {
+		resolved, err := document.resolveParameter(parameter)
+		if err != nil {
+			return method, fmt.Errorf("%s %s: %w", httpMethod, path, err)
+		}
+		key := resolved.In + ":" + resolved.Name
+		if i, found := index[key]; found {
+			parameters[i] = resolved
+			continue
+		}
+		index[key] = len(parameters)
+		parameters = append(parameters, resolved)
+	}
+	for _, parameter := range parameters {
+		method.RequestParams = append(method.RequestParams, toRequestParam(parameter))
+	}
+
+	if operation.RequestBody != nil {
+		requestBody := *operation.RequestBody
+		if requestBody.Ref != "" {
+			requestBody = document.Components.RequestBodies[refName(requestBody.Ref)]
+		}
+		if mediaType, found := preferredMediaType(requestBody.Content); found {
+			method.InputType = typeName(mediaType.Schema)
+			if example, isObject := mediaType.Example.(map[string]interface{}); isObject {
+				method.ExampleInput = example
+			}
+		}
+	}
+	for _, status := range successStatuses(operation.Responses) {
+		response := operation.Responses[status]
+		if response.Ref != "" {
+			response = document.Components.Responses[refName(response.Ref)]
+		}
+		if mediaType, found := preferredMediaType(response.Content); found {
+			method.OutputType = typeName(mediaType.Schema)
+			break
+		}
+	}
+	return method, nil
+}
+
+func (document *openAPIDocument) resolveParameter(parameter openAPIParameter) (openAPIParameter, error) {
+	if parameter.Ref == "" {
+		return parameter, nil
+	}
+	resolved, found := document.Components.Parameters[refName(parameter.Ref)]
+	if !found {
+		return parameter, fmt.Errorf("parameter %s not found", parameter.Ref)
+	}
+	return resolved, nil
+}
+
+func toRequestParam(parameter openAPIParameter) model.RequestParam {
+	requestParam := model.RequestParam{Name: parameter.Name, Type_: parameter.In, Required: parameter.Required || parameter.In == "path"}
+	if parameter.Schema != nil {
+		requestParam.Schema = &model.Schema{Type_: schemaType(parameter.Schema), Format: parameter.Schema.Format}
+		if parameter.Schema.Default != nil {
+			defaultValue := parameter.Schema.Default
+			requestParam.Schema.DefaultValue = &defaultValue
+		}
+	}
+	return requestParam
+}
+
+// successStatuses the 2xx statuses of the responses in order, then default
+func successStatuses(responses map[string]openAPIResponse) []string {
+	var statuses []string
+	for status := range responses {
+		if strings.HasPrefix(status, "2") {
+			statuses = append(statuses, status)
+		}
+	}
+	sort.Strings(statuses)
+	if _, found := responses["default"]; found {
+		statuses = append(statuses, "default")
+	}
+	return statuses
+}
+
+func preferredMediaType(content map[string]openAPIMediaType) (openAPIMediaType, bool) {
+	if mediaType, found := content["application/json"]; found {
+		return mediaType, true
+	}
+	names := make([]string, 0, len(content))
+	for name := range content {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	if len(names) == 0 {
+		return openAPIMediaType{}, false
+	}
+	return content[names[0]], true
+}
+
+// typeName the name of the schema referenced, Pet for #/components/schemas/Pet and Pet[] for an array of Pet, the type
+// for the other schemas
+func typeName(schema *openAPISchema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Ref != "" {
+		return refName(schema.Ref)
+	}
+	if schemaType(schema) == "array" && schema.Items != nil {
+		if items := typeName(schema.Items); items != "" {
+			return items + "[]"
+		}
+	}
+	return schemaType(schema)
+}
+
+// schemaType the type of the schema, the first type other than null for the OpenAPI 3.1 lists of types
+func schemaType(schema *openAPISchema) string {
+	switch value := schema.Type.(type) {
+	case string:
+		return value
+	case []interface{}:
+		for _, item := range value {
+			if text, isString := item.(string); isString && text != "null" {
+				return text
+			}
+		}
+	}
+	return ""
+}
+
+func refName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
@@ -0,0 +1,98 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package registry
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/types/descriptorpb"
+)
+
+const GrpcServiceType = "gRPC"
+
+// The types of the gRPC methods
+const (
+	UnaryMethod           = "UNARY"
+	ServerStreamingMethod = "SERVER_STREAMING"
+	ClientStreamingMethod = "CLIENT_STREAMING"
+	BidiStreamingMethod   = "BIDI_STREAMING"
+)
+
+// ProtoService a gRPC service registry and the compiled descriptors its methods are read from
+type ProtoService struct {
+	Registry *model.ServiceRegistry
+	// Filename the name the descriptors are uploaded with
+	Filename string
+	// Data the serialized FileDescriptorSet, e.g. the output of protoc --descriptor_set_out --include_imports
+	Data []byte
+}
+
+// LoadFileDescriptorSet reads the compiled FileDescriptorSet of the file, see FromFileDescriptorSet
+func LoadFileDescriptorSet(path string, name string, serviceURI string) (*ProtoService, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	service, err := FromFileDescriptorSet(data, name, serviceURI)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", path, err)
+	}
+	service.Filename = filepath.Base(path)
+	return service, nil
+}
+
+// FromFileDescriptorSet the gRPC service registry of the services of a compiled FileDescriptorSet, serviceURI is the
+// host:port of the server.  Each rpc is a method: the full name of the service is the operation name, the name of the
+// rpc the method name, and the message types without the leading dot the input and output types
+func FromFileDescriptorSet(data []byte, name string, serviceURI string) (*ProtoService, error) {
+	var descriptorSet descriptorpb.FileDescriptorSet
+	if err := proto.Unmarshal(data, &descriptorSet); err != nil {
+		return nil, fmt.Errorf("invalid FileDescriptorSet: %w", err)
+	}
+	registry := &model.ServiceRegistry{Name: name, Type_: GrpcServiceType, ServiceURI: serviceURI, Methods: []model.ServiceMethod{}}
+	for _, file := range descriptorSet.GetFile() {
+		for _, service := range file.GetService() {
+			serviceName := service.GetName()
+			if file.GetPackage() != "" {
+				serviceName = file.GetPackage() + "." + serviceName
+			}
+			for _, method := range service.GetMethod() {
+				registry.Methods = append(registry.Methods, model.ServiceMethod{
+					OperationName: serviceName,
+					MethodName:    method.GetName(),
+					MethodType:    grpcMethodType(method),
+					InputType:     strings.TrimPrefix(method.GetInputType(), "."),
+					OutputType:    strings.TrimPrefix(method.GetOutputType(), "."),
+				})
+			}
+		}
+	}
+	if len(registry.Methods) == 0 {
+		return nil, fmt.Errorf("the FileDescriptorSet declares no service")
+	}
+	return &ProtoService{Registry: registry, Filename: name + ".bin", Data: data}, nil
+}
+
+func grpcMethodType(method *descriptorpb.MethodDescriptorProto) string {
+	switch {
+	case method.GetClientStreaming() && method.GetServerStreaming():
+		return BidiStreamingMethod
+	case method.GetClientStreaming():
+		return ClientStreamingMethod
+	case method.GetServerStreaming():
+		return ServerStreamingMethod
+	}
+	return UnaryMethod
+}
@@ -0,0 +1,247 @@
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFromOpenAPI(t *testing.T) {
+	registry, err := LoadOpenAPI(filepath.Join("testdata", "petstore.yaml"), "petstore")
+	assert.NoError(t, err)
+	assert.Equal(t, "petstore", registry.Name)
+	assert.Equal(t, HttpServiceType, registry.Type_)
+	assert.Equal(t, "https://petstore.example.com/v1", registry.ServiceURI)
+
+	var defaultLimit interface{} = 20
+	assert.Equal(t, []model.ServiceMethod{
+		{
+			OperationName: "listPets",
+			MethodName:    "/pets",
+			MethodType:    "GET",
+			OutputType:    "Pet[]",
+			RequestParams: []model.RequestParam{
+				{Name: "limit", Type_: "query", Schema: &model.Schema{Type_: "integer", Format: "int32", DefaultValue: &defaultLimit}},
+				{Name: "X-Request-Id", Type_: "header", Schema: &model.Schema{Type_: "string", Format: "uuid"}},
+			},
+		},
+		{
+			OperationName: "createPet",
+			MethodName:    "/pets",
+			MethodType:    "POST",
+			InputType:     "NewPet",
+			OutputType:    "Pet",
+			ExampleInput:  map[string]interface{}{"name": "Rex", "tag": "dog"},
+			RequestParams: []model.RequestParam{},
+		},
+		{
+			OperationName: "getPet",
+			MethodName:    "/pets/{petId}",
+			MethodType:    "GET",
+			OutputType:    "Pet",
+			RequestParams: []model.RequestParam{
+				{Name: "petId", Type_: "path", Required: true, Schema: &model.Schema{Type_: "string"}},
+				{Name: "X-Trace", Type_: "header", Required: true, Schema: &model.Schema{Type_: "string"}},
+			},
+		},
+		{
+			OperationName: "DELETE /pets/{petId}",
+			MethodName:    "/pets/{petId}",
+			MethodType:    "DELETE",
+			RequestParams: []model.RequestParam{
+				{Name: "petId", Type_: "path", Required: true, Schema: &model.Schema{Type_: "string"}},
+				{Name: "X-Trace", Type_: "header", Schema: &model.Schema{Type_: "string"}},
+			},
+		},
+	}, registry.Methods)
+
+	_, err = FromOpenAPI([]byte(`{"swagger": "2.0", "paths": {}}`), "legacy")
+	assert.EqualError(t, err, `unsupported OpenAPI version "", expected 3.x`)
+}
+
+func TestFromFileDescriptorSet(t *testing.T) {
+	service, err := LoadFileDescriptorSet(filepath.Join("..", "..", "test", "integration_tests", "compiled.bin"), "hello", "grpcbin:50051")
+	assert.NoError(t, err)
+	assert.Equal(t, "compiled.bin", service.Filename)
+	assert.NotEmpty(t, service.Data)
+	assert.Equal(t, GrpcServiceType, service.Registry.Type_)
+	assert.Equal(t, "grpcbin:50051", service.Registry.ServiceURI)
+	assert.Len(t, service.Registry.Methods, 11)
+	assert.Equal(t, model.ServiceMethod{
+		OperationName: "helloworld.HelloWorldService",
+		MethodName:    "SayHello",
+		MethodType:    UnaryMethod,
+		InputType:     "helloworld.HelloRequest",
+		OutputType:    "helloworld.HelloResponse",
+	}, service.Registry.Methods[0])
+	assert.Equal(t, "ComplexRequestStream", service.Registry.Methods[8].MethodName)
+	assert.Equal(t, ServerStreamingMethod, service.Registry.Methods[8].MethodType)
+
+	_, err = FromFileDescriptorSet([]byte("not a descriptor"), "hello", "grpcbin:50051")
+	assert.Error(t, err)
+}
+
+// fakeRegistry the service registry of the server in memory
+type fakeRegistry struct {
+	mutex    sync.Mutex
+	services map[string]*model.ServiceRegistry
+	protos   map[string]string
+	calls    []string
+}
+
+func newFakeRegistry() *fakeRegistry {
+	return &fakeRegistry{services: map[string]*model.ServiceRegistry{}, protos: map[string]string{}}
+}
+
+func (server *fakeRegistry) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	path := strings.TrimPrefix(r.URL.Path, "/registry/service")
+	if r.Method != http.MethodGet {
+		server.calls = append(server.calls, r.Method+" "+r.URL.Path)
+	}
+	notFound := func() {
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message": "not found"}`))
+	}
+	switch {
+	case strings.HasPrefix(path, "/protos/"):
+		key := strings.TrimPrefix(path, "/protos/")
+		if r.Method == http.MethodGet {
+			data, found := server.protos[key]
+			if !found {
+				notFound()
+				return
+			}
+			w.Header().Set("Content-Type", "application/octet-stream")
+			w.Write([]byte(data))
+			return
+		}
+		data, _ := io.ReadAll(r.Body)
+		server.protos[key] = string(data)
+	case path == "" && r.Method == http.MethodPost:
+		var service model.ServiceRegistry
+		json.NewDecoder(r.Body).Decode(&service)
+		if existing, found := server.services[service.Name]; found {
+			service.Methods = existing.Methods
+		}
+		server.services[service.Name] = &service
+	case strings.HasSuffix(path, "/methods"):
+		service := server.services[strings.TrimSuffix(strings.TrimPrefix(path, "/"), "/methods")]
+		if r.Method == http.MethodDelete {
+			query := r.URL.Query()
+			for i, method := range service.Methods {
+				if method.OperationName == query.Get("serviceName") && method.MethodName == query.Get("method") && method.MethodType == query.Get("methodType") {
+					service.Methods = append(service.Methods[:i], service.Methods[i+1:]...)
+					break
+				}
+			}
+			break
+		}
+		var method model.ServiceMethod
+		json.NewDecoder(r.Body).Decode(&method)
+		for i, existing := range service.Methods {
+			if methodName(existing) == methodName(method) {
+				service.Methods[i] = method
+				method.Id = -1
+			}
+		}
+		if method.Id != -1 {
+			method.Id = int64(len(service.Methods) + 1)
+			service.Methods = append(service.Methods, method)
+		}
+	default:
+		service, found := server.services[strings.TrimPrefix(path, "/")]
+		if !found {
+			notFound()
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(service)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func TestSyncOpenAPI(t *testing.T) {
+	fake := newFakeRegistry()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	serviceRegistryClient := client.NewServiceRegistryClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+	registry, err := LoadOpenAPI(filepath.Join("testdata", "petstore.yaml"), "petstore")
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	result, err := Sync(ctx, serviceRegistryClient, registry, SyncOptions{DryRun: true})
+	assert.NoError(t, err)
+	assert.True(t, result.ServiceCreated)
+	assert.Equal(t, []string{"GET /pets", "POST /pets", "GET /pets/{petId}", "DELETE /pets/{petId}"}, result.Created)
+	assert.Empty(t, fake.calls)
+
+	result, err = Sync(ctx, serviceRegistryClient, registry, SyncOptions{})
+	assert.NoError(t, err)
+	assert.True(t, result.HasChanges())
+	assert.Len(t, fake.calls, 5)
+
+	// idempotent
+	result, err = Sync(ctx, serviceRegistryClient, registry, SyncOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.False(t, result.HasChanges())
+	assert.Len(t, result.Unchanged, 4)
+	assert.Len(t, fake.calls, 5)
+
+	// a method changed, another one removed from the document
+	registry.ServiceURI = "https://petstore.example.com/v2"
+	registry.Methods[0].OutputType = "PetPage"
+	registry.Methods = registry.Methods[:3]
+	result, err = Sync(ctx, serviceRegistryClient, registry, SyncOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &SyncResult{
+		ServiceUpdated: true,
+		Created:        []string{},
+		Updated:        []string{"GET /pets"},
+		Deleted:        []string{"DELETE /pets/{petId}"},
+		Unchanged:      []string{"POST /pets", "GET /pets/{petId}"},
+	}, result)
+	assert.Equal(t, []string{
+		"POST /registry/service",
+		"POST /registry/service/petstore/methods",
+		"DELETE /registry/service/petstore/methods",
+	}, fake.calls[5:])
+	assert.Equal(t, "https://petstore.example.com/v2", fake.services["petstore"].ServiceURI)
+	assert.Len(t, fake.services["petstore"].Methods, 3)
+}
+
+func TestSyncProto(t *testing.T) {
+	fake := newFakeRegistry()
+	server := httptest.NewServer(fake)
+	defer server.Close()
+	serviceRegistryClient := client.NewServiceRegistryClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+	service, err := LoadFileDescriptorSet(filepath.Join("..", "..", "test", "integration_tests", "compiled.bin"), "hello", "grpcbin:50051")
+	assert.NoError(t, err)
+	ctx := context.Background()
+
+	result, err := SyncProto(ctx, serviceRegistryClient, service, SyncOptions{})
+	assert.NoError(t, err)
+	assert.True(t, result.ServiceCreated)
+	assert.True(t, result.ProtoUploaded)
+	assert.Len(t, result.Created, 11)
+	assert.Equal(t, string(service.Data), fake.protos["hello/compiled.bin"])
+
+	result, err = SyncProto(ctx, serviceRegistryClient, service, SyncOptions{Prune: true})
+	assert.NoError(t, err)
+	assert.False(t, result.HasChanges())
+	assert.Contains(t, result.Unchanged, "helloworld.HelloWorldService/SayHello")
+}
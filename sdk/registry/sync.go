@@ -0,0 +1,212 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package registry
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"reflect"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// SyncOptions controls how Sync reconciles the service on the server
+type SyncOptions struct {
+	// Prune removes the methods on the server that are not part of the desired service
+	Prune bool
+	// DryRun computes the changes without applying them
+	DryRun bool
+}
+
+// SyncResult the changes applied, or to be applied when DryRun is set.  The methods are named GET /pets for the HTTP
+// services and package.Service/Method for the gRPC services
+type SyncResult struct {
+	ServiceCreated bool     `json:"serviceCreated"`
+	ServiceUpdated bool     `json:"serviceUpdated"`
+	ProtoUploaded  bool     `json:"protoUploaded"`
+	Created        []string `json:"created"`
+	Updated        []string `json:"updated"`
+	Deleted        []string `json:"deleted"`
+	Unchanged      []string `json:"unchanged"`
+}
+
+func (result *SyncResult) HasChanges() bool {
+	return result.ServiceCreated || result.ServiceUpdated || result.ProtoUploaded ||
+		len(result.Created)+len(result.Updated)+len(result.Deleted) > 0
+}
+
+// Sync reconciles the service registry with the server: the service is created or updated when its type, URI or
+// configuration differ, then the missing methods are added, the methods that differ are updated and, with Prune, the
+// methods not desired are removed.  Running it again without changes makes no change
+func Sync(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient, registry *model.ServiceRegistry, options SyncOptions) (*SyncResult, error) {
+	return syncService(ctx, serviceRegistryClient, registry, nil, options)
+}
+
+// SyncProto reconciles a gRPC service like Sync, and uploads the descriptors when they differ from the ones on the
+// server
+func SyncProto(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient, service *ProtoService, options SyncOptions) (*SyncResult, error) {
+	return syncService(ctx, serviceRegistryClient, service.Registry, service, options)
+}
+
+func syncService(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient, registry *model.ServiceRegistry, protoService *ProtoService, options SyncOptions) (*SyncResult, error) {
+	if registry.Name == "" {
+		return nil, errors.New("the name of the service registry is required")
+	}
+	result := &SyncResult{Created: []string{}, Updated: []string{}, Deleted: []string{}, Unchanged: []string{}}
+	current, found, err := getService(ctx, serviceRegistryClient, registry.Name)
+	if err != nil {
+		return nil, err
+	}
+	result.ServiceCreated = !found
+	result.ServiceUpdated = found && !serviceEquals(registry, current)
+	if (result.ServiceCreated || result.ServiceUpdated) && !options.DryRun {
+		service := *registry
+		service.Methods = nil
+		if _, err := serviceRegistryClient.AddOrUpdateService(ctx, service); err != nil {
+			return result, fmt.Errorf("failed to save service %s: %w", registry.Name, err)
+		}
+		if current, _, err = getService(ctx, serviceRegistryClient, registry.Name); err != nil {
+			return result, err
+		}
+	}
+
+	if protoService != nil {
+		data, found, err := getProtoData(ctx, serviceRegistryClient, registry.Name, protoService.Filename)
+		if err != nil {
+			return result, err
+		}
+		result.ProtoUploaded = !found || data != string(protoService.Data)
+		if result.ProtoUploaded && !options.DryRun {
+			if _, err := serviceRegistryClient.SetProtoData(ctx, string(protoService.Data), registry.Name, protoService.Filename); err != nil {
+				return result, fmt.Errorf("failed to upload %s of service %s: %w", protoService.Filename, registry.Name, err)
+			}
+			// the server adds the methods of the descriptors
+			if current, _, err = getService(ctx, serviceRegistryClient, registry.Name); err != nil {
+				return result, err
+			}
+		}
+	}
+
+	existing := map[string]model.ServiceMethod{}
+	for _, method := range current.Methods {
+		existing[methodName(method)] = method
+	}
+	desired := map[string]bool{}
+	for _, method := range registry.Methods {
+		name := methodName(method)
+		desired[name] = true
+		currentMethod, found := existing[name]
+		switch {
+		case !found:
+			result.Created = append(result.Created, name)
+		case methodEquals(method, currentMethod):
+			result.Unchanged = append(result.Unchanged, name)
+			continue
+		default:
+			result.Updated = append(result.Updated, name)
+			method.Id = currentMethod.Id
+		}
+		if !options.DryRun {
+			if _, err := serviceRegistryClient.AddOrUpdateMethod(ctx, method, registry.Name); err != nil {
+				return result, fmt.Errorf("failed to save method %s of service %s: %w", name, registry.Name, err)
+			}
+		}
+	}
+	if options.Prune {
+		for _, method := range current.Methods {
+			name := methodName(method)
+			if desired[name] {
+				continue
+			}
+			result.Deleted = append(result.Deleted, name)
+			if !options.DryRun {
+				if _, err := serviceRegistryClient.RemoveMethod(ctx, registry.Name, method.OperationName, method.MethodName, method.MethodType); err != nil {
+					return result, fmt.Errorf("failed to remove method %s of service %s: %w", name, registry.Name, err)
+				}
+			}
+		}
+	}
+	return result, nil
+}
+
+func getService(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient, name string) (model.ServiceRegistry, bool, error) {
+	service, response, err := serviceRegistryClient.GetService(ctx, name)
+	if internal.IsNotFound(response, err) {
+		return model.ServiceRegistry{}, false, nil
+	}
+	if err != nil {
+		return service, false, fmt.Errorf("failed to get service %s: %w", name, err)
+	}
+	return service, service.Name != "", nil
+}
+
+func getProtoData(ctx context.Context, serviceRegistryClient client.ServiceRegistryClient, name string, filename string) (string, bool, error) {
+	data, response, err := serviceRegistryClient.GetProtoData(ctx, name, filename)
+	if internal.IsNotFound(response, err) {
+		return "", false, nil
+	}
+	if err != nil {
+		return "", false, fmt.Errorf("failed to get %s of service %s: %w", filename, name, err)
+	}
+	return data, data != "", nil
+}
+
+func methodName(method model.ServiceMethod) string {
+	if isHttpMethod(method.MethodType) {
+		return method.MethodType + " " + method.MethodName
+	}
+	return method.OperationName + "/" + method.MethodName
+}
+
+func isHttpMethod(methodType string) bool {
+	for _, httpMethod := range httpMethods {
+		if httpMethod == methodType {
+			return true
+		}
+	}
+	return false
+}
+
+// serviceEquals compares the properties the desired service sets, the configuration and the request params are only
+// compared when they are set
+func serviceEquals(desired *model.ServiceRegistry, current model.ServiceRegistry) bool {
+	if desired.Type_ != current.Type_ || desired.ServiceURI != current.ServiceURI {
+		return false
+	}
+	if desired.Config != nil && !jsonEquals(desired.Config, current.Config) {
+		return false
+	}
+	return len(desired.RequestParams) == 0 || jsonEquals(desired.RequestParams, current.RequestParams)
+}
+
+func methodEquals(desired model.ServiceMethod, current model.ServiceMethod) bool {
+	desired.Id = 0
+	current.Id = 0
+	return jsonEquals(desired, current)
+}
+
+// jsonEquals compares the JSON representations, nil and empty values are the same
+func jsonEquals(a interface{}, b interface{}) bool {
+	return reflect.DeepEqual(normalize(a), normalize(b))
+}
+
+func normalize(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	json.Unmarshal(data, &normalized)
+	return normalized
+}
@@ -0,0 +1,376 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+const (
+	minYear = 1970
+	maxYear = 2099
+)
+
+var (
+	monthNames = map[string]int{
+		"JAN": 1, "FEB": 2, "MAR": 3, "APR": 4, "MAY": 5, "JUN": 6,
+		"JUL": 7, "AUG": 8, "SEP": 9, "OCT": 10, "NOV": 11, "DEC": 12,
+	}
+	dayOfWeekNames = map[string]int{
+		"SUN": 1, "MON": 2, "TUE": 3, "WED": 4, "THU": 5, "FRI": 6, "SAT": 7,
+	}
+)
+
+// CronExpression a Quartz cron expression as used by the scheduler of the server:
+// seconds minutes hours day-of-month month day-of-week [year], e.g. "0 0/15 9-17 ? * MON-FRI".
+// One of day-of-month and day-of-week must be '?'.  Supports lists, ranges, steps, the names of the months and days,
+// and the L, W and # modifiers of the days
+type CronExpression struct {
+	expression  string
+	seconds     uint64
+	minutes     uint64
+	hours       uint64
+	daysOfMonth uint64
+	months      uint64
+	daysOfWeek  uint64
+	// nil matches any year
+	years map[int]bool
+
+	anyDayOfMonth bool
+	anyDayOfWeek  bool
+
+	// L, L-3 and LW
+	lastDayOfMonth     bool
+	lastDayOffset      int
+	lastWeekdayOfMonth bool
+	// 15W
+	nearestWeekday int
+	// 6L, the last friday of the month
+	lastDayOfWeek int
+	// 6#3, the third friday of the month
+	nthDayOfWeek int
+	nth          int
+}
+
+// ParseCron parses and validates the Quartz cron expression
+func ParseCron(expression string) (*CronExpression, error) {
+	fields := strings.Fields(expression)
+	if len(fields) != 6 && len(fields) != 7 {
+		return nil, cronError(expression, "expected 6 or 7 fields (seconds minutes hours day-of-month month day-of-week [year]), got %d", len(fields))
+	}
+	cron := &CronExpression{expression: expression}
+	var err error
+	if cron.seconds, err = parseField(fields[0], 0, 59, nil); err != nil {
+		return nil, cronError(expression, "seconds %s", err)
+	}
+	if cron.minutes, err = parseField(fields[1], 0, 59, nil); err != nil {
+		return nil, cronError(expression, "minutes %s", err)
+	}
+	if cron.hours, err = parseField(fields[2], 0, 23, nil); err != nil {
+		return nil, cronError(expression, "hours %s", err)
+	}
+	if err = cron.parseDayOfMonth(strings.ToUpper(fields[3])); err != nil {
+		return nil, cronError(expression, "day-of-month %s", err)
+	}
+	if cron.months, err = parseField(strings.ToUpper(fields[4]), 1, 12, monthNames); err != nil {
+		return nil, cronError(expression, "month %s", err)
+	}
+	if err = cron.parseDayOfWeek(strings.ToUpper(fields[5])); err != nil {
+		return nil, cronError(expression, "day-of-week %s", err)
+	}
+	if cron.anyDayOfMonth == cron.anyDayOfWeek {
+		return nil, cronError(expression, "one of day-of-month and day-of-week must be '?'")
+	}
+	if len(fields) == 7 && fields[6] != "*" {
+		bits, err := parseYears(fields[6])
+		if err != nil {
+			return nil, cronError(expression, "year %s", err)
+		}
+		cron.years = bits
+	}
+	return cron, nil
+}
+
+func cronError(expression string, format string, args ...interface{}) error {
+	return fmt.Errorf("invalid cron expression %q: %s", expression, fmt.Sprintf(format, args...))
+}
+
+func (c *CronExpression) String() string {
+	return c.expression
+}
+
+func (c *CronExpression) parseDayOfMonth(field string) error {
+	switch {
+	case field == "?":
+		c.anyDayOfMonth = true
+		return nil
+	case field == "LW":
+		c.lastDayOfMonth = true
+		c.lastWeekdayOfMonth = true
+		return nil
+	case strings.HasPrefix(field, "L"):
+		c.lastDayOfMonth = true
+		if field == "L" {
+			return nil
+		}
+		offset, err := strconv.Atoi(strings.TrimPrefix(field, "L-"))
+		if err != nil || !strings.HasPrefix(field, "L-") || offset < 0 || offset > 30 {
+			return fmt.Errorf("invalid last day %s", field)
+		}
+		c.lastDayOffset = offset
+		return nil
+	case strings.HasSuffix(field, "W"):
+		day, err := strconv.Atoi(strings.TrimSuffix(field, "W"))
+		if err != nil || day < 1 || day > 31 {
+			return fmt.Errorf("invalid nearest weekday %s", field)
+		}
+		c.nearestWeekday = day
+		return nil
+	}
+	bits, err := parseField(field, 1, 31, nil)
+	c.daysOfMonth = bits
+	return err
+}
+
+func (c *CronExpression) parseDayOfWeek(field string) error {
+	switch {
+	case field == "?":
+		c.anyDayOfWeek = true
+		return nil
+	case field == "L":
+		// the last day of the week, saturday
+		c.daysOfWeek = 1 << 7
+		return nil
+	case strings.HasSuffix(field, "L"):
+		day, err := parseValue(strings.TrimSuffix(field, "L"), 1, 7, dayOfWeekNames)
+		if err != nil {
+			return err
+		}
+		c.lastDayOfWeek = day
+		return nil
+	case strings.Contains(field, "#"):
+		parts := strings.SplitN(field, "#", 2)
+		day, err := parseValue(parts[0], 1, 7, dayOfWeekNames)
+		if err != nil {
+			return err
+		}
+		nth, err := strconv.Atoi(parts[1])
+		if err != nil || nth < 1 || nth > 5 {
+			return fmt.Errorf("invalid occurrence %s, expected 1 to 5", parts[1])
+		}
+		c.nthDayOfWeek = day
+		c.nth = nth
+		return nil
+	}
+	bits, err := parseField(field, 1, 7, dayOfWeekNames)
+	c.daysOfWeek = bits
+	return err
+}
+
+// parseField parses the lists, ranges and steps of the field into a bit set of the values
+func parseField(field string, min int, max int, names map[string]int) (uint64, error) {
+	var bits uint64
+	err := parseRanges(field, min, max, names, func(value int) {
+		bits |= 1 << uint(value)
+	})
+	return bits, err
+}
+
+func parseYears(field string) (map[int]bool, error) {
+	years := map[int]bool{}
+	err := parseRanges(field, minYear, maxYear, nil, func(value int) {
+		years[value] = true
+	})
+	return years, err
+}
+
+func parseRanges(field string, min int, max int, names map[string]int, add func(value int)) error {
+	for _, part := range strings.Split(field, ",") {
+		rangePart, step := part, 1
+		if i := strings.Index(part, "/"); i >= 0 {
+			var err error
+			step, err = strconv.Atoi(part[i+1:])
+			if err != nil || step < 1 || step > max {
+				return fmt.Errorf("invalid step %s", part[i+1:])
+			}
+			rangePart = part[:i]
+		}
+		var start, end int
+		switch {
+		case rangePart == "*":
+			start, end = min, max
+		case strings.Contains(rangePart, "-"):
+			bounds := strings.SplitN(rangePart, "-", 2)
+			var err error
+			if start, err = parseValue(bounds[0], min, max, names); err != nil {
+				return err
+			}
+			if end, err = parseValue(bounds[1], min, max, names); err != nil {
+				return err
+			}
+		default:
+			var err error
+			if start, err = parseValue(rangePart, min, max, names); err != nil {
+				return err
+			}
+			end = start
+			if step > 1 || strings.Contains(part, "/") {
+				// 5/15 starts at 5 and repeats until the max
+				end = max
+			}
+		}
+		if start <= end {
+			for value := start; value <= end; value += step {
+				add(value)
+			}
+			continue
+		}
+		// ranges wrap around, e.g. FRI-MON or 22-2
+		span := max - min + 1
+		for offset := 0; offset <= end-start+span; offset += step {
+			add(min + (start-min+offset)%span)
+		}
+	}
+	return nil
+}
+
+func parseValue(value string, min int, max int, names map[string]int) (int, error) {
+	if named, ok := names[value]; ok {
+		return named, nil
+	}
+	number, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid value %s", value)
+	}
+	if number < min || number > max {
+		return 0, fmt.Errorf("value %d out of range %d-%d", number, min, max)
+	}
+	return number, nil
+}
+
+// Next returns the first fire time strictly after the given time, in the location of the given time.
+// Returns the zero time when there are no more fire times
+func (c *CronExpression) Next(after time.Time) time.Time {
+	location := after.Location()
+	t := after.Truncate(time.Second).Add(time.Second)
+	for t.Year() <= maxYear {
+		if c.years != nil && !c.years[t.Year()] {
+			t = advance(t, time.Date(t.Year()+1, time.January, 1, 0, 0, 0, 0, location))
+			continue
+		}
+		if !hasBit(c.months, int(t.Month())) {
+			t = advance(t, time.Date(t.Year(), t.Month()+1, 1, 0, 0, 0, 0, location))
+			continue
+		}
+		if !c.dayMatches(t) {
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day()+1, 0, 0, 0, 0, location))
+			continue
+		}
+		if !hasBit(c.hours, t.Hour()) {
+			t = advance(t, time.Date(t.Year(), t.Month(), t.Day(), t.Hour()+1, 0, 0, 0, location))
+			continue
+		}
+		if !hasBit(c.minutes, t.Minute()) {
+			t = t.Truncate(time.Minute).Add(time.Minute)
+			continue
+		}
+		if !hasBit(c.seconds, t.Second()) {
+			t = t.Add(time.Second)
+			continue
+		}
+		return t
+	}
+	return time.Time{}
+}
+
+// advance returns the next candidate time.  Wall clock times skipped by daylight saving transitions can resolve to a
+// time before the current one, in which case the search moves forward by an hour instead
+func advance(current time.Time, next time.Time) time.Time {
+	if next.After(current) {
+		return next
+	}
+	return current.Add(time.Hour)
+}
+
+// NextN returns the next count fire times after the given time
+func (c *CronExpression) NextN(after time.Time, count int) []time.Time {
+	times := make([]time.Time, 0, count)
+	for len(times) < count {
+		after = c.Next(after)
+		if after.IsZero() {
+			break
+		}
+		times = append(times, after)
+	}
+	return times
+}
+
+func (c *CronExpression) dayMatches(t time.Time) bool {
+	if c.anyDayOfWeek {
+		return c.dayOfMonthMatches(t)
+	}
+	return c.dayOfWeekMatches(t)
+}
+
+func (c *CronExpression) dayOfMonthMatches(t time.Time) bool {
+	lastDay := daysIn(t.Year(), t.Month())
+	switch {
+	case c.lastWeekdayOfMonth:
+		return t.Day() == nearestWeekday(t.Year(), t.Month(), lastDay)
+	case c.lastDayOfMonth:
+		return t.Day() == lastDay-c.lastDayOffset
+	case c.nearestWeekday > 0:
+		return t.Day() == nearestWeekday(t.Year(), t.Month(), c.nearestWeekday)
+	}
+	return hasBit(c.daysOfMonth, t.Day())
+}
+
+func (c *CronExpression) dayOfWeekMatches(t time.Time) bool {
+	dayOfWeek := int(t.Weekday()) + 1
+	switch {
+	case c.lastDayOfWeek > 0:
+		return dayOfWeek == c.lastDayOfWeek && t.Day()+7 > daysIn(t.Year(), t.Month())
+	case c.nth > 0:
+		return dayOfWeek == c.nthDayOfWeek && (t.Day()-1)/7+1 == c.nth
+	}
+	return hasBit(c.daysOfWeek, dayOfWeek)
+}
+
+// nearestWeekday the weekday nearest to the day, within the same month
+func nearestWeekday(year int, month time.Month, day int) int {
+	lastDay := daysIn(year, month)
+	if day > lastDay {
+		day = lastDay
+	}
+	switch time.Date(year, month, day, 0, 0, 0, 0, time.UTC).Weekday() {
+	case time.Saturday:
+		if day == 1 {
+			return day + 2
+		}
+		return day - 1
+	case time.Sunday:
+		if day == lastDay {
+			return day - 2
+		}
+		return day + 1
+	}
+	return day
+}
+
+func daysIn(year int, month time.Month) int {
+	return time.Date(year, month+1, 0, 0, 0, 0, 0, time.UTC).Day()
+}
+
+func hasBit(bits uint64, value int) bool {
+	return bits&(1<<uint(value)) != 0
+}
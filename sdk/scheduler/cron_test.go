@@ -0,0 +1,94 @@
+package scheduler
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseCronErrors(t *testing.T) {
+	invalid := map[string]string{
+		"0 0 12 * *":        "expected 6 or 7 fields",
+		"0 0 12 * * *":      "one of day-of-month and day-of-week must be '?'",
+		"0 0 12 ? * ?":      "one of day-of-month and day-of-week must be '?'",
+		"60 0 12 * * ?":     "seconds value 60 out of range 0-59",
+		"0 0 24 * * ?":      "hours value 24 out of range 0-23",
+		"0 0 12 32 * ?":     "day-of-month value 32 out of range 1-31",
+		"0 0 12 ? FOO MON":  "month invalid value FOO",
+		"0 0 12 ? * MON#6":  "day-of-week invalid occurrence 6",
+		"0 0/0 12 * * ?":    "minutes invalid step 0",
+		"0 0 12 * * ? 1969": "year value 1969 out of range 1970-2099",
+		"0 ? 12 * * MON":    "minutes invalid value ?",
+		"0 0 12 L-40 * ?":   "day-of-month invalid last day L-40",
+		"0 0 12 40W * ?":    "day-of-month invalid nearest weekday 40W",
+	}
+	for expression, reason := range invalid {
+		_, err := ParseCron(expression)
+		if assert.Error(t, err, expression) {
+			assert.Contains(t, err.Error(), reason, expression)
+		}
+	}
+}
+
+func TestCronNext(t *testing.T) {
+	from := time.Date(2024, time.January, 30, 10, 15, 30, 0, time.UTC) // Tuesday
+	cases := []struct {
+		expression string
+		expected   []string
+	}{
+		{"0 0/15 * * * ?", []string{"2024-01-30T10:30:00Z", "2024-01-30T10:45:00Z", "2024-01-30T11:00:00Z"}},
+		{"*/20 * * * * ?", []string{"2024-01-30T10:15:40Z", "2024-01-30T10:16:00Z", "2024-01-30T10:16:20Z"}},
+		{"0 0 9 ? * MON-FRI", []string{"2024-01-31T09:00:00Z", "2024-02-01T09:00:00Z", "2024-02-02T09:00:00Z", "2024-02-05T09:00:00Z"}},
+		{"0 30 22-2 * * ?", []string{"2024-01-30T22:30:00Z", "2024-01-30T23:30:00Z", "2024-01-31T00:30:00Z", "2024-01-31T01:30:00Z", "2024-01-31T02:30:00Z", "2024-01-31T22:30:00Z"}},
+		{"0 0 12 L * ?", []string{"2024-01-31T12:00:00Z", "2024-02-29T12:00:00Z", "2024-03-31T12:00:00Z"}},
+		{"0 0 12 L-2 * ?", []string{"2024-02-27T12:00:00Z", "2024-03-29T12:00:00Z"}},
+		{"0 0 12 LW * ?", []string{"2024-01-31T12:00:00Z", "2024-02-29T12:00:00Z", "2024-03-29T12:00:00Z"}},
+		{"0 0 12 1W * ?", []string{"2024-02-01T12:00:00Z", "2024-03-01T12:00:00Z", "2024-04-01T12:00:00Z", "2024-05-01T12:00:00Z", "2024-06-03T12:00:00Z"}},
+		{"0 0 12 ? * 6L", []string{"2024-02-23T12:00:00Z", "2024-03-29T12:00:00Z"}},
+		{"0 0 12 ? * FRI#3", []string{"2024-02-16T12:00:00Z", "2024-03-15T12:00:00Z"}},
+		{"0 0 0 29 FEB ?", []string{"2024-02-29T00:00:00Z", "2028-02-29T00:00:00Z"}},
+		{"0 0 0 1 JAN ? 2025,2027", []string{"2025-01-01T00:00:00Z", "2027-01-01T00:00:00Z"}},
+		{"0 0 10 ? * SAT-MON", []string{"2024-02-03T10:00:00Z", "2024-02-04T10:00:00Z", "2024-02-05T10:00:00Z", "2024-02-10T10:00:00Z"}},
+	}
+	for _, c := range cases {
+		cron, err := ParseCron(c.expression)
+		if !assert.NoError(t, err, c.expression) {
+			continue
+		}
+		actual := make([]string, 0)
+		for _, next := range cron.NextN(from, len(c.expected)) {
+			actual = append(actual, next.Format(time.RFC3339))
+		}
+		assert.Equal(t, c.expected, actual, c.expression)
+	}
+}
+
+func TestCronNextEnds(t *testing.T) {
+	cron, err := ParseCron("0 0 0 1 JAN ? 2025")
+	assert.NoError(t, err)
+	assert.Len(t, cron.NextN(time.Date(2024, time.June, 1, 0, 0, 0, 0, time.UTC), 3), 1)
+}
+
+func TestCronNextAcrossDaylightSaving(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	cron, err := ParseCron("0 30 * * * ?")
+	assert.NoError(t, err)
+	// the clocks move from 2:00 to 3:00 on 2024-03-10, there is no 2:30
+	actual := make([]string, 0)
+	for _, next := range cron.NextN(time.Date(2024, time.March, 10, 0, 45, 0, 0, newYork), 3) {
+		actual = append(actual, next.Format(time.RFC3339))
+	}
+	assert.Equal(t, []string{"2024-03-10T01:30:00-05:00", "2024-03-10T03:30:00-04:00", "2024-03-10T04:30:00-04:00"}, actual)
+
+	daily, err := ParseCron("0 30 2 * * ?")
+	assert.NoError(t, err)
+	actual = make([]string, 0)
+	for _, next := range daily.NextN(time.Date(2024, time.March, 9, 12, 0, 0, 0, newYork), 2) {
+		actual = append(actual, next.Format(time.RFC3339))
+	}
+	assert.Equal(t, []string{"2024-03-11T02:30:00-04:00", "2024-03-12T02:30:00-04:00"}, actual)
+}
@@ -0,0 +1,192 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow"
+)
+
+// defaultZoneId the zone used by the server when the schedule has none
+const defaultZoneId = internal.DefaultZoneId
+
+// Schedule builder of the workflow schedules, e.g.
+//
+//	scheduler.NewSchedule("daily_report").
+//		Cron("0 0 9 ? * MON-FRI").
+//		In("America/New_York").
+//		Starts(reportWorkflow, map[string]interface{}{"format": "pdf"})
+type Schedule struct {
+	name                 string
+	description          string
+	cronExpression       string
+	zoneId               string
+	startTime            time.Time
+	endTime              time.Time
+	paused               bool
+	runCatchup           bool
+	startWorkflowRequest *model.StartWorkflowRequest
+}
+
+func NewSchedule(name string) *Schedule {
+	return &Schedule{name: name}
+}
+
+// Cron the Quartz cron expression of the schedule, see CronExpression
+func (schedule *Schedule) Cron(cronExpression string) *Schedule {
+	schedule.cronExpression = cronExpression
+	return schedule
+}
+
+// In the time zone of the cron expression, e.g. "America/New_York".  Defaults to UTC
+func (schedule *Schedule) In(zoneId string) *Schedule {
+	schedule.zoneId = zoneId
+	return schedule
+}
+
+// Between the schedule only starts workflows between start and end, a zero time leaves the bound open
+func (schedule *Schedule) Between(start time.Time, end time.Time) *Schedule {
+	schedule.startTime = start
+	schedule.endTime = end
+	return schedule
+}
+
+// Starts the workflow with the input on each run.  The input struct MUST be serializable to JSON
+func (schedule *Schedule) Starts(conductorWorkflow *workflow.ConductorWorkflow, input interface{}) *Schedule {
+	return schedule.StartsRequest(&model.StartWorkflowRequest{
+		Name:    conductorWorkflow.GetName(),
+		Version: conductorWorkflow.GetVersion(),
+		Input:   input,
+	})
+}
+
+// StartsRequest starts the workflow of the request on each run, allows setting the correlation id, task domains etc.
+func (schedule *Schedule) StartsRequest(startWorkflowRequest *model.StartWorkflowRequest) *Schedule {
+	schedule.startWorkflowRequest = startWorkflowRequest
+	return schedule
+}
+
+func (schedule *Schedule) Description(description string) *Schedule {
+	schedule.description = description
+	return schedule
+}
+
+func (schedule *Schedule) Paused(paused bool) *Schedule {
+	schedule.paused = paused
+	return schedule
+}
+
+// RunCatchup runs the executions missed while the schedule was paused or the server was down
+func (schedule *Schedule) RunCatchup(runCatchup bool) *Schedule {
+	schedule.runCatchup = runCatchup
+	return schedule
+}
+
+func (schedule *Schedule) GetName() string {
+	return schedule.name
+}
+
+// Validate checks the schedule locally: the cron expression, the time zone, the bounds and the workflow to start
+func (schedule *Schedule) Validate() error {
+	_, _, err := schedule.parse()
+	return err
+}
+
+func (schedule *Schedule) parse() (*CronExpression, *time.Location, error) {
+	if schedule.name == "" {
+		return nil, nil, fmt.Errorf("schedule name is required")
+	}
+	cron, err := ParseCron(schedule.cronExpression)
+	if err != nil {
+		return nil, nil, fmt.Errorf("schedule %s: %s", schedule.name, err)
+	}
+	location, err := time.LoadLocation(schedule.zone())
+	if err != nil {
+		return nil, nil, fmt.Errorf("schedule %s: invalid zone %s: %s", schedule.name, schedule.zoneId, err)
+	}
+	if !schedule.startTime.IsZero() && !schedule.endTime.IsZero() && !schedule.startTime.Before(schedule.endTime) {
+		return nil, nil, fmt.Errorf("schedule %s: start time %s is not before end time %s", schedule.name, schedule.startTime, schedule.endTime)
+	}
+	if schedule.startWorkflowRequest == nil || schedule.startWorkflowRequest.Name == "" {
+		return nil, nil, fmt.Errorf("schedule %s: workflow to start is required", schedule.name)
+	}
+	return cron, location, nil
+}
+
+func (schedule *Schedule) zone() string {
+	if schedule.zoneId == "" {
+		return defaultZoneId
+	}
+	return schedule.zoneId
+}
+
+// NextRuns computes the next count fire times after the given time, in the time zone of the schedule and within its
+// bounds, without calling the server
+func (schedule *Schedule) NextRuns(after time.Time, count int) ([]time.Time, error) {
+	cron, location, err := schedule.parse()
+	if err != nil {
+		return nil, err
+	}
+	if schedule.startTime.After(after) {
+		// the start time itself is a candidate
+		after = schedule.startTime.Add(-time.Nanosecond)
+	}
+	runs := make([]time.Time, 0, count)
+	next := after.In(location)
+	for len(runs) < count {
+		next = cron.Next(next)
+		if next.IsZero() || (!schedule.endTime.IsZero() && next.After(schedule.endTime)) {
+			break
+		}
+		runs = append(runs, next)
+	}
+	return runs, nil
+}
+
+// ToSaveScheduleRequest validates the schedule and returns the request to save it
+func (schedule *Schedule) ToSaveScheduleRequest() (*model.SaveScheduleRequest, error) {
+	if err := schedule.Validate(); err != nil {
+		return nil, err
+	}
+	return &model.SaveScheduleRequest{
+		Name:                        schedule.name,
+		Description:                 schedule.description,
+		CronExpression:              schedule.cronExpression,
+		ZoneId:                      schedule.zoneId,
+		Paused:                      schedule.paused,
+		RunCatchupScheduleInstances: schedule.runCatchup,
+		ScheduleStartTime:           toEpochMillis(schedule.startTime),
+		ScheduleEndTime:             toEpochMillis(schedule.endTime),
+		StartWorkflowRequest:        schedule.startWorkflowRequest,
+	}, nil
+}
+
+// Save validates the schedule locally and saves it on the server
+func (schedule *Schedule) Save(ctx context.Context, schedulerClient client.SchedulerClient) error {
+	request, err := schedule.ToSaveScheduleRequest()
+	if err != nil {
+		return err
+	}
+	_, _, err = schedulerClient.SaveSchedule(ctx, *request)
+	return err
+}
+
+func toEpochMillis(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.UnixNano() / int64(time.Millisecond)
+}
@@ -0,0 +1,121 @@
+package scheduler
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow"
+	"github.com/stretchr/testify/assert"
+)
+
+func newReportWorkflow() *workflow.ConductorWorkflow {
+	return workflow.NewConductorWorkflow(nil).Name("daily_report").Version(2)
+}
+
+func TestScheduleValidate(t *testing.T) {
+	assert.EqualError(t, NewSchedule("").Validate(), "schedule name is required")
+	assert.Contains(t, NewSchedule("report").Cron("0 0 9 * * *").Validate().Error(), "one of day-of-month and day-of-week must be '?'")
+	assert.Contains(t, NewSchedule("report").Cron("0 0 9 * * ?").In("Mars/Olympus").Validate().Error(), "invalid zone Mars/Olympus")
+	assert.EqualError(t, NewSchedule("report").Cron("0 0 9 * * ?").Validate(), "schedule report: workflow to start is required")
+
+	start := time.Date(2024, time.February, 1, 0, 0, 0, 0, time.UTC)
+	assert.Contains(t, NewSchedule("report").Cron("0 0 9 * * ?").Between(start, start).Starts(newReportWorkflow(), nil).Validate().Error(), "is not before end time")
+	assert.NoError(t, NewSchedule("report").Cron("0 0 9 * * ?").Starts(newReportWorkflow(), nil).Validate())
+}
+
+func TestScheduleNextRuns(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Skip("time zone database not available")
+	}
+	start := time.Date(2024, time.February, 1, 0, 0, 0, 0, newYork)
+	end := time.Date(2024, time.February, 6, 0, 0, 0, 0, newYork)
+	schedule := NewSchedule("report").
+		Cron("0 0 9 ? * MON-FRI").
+		In("America/New_York").
+		Between(start, end).
+		Starts(newReportWorkflow(), map[string]interface{}{"format": "pdf"})
+
+	runs, err := schedule.NextRuns(time.Date(2024, time.January, 1, 0, 0, 0, 0, time.UTC), 10)
+	assert.NoError(t, err)
+	actual := make([]string, 0)
+	for _, run := range runs {
+		actual = append(actual, run.UTC().Format(time.RFC3339))
+	}
+	assert.Equal(t, []string{"2024-02-01T14:00:00Z", "2024-02-02T14:00:00Z", "2024-02-05T14:00:00Z"}, actual)
+
+	request, err := schedule.ToSaveScheduleRequest()
+	assert.NoError(t, err)
+	assert.Equal(t, "America/New_York", request.ZoneId)
+	assert.Equal(t, start.UnixNano()/int64(time.Millisecond), request.ScheduleStartTime)
+	assert.Equal(t, "daily_report", request.StartWorkflowRequest.Name)
+	assert.Equal(t, int32(2), request.StartWorkflowRequest.Version)
+}
+
+func TestSync(t *testing.T) {
+	existing := []model.WorkflowScheduleModel{
+		{
+			Name: "unchanged", CronExpression: "0 0 9 * * ?", ZoneId: "UTC",
+			StartWorkflowRequest: &model.StartWorkflowRequest{Name: "daily_report", Version: 2, Input: map[string]interface{}{"format": "pdf"}},
+		},
+		{
+			Name: "changed", CronExpression: "0 0 9 * * ?",
+			StartWorkflowRequest: &model.StartWorkflowRequest{Name: "daily_report", Version: 2},
+		},
+		{Name: "obsolete", CronExpression: "0 0 9 * * ?"},
+	}
+	saved := make([]string, 0)
+	deleted := make([]string, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/scheduler/schedules":
+			json.NewEncoder(w).Encode(existing)
+		case r.Method == http.MethodPost && r.URL.Path == "/scheduler/schedules":
+			var request model.SaveScheduleRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			saved = append(saved, request.Name)
+			w.Write([]byte("{}"))
+		case r.Method == http.MethodDelete:
+			deleted = append(deleted, strings.TrimPrefix(r.URL.Path, "/scheduler/schedules/"))
+			w.Write([]byte("{}"))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	schedulerClient := client.NewSchedulerClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+
+	type reportInput struct {
+		Format string `json:"format"`
+	}
+	schedules := []*Schedule{
+		NewSchedule("unchanged").Cron("0 0 9 * * ?").Starts(newReportWorkflow(), &reportInput{Format: "pdf"}),
+		NewSchedule("changed").Cron("0 0 10 * * ?").Starts(newReportWorkflow(), nil),
+		NewSchedule("new").Cron("0 0 11 * * ?").Starts(newReportWorkflow(), nil),
+	}
+
+	result, err := Sync(context.Background(), schedulerClient, schedules, SyncOptions{Prune: true, DryRun: true})
+	assert.NoError(t, err)
+	assert.Equal(t, &SyncResult{Created: []string{"new"}, Updated: []string{"changed"}, Deleted: []string{"obsolete"}, Unchanged: []string{"unchanged"}}, result)
+	assert.Empty(t, saved)
+	assert.Empty(t, deleted)
+
+	result, err = Sync(context.Background(), schedulerClient, schedules, SyncOptions{})
+	assert.NoError(t, err)
+	assert.Empty(t, result.Deleted)
+	assert.Equal(t, []string{"changed", "new"}, saved)
+	assert.Empty(t, deleted)
+
+	_, err = Sync(context.Background(), schedulerClient, append(schedules, NewSchedule("invalid").Cron("0 0 9 * *")), SyncOptions{Prune: true})
+	assert.Error(t, err)
+	assert.Equal(t, []string{"changed", "new"}, saved, "invalid schedules are detected before any change")
+}
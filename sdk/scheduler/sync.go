@@ -0,0 +1,113 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package scheduler
+
+import (
+	"context"
+	"reflect"
+	"sort"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// SyncOptions controls how Sync reconciles the schedules on the server
+type SyncOptions struct {
+	// Prune deletes the schedules on the server that are not part of the desired schedules
+	Prune bool
+	// DryRun computes the changes without applying them
+	DryRun bool
+	// WorkflowName limits the schedules considered on the server to the ones starting the workflow
+	WorkflowName string
+}
+
+// SyncResult the names of the schedules by the change applied, or to be applied when DryRun is set
+type SyncResult struct {
+	Created   []string `json:"created"`
+	Updated   []string `json:"updated"`
+	Deleted   []string `json:"deleted"`
+	Unchanged []string `json:"unchanged"`
+}
+
+func (result *SyncResult) HasChanges() bool {
+	return len(result.Created)+len(result.Updated)+len(result.Deleted) > 0
+}
+
+// Sync reconciles the desired schedules with the schedules on the server: the missing schedules are created, the
+// schedules that differ are updated and, with Prune, the schedules not desired are deleted.
+// All the schedules are validated locally before any change is made
+func Sync(ctx context.Context, schedulerClient client.SchedulerClient, schedules []*Schedule, options SyncOptions) (*SyncResult, error) {
+	desired := make(map[string]*model.SaveScheduleRequest, len(schedules))
+	names := make([]string, 0, len(schedules))
+	for _, schedule := range schedules {
+		request, err := schedule.ToSaveScheduleRequest()
+		if err != nil {
+			return nil, err
+		}
+		desired[request.Name] = request
+		names = append(names, request.Name)
+	}
+	sort.Strings(names)
+
+	var opts *client.SchedulerResourceApiGetAllSchedulesOpts
+	if options.WorkflowName != "" {
+		opts = &client.SchedulerResourceApiGetAllSchedulesOpts{WorkflowName: optional.NewString(options.WorkflowName)}
+	}
+	existingSchedules, _, err := schedulerClient.GetAllSchedules(ctx, opts)
+	if err != nil {
+		return nil, err
+	}
+	existing := make(map[string]model.WorkflowScheduleModel, len(existingSchedules))
+	for _, schedule := range existingSchedules {
+		existing[schedule.Name] = schedule
+	}
+
+	result := &SyncResult{Created: []string{}, Updated: []string{}, Deleted: []string{}, Unchanged: []string{}}
+	for _, name := range names {
+		request := desired[name]
+		current, found := existing[name]
+		switch {
+		case !found:
+			result.Created = append(result.Created, name)
+		case scheduleEquals(request, &current):
+			result.Unchanged = append(result.Unchanged, name)
+			continue
+		default:
+			result.Updated = append(result.Updated, name)
+		}
+		if !options.DryRun {
+			if _, _, err := schedulerClient.SaveSchedule(ctx, *request); err != nil {
+				return result, err
+			}
+		}
+	}
+	if options.Prune {
+		for _, schedule := range existingSchedules {
+			if _, isDesired := desired[schedule.Name]; isDesired {
+				continue
+			}
+			result.Deleted = append(result.Deleted, schedule.Name)
+			if !options.DryRun {
+				if _, _, err := schedulerClient.DeleteSchedule(ctx, schedule.Name); err != nil {
+					return result, err
+				}
+			}
+		}
+		sort.Strings(result.Deleted)
+	}
+	return result, nil
+}
+
+// scheduleEquals compares the properties of the schedules, see internal.ScheduleProperties
+func scheduleEquals(request *model.SaveScheduleRequest, current *model.WorkflowScheduleModel) bool {
+	return reflect.DeepEqual(internal.ScheduleProperties(request), internal.ScheduleProperties(current))
+}
@@ -0,0 +1,97 @@
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+package schema
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// NewJsonSchemaDef the schema of the Go value or type, see Generate
+func NewJsonSchemaDef(name string, version int32, value interface{}) model.SchemaDef {
+	return model.SchemaDef{
+		Name:    name,
+		Version: version,
+		Type_:   model.JsonSchema,
+		Data:    Generate(value),
+	}
+}
+
+// ForTask sets the input and output schemas of the task definition to the schemas of the input and output types of
+// the worker, named <task>_input and <task>_output.  Returns the schemas to register before the task definition.
+// Either input or output can be nil
+func ForTask(taskDef *model.TaskDef, input interface{}, output interface{}, enforce bool) []model.SchemaDef {
+	schemas := make([]model.SchemaDef, 0, 2)
+	if input != nil {
+		inputSchema := NewJsonSchemaDef(taskDef.Name+"_input", 1, input)
+		taskDef.InputSchema = model.NewSchemaRef(inputSchema.Name, inputSchema.Version)
+		schemas = append(schemas, inputSchema)
+	}
+	if output != nil {
+		outputSchema := NewJsonSchemaDef(taskDef.Name+"_output", 1, output)
+		taskDef.OutputSchema = model.NewSchemaRef(outputSchema.Name, outputSchema.Version)
+		schemas = append(schemas, outputSchema)
+	}
+	taskDef.EnforceSchema = enforce
+	return schemas
+}
+
+// ValidateInput validates the input against the data of the schema
+func ValidateInput(schemaDef *model.SchemaDef, input interface{}) error {
+	if schemaDef == nil || len(schemaDef.Data) == 0 {
+		return nil
+	}
+	if schemaDef.Type_ != "" && schemaDef.Type_ != model.JsonSchema {
+		return fmt.Errorf("schema %s is of type %s, only JSON schemas can be validated", schemaDef.Name, schemaDef.Type_)
+	}
+	if input == nil {
+		// the server starts the workflow with an empty input
+		input = map[string]interface{}{}
+	}
+	return Validate(schemaDef.Data, input)
+}
+
+// ValidateStartWorkflowRequest validates the input of the request against the input schema of the workflow
+// registered on the server, or of the WorkflowDef of the request.  Workflows without input schema are not validated
+func ValidateStartWorkflowRequest(ctx context.Context, metadataClient client.MetadataClient, schemaClient client.SchemaClient, request *model.StartWorkflowRequest) error {
+	workflowDef := request.WorkflowDef
+	if workflowDef == nil {
+		var opts *client.MetadataResourceApiGetOpts
+		if request.Version > 0 {
+			opts = &client.MetadataResourceApiGetOpts{Version: optional.NewInt32(request.Version)}
+		}
+		registered, _, err := metadataClient.Get(ctx, request.Name, opts)
+		if err != nil {
+			return err
+		}
+		workflowDef = &registered
+	}
+	inputSchema := workflowDef.InputSchema
+	if inputSchema == nil {
+		return nil
+	}
+	if len(inputSchema.Data) == 0 {
+		var schemaDef model.SchemaDef
+		var err error
+		if inputSchema.Version > 0 {
+			schemaDef, _, err = schemaClient.GetSchemaByNameAndVersion(ctx, inputSchema.Name, inputSchema.Version)
+		} else {
+			schemaDef, _, err = schemaClient.GetSchemaByNameWithLatestVersion(ctx, inputSchema.Name)
+		}
+		if err != nil {
+			return err
+		}
+		inputSchema = &schemaDef
+	}
+	return ValidateInput(inputSchema, request.Input)
+}
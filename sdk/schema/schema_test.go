@@ -0,0 +1,177 @@
+package schema
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+type address struct {
+	City string `json:"city" description:"name of the city"`
+	Zip  string `json:"zip,omitempty"`
+}
+
+type base struct {
+	RequestId string `json:"requestId"`
+}
+
+type orderInput struct {
+	base
+	OrderId   int64             `json:"orderId"`
+	Amount    float64           `json:"amount"`
+	Express   bool              `json:"express,omitempty"`
+	Items     []string          `json:"items"`
+	Address   *address          `json:"address"`
+	Labels    map[string]string `json:"labels,omitempty"`
+	CreatedAt time.Time         `json:"createdAt"`
+	Extra     interface{}       `json:"extra,omitempty"`
+	Parent    *orderInput       `json:"parent,omitempty"`
+	Ignored   string            `json:"-"`
+	internal  string
+}
+
+func TestGenerate(t *testing.T) {
+	schema := Generate(orderInput{})
+	data, err := json.Marshal(schema)
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{
+		"$schema": "http://json-schema.org/draft-07/schema#",
+		"type": "object",
+		"properties": {
+			"requestId": {"type": "string"},
+			"orderId": {"type": "integer"},
+			"amount": {"type": "number"},
+			"express": {"type": "boolean"},
+			"items": {"type": ["array", "null"], "items": {"type": "string"}},
+			"address": {
+				"type": ["object", "null"],
+				"properties": {
+					"city": {"type": "string", "description": "name of the city"},
+					"zip": {"type": "string"}
+				},
+				"required": ["city"]
+			},
+			"labels": {"type": "object", "additionalProperties": {"type": "string"}},
+			"createdAt": {"type": "string", "format": "date-time"},
+			"extra": {},
+			"parent": {"type": "object"}
+		},
+		"required": ["requestId", "orderId", "amount", "items", "createdAt"]
+	}`, string(data))
+}
+
+func TestValidate(t *testing.T) {
+	schema := Generate(&orderInput{})
+	assert.NoError(t, Validate(schema, orderInput{OrderId: 1, Items: []string{"book"}}))
+	assert.NoError(t, Validate(schema, orderInput{OrderId: 1}))
+	assert.NoError(t, Validate(schema, map[string]interface{}{
+		"requestId": "r1", "orderId": 1, "amount": 10.5, "items": []string{}, "createdAt": "2024-01-01T00:00:00Z",
+	}))
+
+	err := Validate(schema, map[string]interface{}{
+		"requestId": "r1",
+		"orderId":   1.5,
+		"amount":    "10",
+		"items":     []interface{}{"book", 2},
+		"address":   map[string]interface{}{"zip": "94107"},
+	})
+	assert.IsType(t, &ValidationError{}, err)
+	assert.Equal(t, []string{
+		"$.address: missing required property city",
+		"$.amount: expected number, got string",
+		"$.items[1]: expected string, got integer",
+		"$.orderId: expected integer, got number",
+		"$: missing required property createdAt",
+	}, err.(*ValidationError).Problems)
+}
+
+func TestValidateKeywords(t *testing.T) {
+	schema := map[string]interface{}{
+		"type": "object",
+		"properties": map[string]interface{}{
+			"status":   map[string]interface{}{"enum": []string{"NEW", "PAID"}},
+			"quantity": map[string]interface{}{"type": "integer", "minimum": 1, "maximum": 10},
+			"code":     map[string]interface{}{"type": "string", "pattern": "^[A-Z]{3}$", "maxLength": 3},
+			"tags":     map[string]interface{}{"type": "array", "minItems": 1},
+			"address":  map[string]interface{}{"$ref": "#/definitions/address"},
+			"contact": map[string]interface{}{"oneOf": []interface{}{
+				map[string]interface{}{"type": "string"},
+				map[string]interface{}{"type": "integer"},
+			}},
+		},
+		"additionalProperties": false,
+		"definitions": map[string]interface{}{
+			"address": map[string]interface{}{"type": "object", "required": []interface{}{"city"}},
+		},
+	}
+	assert.NoError(t, Validate(schema, map[string]interface{}{
+		"status": "NEW", "quantity": 2, "code": "ABC", "tags": []string{"a"}, "address": map[string]interface{}{"city": "SF"}, "contact": 12,
+	}))
+	err := Validate(schema, map[string]interface{}{
+		"status": "SHIPPED", "quantity": 11, "code": "abcd", "tags": []string{}, "address": map[string]interface{}{}, "contact": true, "other": 1,
+	})
+	assert.Equal(t, []string{
+		"$.address: missing required property city",
+		"$.code: must be at most 3 characters",
+		"$.code: must match ^[A-Z]{3}$",
+		"$.contact: must match exactly one of the schemas, matched 0",
+		"$.quantity: must be <= 10",
+		"$.status: must be one of [NEW PAID]",
+		"$.tags: must have at least 1 items",
+		"$: unknown property other",
+	}, err.(*ValidationError).Problems)
+}
+
+func TestForTask(t *testing.T) {
+	taskDef := &model.TaskDef{Name: "create_order"}
+	schemas := ForTask(taskDef, orderInput{}, address{}, true)
+	assert.Len(t, schemas, 2)
+	assert.Equal(t, "create_order_input", schemas[0].Name)
+	assert.Equal(t, model.JsonSchema, schemas[0].Type_)
+	assert.Equal(t, model.NewSchemaRef("create_order_input", 1), taskDef.InputSchema)
+	assert.Equal(t, model.NewSchemaRef("create_order_output", 1), taskDef.OutputSchema)
+	assert.True(t, taskDef.EnforceSchema)
+
+	data, _ := json.Marshal(taskDef)
+	assert.Contains(t, string(data), `"inputSchema":{"name":"create_order_input","version":1,"type":"JSON"}`)
+}
+
+func TestValidateStartWorkflowRequest(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		switch r.URL.Path {
+		case "/metadata/workflow/create_order":
+			assert.Equal(t, "2", r.URL.Query().Get("version"))
+			json.NewEncoder(w).Encode(model.WorkflowDef{Name: "create_order", InputSchema: model.NewSchemaRef("create_order_input", 3)})
+		case "/metadata/workflow/no_schema":
+			json.NewEncoder(w).Encode(model.WorkflowDef{Name: "no_schema"})
+		case "/schema/create_order_input/3":
+			json.NewEncoder(w).Encode(NewJsonSchemaDef("create_order_input", 3, address{}))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	metadataClient := client.NewMetadataClient(apiClient)
+	schemaClient := client.NewSchemaClient(apiClient)
+	ctx := context.Background()
+
+	request := model.NewStartWorkflowRequest("create_order", 2, "", map[string]interface{}{"city": "SF"})
+	assert.NoError(t, ValidateStartWorkflowRequest(ctx, metadataClient, schemaClient, request))
+
+	request.Input = map[string]interface{}{"zip": "94107"}
+	err := ValidateStartWorkflowRequest(ctx, metadataClient, schemaClient, request)
+	assert.EqualError(t, err, "invalid input: $: missing required property city")
+
+	noSchema := model.NewStartWorkflowRequest("no_schema", 0, "", nil)
+	assert.NoError(t, ValidateStartWorkflowRequest(ctx, metadataClient, schemaClient, noSchema))
+}
@@ -0,0 +1,319 @@
+// Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+// the License. You may obtain a copy of the License at
+//
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+// an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+// specific language governing permissions and limitations under the License.
+package schema
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+// ValidationError the value does not conform to the schema, Problems lists every violation with the path of the value
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return "invalid input: " + strings.Join(e.Problems, "; ")
+}
+
+// Validate checks the value against the JSON Schema.  The value is compared using its JSON representation, so structs
+// are validated by their json tags.  Supports the type, properties, required, additionalProperties, items, enum,
+// const, numeric and length bounds, pattern, allOf, anyOf and oneOf keywords, local $ref are resolved against
+// definitions and $defs
+func Validate(schema map[string]interface{}, value interface{}) error {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return err
+	}
+	var document interface{}
+	if err := json.Unmarshal(data, &document); err != nil {
+		return err
+	}
+	v := &validator{root: schema}
+	v.validate(schema, document, "$")
+	if len(v.problems) == 0 {
+		return nil
+	}
+	sort.Strings(v.problems)
+	return &ValidationError{Problems: v.problems}
+}
+
+type validator struct {
+	root     map[string]interface{}
+	problems []string
+}
+
+func (v *validator) fail(path string, format string, args ...interface{}) {
+	v.problems = append(v.problems, path+": "+fmt.Sprintf(format, args...))
+}
+
+// valid validates without reporting the problems, used for anyOf and oneOf
+func (v *validator) valid(schema map[string]interface{}, value interface{}) bool {
+	nested := &validator{root: v.root}
+	nested.validate(schema, value, "$")
+	return len(nested.problems) == 0
+}
+
+func (v *validator) validate(schema map[string]interface{}, value interface{}, path string) {
+	if ref, ok := schema["$ref"].(string); ok {
+		resolved := v.resolve(ref)
+		if resolved == nil {
+			v.fail(path, "unresolved reference %s", ref)
+			return
+		}
+		v.validate(resolved, value, path)
+	}
+	if types := asStrings(schema["type"]); len(types) > 0 && !matchesAnyType(types, value) {
+		v.fail(path, "expected %s, got %s", strings.Join(types, " or "), typeOf(value))
+		return
+	}
+	if enum, ok := normalize(schema["enum"]).([]interface{}); ok && !containsValue(enum, value) {
+		v.fail(path, "must be one of %v", enum)
+	}
+	if constant, ok := schema["const"]; ok && !reflect.DeepEqual(normalize(constant), value) {
+		v.fail(path, "must be %v", constant)
+	}
+	for _, subSchema := range asSchemas(schema["allOf"]) {
+		v.validate(subSchema, value, path)
+	}
+	if anyOf := asSchemas(schema["anyOf"]); len(anyOf) > 0 {
+		matched := false
+		for _, subSchema := range anyOf {
+			if v.valid(subSchema, value) {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			v.fail(path, "does not match any of the schemas")
+		}
+	}
+	if oneOf := asSchemas(schema["oneOf"]); len(oneOf) > 0 {
+		matched := 0
+		for _, subSchema := range oneOf {
+			if v.valid(subSchema, value) {
+				matched++
+			}
+		}
+		if matched != 1 {
+			v.fail(path, "must match exactly one of the schemas, matched %d", matched)
+		}
+	}
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		v.validateObject(schema, typed, path)
+	case []interface{}:
+		v.validateArray(schema, typed, path)
+	case string:
+		v.validateString(schema, typed, path)
+	case float64:
+		v.validateNumber(schema, typed, path)
+	}
+}
+
+func (v *validator) validateObject(schema map[string]interface{}, object map[string]interface{}, path string) {
+	for _, name := range asStrings(schema["required"]) {
+		if _, ok := object[name]; !ok {
+			v.fail(path, "missing required property %s", name)
+		}
+	}
+	properties, _ := schema["properties"].(map[string]interface{})
+	names := make([]string, 0, len(object))
+	for name := range object {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		if property, ok := properties[name].(map[string]interface{}); ok {
+			v.validate(property, object[name], path+"."+name)
+			continue
+		}
+		switch additional := schema["additionalProperties"].(type) {
+		case bool:
+			if !additional {
+				v.fail(path, "unknown property %s", name)
+			}
+		case map[string]interface{}:
+			v.validate(additional, object[name], path+"."+name)
+		}
+	}
+}
+
+func (v *validator) validateArray(schema map[string]interface{}, array []interface{}, path string) {
+	if min, ok := asNumber(schema["minItems"]); ok && float64(len(array)) < min {
+		v.fail(path, "must have at least %v items", min)
+	}
+	if max, ok := asNumber(schema["maxItems"]); ok && float64(len(array)) > max {
+		v.fail(path, "must have at most %v items", max)
+	}
+	if items, ok := schema["items"].(map[string]interface{}); ok {
+		for i, item := range array {
+			v.validate(items, item, fmt.Sprintf("%s[%d]", path, i))
+		}
+	}
+}
+
+func (v *validator) validateString(schema map[string]interface{}, value string, path string) {
+	length := float64(len([]rune(value)))
+	if min, ok := asNumber(schema["minLength"]); ok && length < min {
+		v.fail(path, "must be at least %v characters", min)
+	}
+	if max, ok := asNumber(schema["maxLength"]); ok && length > max {
+		v.fail(path, "must be at most %v characters", max)
+	}
+	if pattern, ok := schema["pattern"].(string); ok {
+		expression, err := regexp.Compile(pattern)
+		if err != nil {
+			v.fail(path, "invalid pattern %s", pattern)
+		} else if !expression.MatchString(value) {
+			v.fail(path, "must match %s", pattern)
+		}
+	}
+}
+
+func (v *validator) validateNumber(schema map[string]interface{}, value float64, path string) {
+	if min, ok := asNumber(schema["minimum"]); ok && value < min {
+		v.fail(path, "must be >= %v", min)
+	}
+	if max, ok := asNumber(schema["maximum"]); ok && value > max {
+		v.fail(path, "must be <= %v", max)
+	}
+	if min, ok := asNumber(schema["exclusiveMinimum"]); ok && value <= min {
+		v.fail(path, "must be > %v", min)
+	}
+	if max, ok := asNumber(schema["exclusiveMaximum"]); ok && value >= max {
+		v.fail(path, "must be < %v", max)
+	}
+}
+
+// resolve supports the references inside the schema, e.g. #/definitions/address or #/$defs/address
+func (v *validator) resolve(ref string) map[string]interface{} {
+	if !strings.HasPrefix(ref, "#") {
+		return nil
+	}
+	var current interface{} = v.root
+	for _, segment := range strings.Split(strings.TrimPrefix(strings.TrimPrefix(ref, "#"), "/"), "/") {
+		if segment == "" {
+			continue
+		}
+		object, ok := current.(map[string]interface{})
+		if !ok {
+			return nil
+		}
+		current = object[strings.ReplaceAll(strings.ReplaceAll(segment, "~1", "/"), "~0", "~")]
+	}
+	resolved, _ := current.(map[string]interface{})
+	return resolved
+}
+
+func matchesAnyType(types []string, value interface{}) bool {
+	actual := typeOf(value)
+	for _, expected := range types {
+		if expected == actual || (expected == "number" && actual == "integer") {
+			return true
+		}
+	}
+	return false
+}
+
+func typeOf(value interface{}) string {
+	switch typed := value.(type) {
+	case nil:
+		return "null"
+	case bool:
+		return "boolean"
+	case float64:
+		if typed == math.Trunc(typed) {
+			return "integer"
+		}
+		return "number"
+	case string:
+		return "string"
+	case []interface{}:
+		return "array"
+	}
+	return "object"
+}
+
+func asStrings(value interface{}) []string {
+	switch typed := value.(type) {
+	case string:
+		return []string{typed}
+	case []string:
+		return typed
+	case []interface{}:
+		result := make([]string, 0, len(typed))
+		for _, item := range typed {
+			if s, ok := item.(string); ok {
+				result = append(result, s)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func asSchemas(value interface{}) []map[string]interface{} {
+	switch typed := value.(type) {
+	case []map[string]interface{}:
+		return typed
+	case []interface{}:
+		result := make([]map[string]interface{}, 0, len(typed))
+		for _, item := range typed {
+			if schema, ok := item.(map[string]interface{}); ok {
+				result = append(result, schema)
+			}
+		}
+		return result
+	}
+	return nil
+}
+
+func asNumber(value interface{}) (float64, bool) {
+	switch typed := value.(type) {
+	case float64:
+		return typed, true
+	case float32:
+		return float64(typed), true
+	case int:
+		return float64(typed), true
+	case int32:
+		return float64(typed), true
+	case int64:
+		return float64(typed), true
+	}
+	return 0, false
+}
+
+func containsValue(values []interface{}, value interface{}) bool {
+	for _, candidate := range values {
+		if reflect.DeepEqual(candidate, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// normalize converts the values of the schemas built in Go to their JSON representation, e.g. int to float64
+func normalize(value interface{}) interface{} {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return value
+	}
+	var normalized interface{}
+	if err := json.Unmarshal(data, &normalized); err != nil {
+		return value
+	}
+	return normalized
+}
@@ -0,0 +1,51 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package search
+
+import (
+	"iter"
+
+	"github.com/antihax/optional"
+)
+
+// Paginate iterates over the results of fetch page by page, until a page is empty, smaller than the page size or the
+// total number of hits is reached.  A page size that is not positive is replaced by the default page size of the queries.
+// A failed fetch yields the error and ends the iteration
+func Paginate[T any](pageSize int32, fetch func(start int32, size int32) (results []T, totalHits int64, err error)) iter.Seq2[T, error] {
+	if pageSize <= 0 {
+		pageSize = defaultPageSize
+	}
+	return func(yield func(T, error) bool) {
+		for start := int32(0); ; {
+			results, totalHits, err := fetch(start, pageSize)
+			if err != nil {
+				var zero T
+				yield(zero, err)
+				return
+			}
+			for _, result := range results {
+				if !yield(result, nil) {
+					return
+				}
+			}
+			start += int32(len(results))
+			if len(results) == 0 || len(results) < int(pageSize) || (totalHits > 0 && int64(start) >= totalHits) {
+				return
+			}
+		}
+	}
+}
+
+func optionalString(value string) optional.String {
+	if value == "" {
+		return optional.EmptyString()
+	}
+	return optional.NewString(value)
+}
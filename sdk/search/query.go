@@ -0,0 +1,94 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package search
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+const defaultPageSize = 100
+
+var unquotedValue = regexp.MustCompile(`^[A-Za-z0-9_.:-]+$`)
+
+// Operators of the query conditions
+const (
+	Equals      = "="
+	NotEquals   = "!="
+	GreaterThan = ">"
+	LessThan    = "<"
+	In          = "IN"
+)
+
+// query conditions combined with AND, the free text search, sort and page size shared by all the searches
+type query struct {
+	conditions []string
+	freeText   string
+	sort       string
+	pageSize   int32
+}
+
+func (q *query) where(field string, operator string, values ...interface{}) {
+	if len(values) == 0 {
+		return
+	}
+	if operator == In {
+		quoted := make([]string, len(values))
+		for i, value := range values {
+			quoted[i] = Quote(value)
+		}
+		q.conditions = append(q.conditions, fmt.Sprintf("%s IN (%s)", field, strings.Join(quoted, ",")))
+		return
+	}
+	q.conditions = append(q.conditions, fmt.Sprintf("%s %s %s", field, operator, Quote(values[0])))
+}
+
+func (q *query) in(field string, values []string) {
+	args := make([]interface{}, len(values))
+	for i, value := range values {
+		args[i] = value
+	}
+	q.where(field, In, args...)
+}
+
+func (q *query) String() string {
+	return strings.Join(q.conditions, " AND ")
+}
+
+func (q *query) freeTextOrAll() string {
+	if q.freeText == "" {
+		return "*"
+	}
+	return q.freeText
+}
+
+func (q *query) size() int32 {
+	if q.pageSize <= 0 {
+		return defaultPageSize
+	}
+	return q.pageSize
+}
+
+// Quote formats the value for the query: times are converted to epoch milliseconds, and the strings with characters
+// other than letters, digits and _.:- are quoted with the quotes and backslashes escaped
+func Quote(value interface{}) string {
+	switch typed := value.(type) {
+	case time.Time:
+		return fmt.Sprint(typed.UnixMilli())
+	case string:
+		if unquotedValue.MatchString(typed) {
+			return typed
+		}
+		return `"` + strings.NewReplacer(`\`, `\\`, `"`, `\"`).Replace(typed) + `"`
+	}
+	return Quote(fmt.Sprint(value))
+}
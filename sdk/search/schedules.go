@@ -0,0 +1,93 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package search
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// ScheduleExecutionQuery search of the executions of the schedules
+type ScheduleExecutionQuery struct {
+	query
+}
+
+func ScheduleExecutions() *ScheduleExecutionQuery {
+	return &ScheduleExecutionQuery{}
+}
+
+func (q *ScheduleExecutionQuery) ScheduleName(names ...string) *ScheduleExecutionQuery {
+	q.in("scheduleName", names)
+	return q
+}
+
+// WorkflowName the names of the workflows started by the schedules
+func (q *ScheduleExecutionQuery) WorkflowName(names ...string) *ScheduleExecutionQuery {
+	q.in("workflowName", names)
+	return q
+}
+
+// State e.g. POLLED, FAILED or EXECUTED
+func (q *ScheduleExecutionQuery) State(states ...string) *ScheduleExecutionQuery {
+	q.in("state", states)
+	return q
+}
+
+func (q *ScheduleExecutionQuery) ScheduledAfter(t time.Time) *ScheduleExecutionQuery {
+	q.where("scheduledTime", GreaterThan, t)
+	return q
+}
+
+func (q *ScheduleExecutionQuery) ScheduledBefore(t time.Time) *ScheduleExecutionQuery {
+	q.where("scheduledTime", LessThan, t)
+	return q
+}
+
+// Where adds a condition on any field, the values are quoted as needed
+func (q *ScheduleExecutionQuery) Where(field string, operator string, values ...interface{}) *ScheduleExecutionQuery {
+	q.where(field, operator, values...)
+	return q
+}
+
+func (q *ScheduleExecutionQuery) FreeText(freeText string) *ScheduleExecutionQuery {
+	q.freeText = freeText
+	return q
+}
+
+// Sort e.g. scheduledTime:DESC
+func (q *ScheduleExecutionQuery) Sort(sort string) *ScheduleExecutionQuery {
+	q.sort = sort
+	return q
+}
+
+// PageSize number of results fetched per request
+func (q *ScheduleExecutionQuery) PageSize(pageSize int32) *ScheduleExecutionQuery {
+	q.pageSize = pageSize
+	return q
+}
+
+// Iterate over the matching executions, the pages are fetched as the iteration advances
+func (q *ScheduleExecutionQuery) Iterate(ctx context.Context, schedulerClient client.SchedulerClient) iter.Seq2[model.WorkflowScheduleExecutionModel, error] {
+	return Paginate(q.size(), func(start int32, size int32) ([]model.WorkflowScheduleExecutionModel, int64, error) {
+		result, _, err := schedulerClient.SearchV2(ctx, &client.SchedulerSearchOpts{
+			Start:    optional.NewInt32(start),
+			Size:     optional.NewInt32(size),
+			Sort:     optionalString(q.sort),
+			FreeText: optional.NewString(q.freeTextOrAll()),
+			Query:    optionalString(q.String()),
+		})
+		return result.Results, result.TotalHits, err
+	})
+}
@@ -0,0 +1,133 @@
+package search
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQueries(t *testing.T) {
+	before := time.UnixMilli(1700000000000)
+	workflows := Workflows().
+		Type("order", "order v2").
+		Status(model.RunningWorkflow, model.PausedWorkflow).
+		StartedBefore(before).
+		CorrelationId(`say "hi"\`)
+	assert.Equal(t, `workflowType IN (order,"order v2") AND status IN (RUNNING,PAUSED) AND startTime < 1700000000000 AND correlationId IN ("say \"hi\"\\")`, workflows.String())
+	assert.Equal(t, "", Workflows().Type().String())
+
+	tasks := Tasks().TaskDefName("charge").Status(model.FailedTask).Where("retryCount", GreaterThan, 2)
+	assert.Equal(t, "taskDefName IN (charge) AND status IN (FAILED) AND retryCount > 2", tasks.String())
+
+	executions := ScheduleExecutions().ScheduleName("daily_report").State("FAILED").ScheduledAfter(before)
+	assert.Equal(t, "scheduleName IN (daily_report) AND state IN (FAILED) AND scheduledTime > 1700000000000", executions.String())
+
+	assert.Equal(t, "2024-01-01T00:00:00Z", Quote("2024-01-01T00:00:00Z"))
+	assert.Equal(t, `"a) OR (b"`, Quote("a) OR (b"))
+	assert.Equal(t, "true", Quote(true))
+}
+
+func TestPaginate(t *testing.T) {
+	starts := make([]int32, 0)
+	fetch := func(start int32, size int32) ([]int, int64, error) {
+		starts = append(starts, start)
+		results := make([]int, 0)
+		for i := start; i < start+size && i < 7; i++ {
+			results = append(results, int(i))
+		}
+		return results, 7, nil
+	}
+	values := make([]int, 0)
+	for value, err := range Paginate(3, fetch) {
+		assert.NoError(t, err)
+		values = append(values, value)
+	}
+	assert.Equal(t, []int{0, 1, 2, 3, 4, 5, 6}, values)
+	assert.Equal(t, []int32{0, 3, 6}, starts)
+
+	starts = starts[:0]
+	for value := range Paginate(3, fetch) {
+		if value == 4 {
+			break
+		}
+	}
+	assert.Equal(t, []int32{0, 3}, starts)
+
+	// the default page size replaces the page sizes that are not positive
+	sizes := make([]int32, 0)
+	for range Paginate(0, func(start int32, size int32) ([]int, int64, error) {
+		sizes = append(sizes, size)
+		return nil, 0, nil
+	}) {
+	}
+	assert.Equal(t, []int32{defaultPageSize}, sizes)
+
+	for _, err := range Paginate(3, func(start int32, size int32) ([]int, int64, error) {
+		return nil, 0, errors.New("unavailable")
+	}) {
+		assert.EqualError(t, err, "unavailable")
+	}
+}
+
+func TestIterate(t *testing.T) {
+	requests := make([]url.Values, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		query := r.URL.Query()
+		requests = append(requests, query)
+		start, _ := strconv.Atoi(query.Get("start"))
+		count := 3 - start
+		if count > 2 {
+			count = 2
+		}
+		switch r.URL.Path {
+		case "/workflow/search":
+			results := make([]model.WorkflowSummary, count)
+			for i := range results {
+				results[i].WorkflowId = strconv.Itoa(start + i)
+			}
+			json.NewEncoder(w).Encode(model.SearchResultWorkflowSummary{TotalHits: 3, Results: results})
+		case "/tasks/search-v2":
+			json.NewEncoder(w).Encode(model.SearchResultTask{TotalHits: 1, Results: []model.Task{{TaskId: "task_1"}}})
+		case "/scheduler/search/executions":
+			json.NewEncoder(w).Encode(model.SearchResultWorkflowSchedule{TotalHits: 1, Results: []model.WorkflowScheduleExecutionModel{{ExecutionId: "execution_1"}}})
+		}
+	}))
+	defer server.Close()
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	ctx := context.Background()
+
+	ids := make([]string, 0)
+	query := Workflows().Type("order").Sort("startTime:DESC").PageSize(2)
+	for workflow, err := range query.Iterate(ctx, client.NewWorkflowClient(apiClient)) {
+		assert.NoError(t, err)
+		ids = append(ids, workflow.WorkflowId)
+	}
+	assert.Equal(t, []string{"0", "1", "2"}, ids)
+	assert.Len(t, requests, 2)
+	assert.Equal(t, "workflowType IN (order)", requests[0].Get("query"))
+	assert.Equal(t, "startTime:DESC", requests[0].Get("sort"))
+	assert.Equal(t, "*", requests[0].Get("freeText"))
+	assert.Equal(t, "2", requests[1].Get("start"))
+
+	for task, err := range Tasks().Status(model.FailedTask).Iterate(ctx, client.NewTaskClient(apiClient)) {
+		assert.NoError(t, err)
+		assert.Equal(t, "task_1", task.TaskId)
+	}
+	for execution, err := range ScheduleExecutions().Iterate(ctx, client.NewSchedulerClient(apiClient)) {
+		assert.NoError(t, err)
+		assert.Equal(t, "execution_1", execution.ExecutionId)
+	}
+	assert.False(t, requests[len(requests)-1].Has("query"))
+}
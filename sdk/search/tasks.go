@@ -0,0 +1,138 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package search
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// TaskQuery search of the task executions
+type TaskQuery struct {
+	query
+}
+
+func Tasks() *TaskQuery {
+	return &TaskQuery{}
+}
+
+// Type the task types, e.g. SIMPLE or HTTP
+func (q *TaskQuery) Type(taskTypes ...string) *TaskQuery {
+	q.in("taskType", taskTypes)
+	return q
+}
+
+// TaskDefName the names of the task definitions
+func (q *TaskQuery) TaskDefName(names ...string) *TaskQuery {
+	q.in("taskDefName", names)
+	return q
+}
+
+func (q *TaskQuery) Status(statuses ...model.TaskResultStatus) *TaskQuery {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	q.in("status", values)
+	return q
+}
+
+// WorkflowType the names of the workflows the tasks belong to
+func (q *TaskQuery) WorkflowType(workflowNames ...string) *TaskQuery {
+	q.in("workflowType", workflowNames)
+	return q
+}
+
+func (q *TaskQuery) WorkflowId(workflowIds ...string) *TaskQuery {
+	q.in("workflowId", workflowIds)
+	return q
+}
+
+func (q *TaskQuery) TaskId(taskIds ...string) *TaskQuery {
+	q.in("taskId", taskIds)
+	return q
+}
+
+func (q *TaskQuery) StartedAfter(t time.Time) *TaskQuery {
+	q.where("startTime", GreaterThan, t)
+	return q
+}
+
+func (q *TaskQuery) StartedBefore(t time.Time) *TaskQuery {
+	q.where("startTime", LessThan, t)
+	return q
+}
+
+func (q *TaskQuery) UpdatedAfter(t time.Time) *TaskQuery {
+	q.where("updateTime", GreaterThan, t)
+	return q
+}
+
+func (q *TaskQuery) UpdatedBefore(t time.Time) *TaskQuery {
+	q.where("updateTime", LessThan, t)
+	return q
+}
+
+// Where adds a condition on any field, the values are quoted as needed
+func (q *TaskQuery) Where(field string, operator string, values ...interface{}) *TaskQuery {
+	q.where(field, operator, values...)
+	return q
+}
+
+// FreeText search in the input and output of the tasks, * by default
+func (q *TaskQuery) FreeText(freeText string) *TaskQuery {
+	q.freeText = freeText
+	return q
+}
+
+// Sort e.g. updateTime:DESC
+func (q *TaskQuery) Sort(sort string) *TaskQuery {
+	q.sort = sort
+	return q
+}
+
+// PageSize number of results fetched per request
+func (q *TaskQuery) PageSize(pageSize int32) *TaskQuery {
+	q.pageSize = pageSize
+	return q
+}
+
+// Iterate over the matching tasks, the pages are fetched as the iteration advances
+func (q *TaskQuery) Iterate(ctx context.Context, taskClient client.TaskClient) iter.Seq2[model.Task, error] {
+	return Paginate(q.size(), func(start int32, size int32) ([]model.Task, int64, error) {
+		result, _, err := taskClient.SearchV2(ctx, &client.TaskResourceApiSearchV21Opts{
+			Start:    optional.NewInt32(start),
+			Size:     optional.NewInt32(size),
+			Sort:     optionalString(q.sort),
+			FreeText: optional.NewString(q.freeTextOrAll()),
+			Query:    optionalString(q.String()),
+		})
+		return result.Results, result.TotalHits, err
+	})
+}
+
+// IterateSummaries over the summaries of the matching tasks
+func (q *TaskQuery) IterateSummaries(ctx context.Context, taskClient client.TaskClient) iter.Seq2[model.TaskSummary, error] {
+	return Paginate(q.size(), func(start int32, size int32) ([]model.TaskSummary, int64, error) {
+		result, _, err := taskClient.Search(ctx, &client.TaskResourceApiSearch1Opts{
+			Start:    optional.NewInt32(start),
+			Size:     optional.NewInt32(size),
+			Sort:     optionalString(q.sort),
+			FreeText: optional.NewString(q.freeTextOrAll()),
+			Query:    optionalString(q.String()),
+		})
+		return result.Results, result.TotalHits, err
+	})
+}
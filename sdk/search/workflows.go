@@ -0,0 +1,117 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package search
+
+import (
+	"context"
+	"iter"
+	"time"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// WorkflowQuery search of the workflow executions
+//
+//	query := search.Workflows().Type("order").Status(model.RunningWorkflow).StartedBefore(twoDaysAgo).Sort("startTime:DESC")
+//	for workflow, err := range query.Iterate(ctx, workflowClient) {
+//	}
+type WorkflowQuery struct {
+	query
+}
+
+func Workflows() *WorkflowQuery {
+	return &WorkflowQuery{}
+}
+
+// Type the names of the workflows
+func (q *WorkflowQuery) Type(workflowNames ...string) *WorkflowQuery {
+	q.in("workflowType", workflowNames)
+	return q
+}
+
+func (q *WorkflowQuery) Status(statuses ...model.WorkflowStatus) *WorkflowQuery {
+	values := make([]string, len(statuses))
+	for i, status := range statuses {
+		values[i] = string(status)
+	}
+	q.in("status", values)
+	return q
+}
+
+func (q *WorkflowQuery) WorkflowId(workflowIds ...string) *WorkflowQuery {
+	q.in("workflowId", workflowIds)
+	return q
+}
+
+func (q *WorkflowQuery) CorrelationId(correlationIds ...string) *WorkflowQuery {
+	q.in("correlationId", correlationIds)
+	return q
+}
+
+func (q *WorkflowQuery) StartedAfter(t time.Time) *WorkflowQuery {
+	q.where("startTime", GreaterThan, t)
+	return q
+}
+
+func (q *WorkflowQuery) StartedBefore(t time.Time) *WorkflowQuery {
+	q.where("startTime", LessThan, t)
+	return q
+}
+
+func (q *WorkflowQuery) UpdatedAfter(t time.Time) *WorkflowQuery {
+	q.where("updateTime", GreaterThan, t)
+	return q
+}
+
+func (q *WorkflowQuery) UpdatedBefore(t time.Time) *WorkflowQuery {
+	q.where("updateTime", LessThan, t)
+	return q
+}
+
+// Where adds a condition on any field, the values are quoted as needed
+func (q *WorkflowQuery) Where(field string, operator string, values ...interface{}) *WorkflowQuery {
+	q.where(field, operator, values...)
+	return q
+}
+
+// FreeText search in the input and output of the workflows, * by default
+func (q *WorkflowQuery) FreeText(freeText string) *WorkflowQuery {
+	q.freeText = freeText
+	return q
+}
+
+// Sort e.g. startTime:DESC
+func (q *WorkflowQuery) Sort(sort string) *WorkflowQuery {
+	q.sort = sort
+	return q
+}
+
+// PageSize number of results fetched per request
+func (q *WorkflowQuery) PageSize(pageSize int32) *WorkflowQuery {
+	q.pageSize = pageSize
+	return q
+}
+
+// Iterate over the summaries of the matching workflows, the pages are fetched as the iteration advances
+func (q *WorkflowQuery) Iterate(ctx context.Context, workflowClient client.WorkflowClient) iter.Seq2[model.WorkflowSummary, error] {
+	return Paginate(q.size(), func(start int32, size int32) ([]model.WorkflowSummary, int64, error) {
+		result, _, err := workflowClient.Search(ctx, &client.WorkflowResourceApiSearchOpts{
+			Start:    optional.NewInt32(start),
+			Size:     optional.NewInt32(size),
+			Sort:     optionalString(q.sort),
+			FreeText: optional.NewString(q.freeTextOrAll()),
+			Query:    optionalString(q.String()),
+		})
+		return result.Results, result.TotalHits, err
+	})
+}
+
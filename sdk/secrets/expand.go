@@ -0,0 +1,136 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+// placeholders ${secret:name} and ${env:name}, the other expressions such as ${workflow.input.x} are left as they are
+var placeholder = regexp.MustCompile(`\$\{(secret|env):([^}]+)\}`)
+
+// Expand replaces the ${secret:name} and ${env:name} placeholders of the text with their values
+func (resolver *Resolver) Expand(ctx context.Context, text string) (string, error) {
+	var problems []string
+	expanded := placeholder.ReplaceAllStringFunc(text, func(match string) string {
+		groups := placeholder.FindStringSubmatch(match)
+		name := strings.TrimSpace(groups[2])
+		var value string
+		var err error
+		if groups[1] == secretKind {
+			value, err = resolver.Secret(ctx, name)
+		} else {
+			value, err = resolver.Env(ctx, name)
+		}
+		if err != nil {
+			problems = append(problems, err.Error())
+			return match
+		}
+		return value
+	})
+	if len(problems) > 0 {
+		return "", errors.New(strings.Join(problems, "; "))
+	}
+	return expanded, nil
+}
+
+// ExpandStruct replaces the placeholders in the strings of the configuration, target is a pointer to a struct or a
+// map.  The exported string fields are expanded, including the ones of the nested structs, pointers, slices and maps.
+// Returns the errors of all the placeholders that could not be resolved, the configuration is unchanged in that case
+func (resolver *Resolver) ExpandStruct(ctx context.Context, target interface{}) error {
+	value := reflect.ValueOf(target)
+	if (value.Kind() != reflect.Ptr && value.Kind() != reflect.Map) || value.IsNil() {
+		return fmt.Errorf("expected a pointer or a map, got %T", target)
+	}
+	var updates []func()
+	var problems []string
+	var walk func(value reflect.Value, path string, set func(reflect.Value))
+	walk = func(value reflect.Value, path string, set func(reflect.Value)) {
+		switch value.Kind() {
+		case reflect.String:
+			if !placeholder.MatchString(value.String()) {
+				return
+			}
+			expanded, err := resolver.Expand(ctx, value.String())
+			if err != nil {
+				problems = append(problems, path+": "+err.Error())
+				return
+			}
+			if set != nil {
+				updates = append(updates, func() { set(reflect.ValueOf(expanded).Convert(value.Type())) })
+			}
+		case reflect.Ptr:
+			if !value.IsNil() {
+				walk(value.Elem(), path, settable(value.Elem()))
+			}
+		case reflect.Interface:
+			if !value.IsNil() {
+				// the value of an interface is not addressable, a copy is expanded and replaces it
+				element := addressableCopy(value.Elem())
+				pending := len(updates)
+				walk(element, path, settable(element))
+				if len(updates) > pending && set != nil {
+					updates = append(updates, func() { set(element) })
+				}
+			}
+		case reflect.Struct:
+			for i := 0; i < value.NumField(); i++ {
+				field := value.Field(i)
+				if !value.Type().Field(i).IsExported() {
+					continue
+				}
+				walk(field, path+"."+value.Type().Field(i).Name, settable(field))
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				walk(value.Index(i), fmt.Sprintf("%s[%d]", path, i), settable(value.Index(i)))
+			}
+		case reflect.Map:
+			for _, key := range value.MapKeys() {
+				key := key
+				// the values of a map are not addressable, a copy is expanded and replaces the value
+				element := addressableCopy(value.MapIndex(key))
+				pending := len(updates)
+				walk(element, fmt.Sprintf("%s[%v]", path, key), settable(element))
+				if len(updates) > pending {
+					updates = append(updates, func() { value.SetMapIndex(key, element) })
+				}
+			}
+		}
+	}
+	walk(value, "config", nil)
+	if len(problems) > 0 {
+		return errors.New(strings.Join(problems, "; "))
+	}
+	for _, update := range updates {
+		update()
+	}
+	return nil
+}
+
+func addressableCopy(value reflect.Value) reflect.Value {
+	element := reflect.New(value.Type()).Elem()
+	element.Set(value)
+	return element
+}
+
+func settable(value reflect.Value) func(reflect.Value) {
+	if !value.CanSet() {
+		return nil
+	}
+	return func(v reflect.Value) {
+		value.Set(v)
+	}
+}
@@ -0,0 +1,285 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package secrets
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	secretKind = "secret"
+	envKind    = "env"
+
+	defaultTtl          = 5 * time.Minute
+	defaultNotFoundTtl  = time.Minute
+	defaultBackoff      = time.Second
+	defaultMaxBackoff   = time.Minute
+	prefetchConcurrency = 8
+	redacted            = "****"
+)
+
+// ErrNotFound the secret or the environment variable does not exist, or is not visible to the application
+var ErrNotFound = errors.New("not found")
+
+type entry struct {
+	value     string
+	found     bool
+	expiresAt time.Time
+	// set after a failure other than not found, the server is not called again before retryAt
+	err      error
+	failures int
+	retryAt  time.Time
+}
+
+// Resolver reads the secrets and the environment variables stored in Conductor and caches them for the TTL.
+// Missing values are cached for the not found TTL.  Other failures, e.g. 5xx responses, are retried with an
+// exponential backoff, in the meantime the expired value is served when there is one.
+// Values never appear in the errors or the logs of the resolver, see Redact for the logs of the workers
+type Resolver struct {
+	secretsClient     client.SecretsClient
+	environmentClient client.EnvironmentClient
+	ttl               time.Duration
+	notFoundTtl       time.Duration
+	backoff           time.Duration
+	maxBackoff        time.Duration
+	now               func() time.Time
+
+	mutex   sync.Mutex
+	entries map[string]*entry
+}
+
+// NewResolver the resolver of the secrets and the environment variables, either client may be nil when the values of
+// its kind are not used
+func NewResolver(secretsClient client.SecretsClient, environmentClient client.EnvironmentClient) *Resolver {
+	return &Resolver{
+		secretsClient:     secretsClient,
+		environmentClient: environmentClient,
+		ttl:               defaultTtl,
+		notFoundTtl:       defaultNotFoundTtl,
+		backoff:           defaultBackoff,
+		maxBackoff:        defaultMaxBackoff,
+		now:               time.Now,
+		entries:           map[string]*entry{},
+	}
+}
+
+// WithTTL how long the values are cached, 5 minutes by default
+func (resolver *Resolver) WithTTL(ttl time.Duration) *Resolver {
+	resolver.ttl = ttl
+	return resolver
+}
+
+// WithNotFoundTTL how long the missing values are remembered, 1 minute by default
+func (resolver *Resolver) WithNotFoundTTL(ttl time.Duration) *Resolver {
+	resolver.notFoundTtl = ttl
+	return resolver
+}
+
+// WithBackoff the delay before retrying after a failure, doubled after each consecutive failure up to max.
+// 1 second up to 1 minute by default
+func (resolver *Resolver) WithBackoff(initial time.Duration, max time.Duration) *Resolver {
+	resolver.backoff = initial
+	resolver.maxBackoff = max
+	return resolver
+}
+
+// Secret the value of the secret
+func (resolver *Resolver) Secret(ctx context.Context, name string) (string, error) {
+	if resolver.secretsClient == nil {
+		return "", fmt.Errorf("cannot resolve secret %s: no secrets client", name)
+	}
+	return resolver.resolve(ctx, secretKind, name, func(ctx context.Context) (string, *http.Response, error) {
+		return resolver.secretsClient.GetSecret(ctx, name)
+	})
+}
+
+// Env the value of the environment variable stored in Conductor
+func (resolver *Resolver) Env(ctx context.Context, name string) (string, error) {
+	if resolver.environmentClient == nil {
+		return "", fmt.Errorf("cannot resolve env %s: no environment client", name)
+	}
+	return resolver.resolve(ctx, envKind, name, func(ctx context.Context) (string, *http.Response, error) {
+		return resolver.environmentClient.Get(ctx, name)
+	})
+}
+
+func (resolver *Resolver) resolve(ctx context.Context, kind string, name string, fetch func(ctx context.Context) (string, *http.Response, error)) (string, error) {
+	key := kind + ":" + name
+	now := resolver.now()
+	resolver.mutex.Lock()
+	cached, isCached := resolver.entries[key]
+	if isCached && now.Before(cached.expiresAt) {
+		resolver.mutex.Unlock()
+		return cached.result(kind, name)
+	}
+	if isCached && cached.err != nil && now.Before(cached.retryAt) {
+		resolver.mutex.Unlock()
+		return cached.stale(kind, name)
+	}
+	resolver.mutex.Unlock()
+
+	value, response, err := fetch(ctx)
+
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	now = resolver.now()
+	current := resolver.entries[key]
+	switch {
+	case err == nil:
+		resolver.entries[key] = &entry{value: value, found: true, expiresAt: now.Add(resolver.ttl)}
+		log.Debug("Resolved ", kind, " ", name)
+		return value, nil
+	case internal.IsNotFound(response, err):
+		resolver.entries[key] = &entry{expiresAt: now.Add(resolver.notFoundTtl)}
+		return "", fmt.Errorf("%s %s: %w", kind, name, ErrNotFound)
+	}
+	if current == nil {
+		current = &entry{}
+		resolver.entries[key] = current
+	}
+	if ctx.Err() != nil {
+		return current.stale(kind, name)
+	}
+	current.failures++
+	current.err = fmt.Errorf("failed to resolve %s %s: %w", kind, name, err)
+	current.retryAt = now.Add(resolver.backoffFor(current.failures))
+	log.Warning("Failed to resolve ", kind, " ", name, ", retrying after ", current.retryAt.Sub(now), ", error: ", err)
+	return current.stale(kind, name)
+}
+
+func (resolver *Resolver) backoffFor(failures int) time.Duration {
+	backoff := resolver.backoff
+	for i := 1; i < failures && backoff < resolver.maxBackoff; i++ {
+		backoff *= 2
+	}
+	if backoff > resolver.maxBackoff {
+		return resolver.maxBackoff
+	}
+	return backoff
+}
+
+func (cached *entry) result(kind string, name string) (string, error) {
+	if !cached.found {
+		return "", fmt.Errorf("%s %s: %w", kind, name, ErrNotFound)
+	}
+	return cached.value, nil
+}
+
+// stale the expired value while the server fails, the error when there is none
+func (cached *entry) stale(kind string, name string) (string, error) {
+	if cached.found {
+		return cached.value, nil
+	}
+	if cached.err != nil {
+		return "", cached.err
+	}
+	return "", fmt.Errorf("failed to resolve %s %s", kind, name)
+}
+
+// PrefetchSecrets resolves the secrets concurrently, e.g. when the worker starts, and returns the errors of all the
+// secrets that could not be resolved
+func (resolver *Resolver) PrefetchSecrets(ctx context.Context, names ...string) error {
+	var wg sync.WaitGroup
+	var mutex sync.Mutex
+	var problems []string
+	semaphore := make(chan struct{}, prefetchConcurrency)
+	for _, name := range names {
+		wg.Add(1)
+		semaphore <- struct{}{}
+		go func(name string) {
+			defer wg.Done()
+			defer func() { <-semaphore }()
+			if _, err := resolver.Secret(ctx, name); err != nil {
+				mutex.Lock()
+				problems = append(problems, err.Error())
+				mutex.Unlock()
+			}
+		}(name)
+	}
+	wg.Wait()
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return errors.New(strings.Join(problems, "; "))
+}
+
+// PrefetchEnv caches all the environment variables with a single request
+func (resolver *Resolver) PrefetchEnv(ctx context.Context) error {
+	if resolver.environmentClient == nil {
+		return errors.New("cannot prefetch the environment variables: no environment client")
+	}
+	variables, _, err := resolver.environmentClient.GetAll(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to prefetch the environment variables: %w", err)
+	}
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	expiresAt := resolver.now().Add(resolver.ttl)
+	for _, variable := range variables {
+		resolver.entries[envKind+":"+variable.Name] = &entry{value: variable.Value, found: true, expiresAt: expiresAt}
+	}
+	return nil
+}
+
+// InvalidateSecret drops the cached secret, the next use reads it from the server, e.g. after the secret is rotated
+func (resolver *Resolver) InvalidateSecret(name string) {
+	resolver.invalidate(secretKind + ":" + name)
+}
+
+// InvalidateEnv drops the cached environment variable
+func (resolver *Resolver) InvalidateEnv(name string) {
+	resolver.invalidate(envKind + ":" + name)
+}
+
+// InvalidateAll drops all the cached values
+func (resolver *Resolver) InvalidateAll() {
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	resolver.entries = map[string]*entry{}
+}
+
+func (resolver *Resolver) invalidate(key string) {
+	resolver.mutex.Lock()
+	defer resolver.mutex.Unlock()
+	delete(resolver.entries, key)
+}
+
+// Redact replaces the values of the secrets resolved so far with ****, e.g. before logging a message or an error that
+// may contain them.  The environment variables are not considered secret
+func (resolver *Resolver) Redact(text string) string {
+	resolver.mutex.Lock()
+	values := make([]string, 0, len(resolver.entries))
+	for key, cached := range resolver.entries {
+		if cached.found && cached.value != "" && strings.HasPrefix(key, secretKind+":") {
+			values = append(values, cached.value)
+		}
+	}
+	resolver.mutex.Unlock()
+	// the longest first, a value may contain another
+	sort.Slice(values, func(i, j int) bool {
+		return len(values[i]) > len(values[j])
+	})
+	for _, value := range values {
+		text = strings.ReplaceAll(text, value, redacted)
+	}
+	return text
+}
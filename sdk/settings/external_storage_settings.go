@@ -13,10 +13,22 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 )
 
+const (
+	defaultWorkflowInputPayloadThresholdKB    = 5120
+	defaultWorkflowInputMaxPayloadThresholdKB = 10240
+)
+
+// ExternalStorageSettings payloads larger than the threshold are stored using the PayloadStore and only their path is
+// sent to the server.  Payloads larger than the max threshold are rejected.
+// ExternalStorageHandler is used to store the task outputs when no PayloadStore is set.  There is no default
+// PayloadStore, reading the payloads stored externally fails without one
 type ExternalStorageSettings struct {
-	TaskOutputPayloadThresholdKB    int64
-	TaskOutputMaxPayloadThresholdKB int64
-	ExternalStorageHandler          model.ExternalStorageHandler
+	TaskOutputPayloadThresholdKB       int64
+	TaskOutputMaxPayloadThresholdKB    int64
+	WorkflowInputPayloadThresholdKB    int64
+	WorkflowInputMaxPayloadThresholdKB int64
+	ExternalStorageHandler             model.ExternalStorageHandler
+	PayloadStore                       model.PayloadStore
 }
 
 func NewExternalStorageSettings(
@@ -25,8 +37,22 @@ func NewExternalStorageSettings(
 	externalStorageHandler model.ExternalStorageHandler,
 ) *ExternalStorageSettings {
 	return &ExternalStorageSettings{
-		TaskOutputPayloadThresholdKB:    taskOutputPayloadThresholdKB,
-		TaskOutputMaxPayloadThresholdKB: taskOutputMaxPayloadThresholdKB,
-		ExternalStorageHandler:          externalStorageHandler,
+		TaskOutputPayloadThresholdKB:       taskOutputPayloadThresholdKB,
+		TaskOutputMaxPayloadThresholdKB:    taskOutputMaxPayloadThresholdKB,
+		WorkflowInputPayloadThresholdKB:    defaultWorkflowInputPayloadThresholdKB,
+		WorkflowInputMaxPayloadThresholdKB: defaultWorkflowInputMaxPayloadThresholdKB,
+		ExternalStorageHandler:             externalStorageHandler,
 	}
 }
+
+// NewExternalPayloadStorageSettings settings storing the payloads using payloadStore, see storage package for the
+// implementations
+func NewExternalPayloadStorageSettings(
+	taskOutputPayloadThresholdKB int64,
+	taskOutputMaxPayloadThresholdKB int64,
+	payloadStore model.PayloadStore,
+) *ExternalStorageSettings {
+	externalStorageSettings := NewExternalStorageSettings(taskOutputPayloadThresholdKB, taskOutputMaxPayloadThresholdKB, nil)
+	externalStorageSettings.PayloadStore = payloadStore
+	return externalStorageSettings
+}
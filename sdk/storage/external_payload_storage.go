@@ -0,0 +1,230 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+)
+
+// PayloadTooLargeError the payload is larger than the max threshold and can not be sent to the server
+type PayloadTooLargeError struct {
+	PayloadType metrics.PayloadType
+	SizeKB      int64
+	MaxSizeKB   int64
+	EntityName  string
+}
+
+func (e *PayloadTooLargeError) Error() string {
+	return fmt.Sprintf("the %s payload of %s is %d KB, larger than the max of %d KB", e.PayloadType, e.EntityName, e.SizeKB, e.MaxSizeKB)
+}
+
+// ErrNoPayloadStore the settings have no PayloadStore to read or write the payloads stored externally
+var ErrNoPayloadStore = errors.New("no payload store to read or write the payloads stored externally, see settings.NewExternalPayloadStorageSettings")
+
+// ExternalPayloadStorage moves the payloads between the server and the external storage.  The server provides the
+// location of each payload, the payload itself is read and written using the PayloadStore of the settings
+type ExternalPayloadStorage struct {
+	settings       *settings.ExternalStorageSettings
+	taskClient     client.TaskClient
+	workflowClient client.WorkflowClient
+}
+
+func NewExternalPayloadStorage(apiClient *client.APIClient, externalStorageSettings *settings.ExternalStorageSettings) *ExternalPayloadStorage {
+	return &ExternalPayloadStorage{
+		settings:       externalStorageSettings,
+		taskClient:     client.NewTaskClient(apiClient),
+		workflowClient: client.NewWorkflowClient(apiClient),
+	}
+}
+
+// DownloadTaskInput sets the input of the task from the external storage when the input was stored externally
+func (s *ExternalPayloadStorage) DownloadTaskInput(ctx context.Context, task *model.Task) error {
+	if task.ExternalInputPayloadStoragePath == "" {
+		return nil
+	}
+	input, err := s.download(ctx, task.TaskDefName, task.ExternalInputPayloadStoragePath, metrics.TASK_INPUT)
+	if err != nil {
+		return err
+	}
+	task.InputData = input
+	task.ExternalInputPayloadStoragePath = ""
+	return nil
+}
+
+// DownloadTaskOutput sets the output of the task from the external storage when the output was stored externally
+func (s *ExternalPayloadStorage) DownloadTaskOutput(ctx context.Context, task *model.Task) error {
+	if task.ExternalOutputPayloadStoragePath == "" {
+		return nil
+	}
+	output, err := s.download(ctx, task.TaskDefName, task.ExternalOutputPayloadStoragePath, metrics.TASK_OUTPUT)
+	if err != nil {
+		return err
+	}
+	task.OutputData = output
+	task.ExternalOutputPayloadStoragePath = ""
+	return nil
+}
+
+// DownloadWorkflowPayloads sets the input and output of the workflow, and of its tasks, stored externally
+func (s *ExternalPayloadStorage) DownloadWorkflowPayloads(ctx context.Context, workflow *model.Workflow) error {
+	if workflow.ExternalInputPayloadStoragePath != "" {
+		input, err := s.download(ctx, workflow.WorkflowName, workflow.ExternalInputPayloadStoragePath, metrics.WORKFLOW_INPUT)
+		if err != nil {
+			return err
+		}
+		workflow.Input = input
+		workflow.ExternalInputPayloadStoragePath = ""
+	}
+	if workflow.ExternalOutputPayloadStoragePath != "" {
+		output, err := s.download(ctx, workflow.WorkflowName, workflow.ExternalOutputPayloadStoragePath, metrics.WORKFLOW_OUTPUT)
+		if err != nil {
+			return err
+		}
+		workflow.Output = output
+		workflow.ExternalOutputPayloadStoragePath = ""
+	}
+	for i := range workflow.Tasks {
+		if err := s.DownloadTaskInput(ctx, &workflow.Tasks[i]); err != nil {
+			return err
+		}
+		if err := s.DownloadTaskOutput(ctx, &workflow.Tasks[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// UploadTaskOutput stores the output of the task externally when it is larger than TaskOutputPayloadThresholdKB.
+// Returns PayloadTooLargeError when the output is larger than TaskOutputMaxPayloadThresholdKB
+func (s *ExternalPayloadStorage) UploadTaskOutput(ctx context.Context, taskType string, taskResult *model.TaskResult) error {
+	path, err := s.upload(ctx, taskType, taskResult.OutputData, metrics.TASK_OUTPUT,
+		s.settings.TaskOutputPayloadThresholdKB, s.settings.TaskOutputMaxPayloadThresholdKB)
+	if err != nil || path == "" {
+		return err
+	}
+	taskResult.OutputData = nil
+	taskResult.ExternalOutputPayloadStoragePath = path
+	return nil
+}
+
+// UploadWorkflowInput stores the input of the workflow externally when it is larger than WorkflowInputPayloadThresholdKB.
+// Returns PayloadTooLargeError when the input is larger than WorkflowInputMaxPayloadThresholdKB
+func (s *ExternalPayloadStorage) UploadWorkflowInput(ctx context.Context, request *model.StartWorkflowRequest) error {
+	input, isMap := request.Input.(map[string]interface{})
+	if !isMap {
+		converted, err := model.ConvertToMap(request.Input)
+		if err != nil {
+			return err
+		}
+		input = converted
+	}
+	path, err := s.upload(ctx, request.Name, input, metrics.WORKFLOW_INPUT,
+		s.settings.WorkflowInputPayloadThresholdKB, s.settings.WorkflowInputMaxPayloadThresholdKB)
+	if err != nil || path == "" {
+		return err
+	}
+	request.Input = nil
+	request.ExternalInputPayloadStoragePath = path
+	return nil
+}
+
+// upload returns the path of the stored payload, or empty if the payload is below the threshold
+func (s *ExternalPayloadStorage) upload(ctx context.Context, entityName string, payload map[string]interface{}, payloadType metrics.PayloadType, thresholdKB int64, maxThresholdKB int64) (string, error) {
+	if thresholdKB <= 0 || len(payload) == 0 {
+		return "", nil
+	}
+	data, err := json.Marshal(payload)
+	if err != nil {
+		return "", err
+	}
+	sizeKB := int64(len(data)) / 1024
+	if int64(len(data)) <= thresholdKB*1024 {
+		return "", nil
+	}
+	if maxThresholdKB > 0 && int64(len(data)) > maxThresholdKB*1024 {
+		return "", &PayloadTooLargeError{PayloadType: payloadType, SizeKB: sizeKB, MaxSizeKB: maxThresholdKB, EntityName: entityName}
+	}
+	var path string
+	if s.settings.PayloadStore == nil && s.settings.ExternalStorageHandler != nil {
+		path, err = s.settings.ExternalStorageHandler(payload)
+		if err != nil {
+			return "", err
+		}
+	} else {
+		payloadStore, err := s.payloadStore()
+		if err != nil {
+			return "", err
+		}
+		location, err := s.location(ctx, "", metrics.WRITE, payloadType)
+		if err != nil {
+			return "", err
+		}
+		if err := payloadStore.Upload(ctx, location.Uri, data); err != nil {
+			return "", err
+		}
+		path = location.Path
+	}
+	metrics.IncrementExternalPayloadUsed(entityName, string(metrics.WRITE), string(payloadType))
+	return path, nil
+}
+
+func (s *ExternalPayloadStorage) download(ctx context.Context, entityName string, path string, payloadType metrics.PayloadType) (map[string]interface{}, error) {
+	payloadStore, err := s.payloadStore()
+	if err != nil {
+		return nil, err
+	}
+	location, err := s.location(ctx, path, metrics.READ, payloadType)
+	if err != nil {
+		return nil, err
+	}
+	data, err := payloadStore.Download(ctx, location.Uri)
+	if err != nil {
+		return nil, err
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return nil, fmt.Errorf("failed to parse the %s payload stored at %s, reason: %s", payloadType, path, err)
+	}
+	metrics.IncrementExternalPayloadUsed(entityName, string(metrics.READ), string(payloadType))
+	return payload, nil
+}
+
+func (s *ExternalPayloadStorage) location(ctx context.Context, path string, operation metrics.Operation, payloadType metrics.PayloadType) (model.ExternalStorageLocation, error) {
+	var location model.ExternalStorageLocation
+	var err error
+	if payloadType == metrics.WORKFLOW_INPUT || payloadType == metrics.WORKFLOW_OUTPUT {
+		location, _, err = s.workflowClient.GetExternalStorageLocation(ctx, path, string(operation), string(payloadType))
+	} else {
+		location, _, err = s.taskClient.GetExternalStorageLocation1(ctx, path, string(operation), string(payloadType))
+	}
+	if err != nil {
+		return location, fmt.Errorf("failed to get the location of the %s payload, reason: %s", payloadType, err)
+	}
+	if location.Uri == "" {
+		location.Uri = path
+	}
+	return location, nil
+}
+
+// payloadStore the PayloadStore of the settings, there is no default since it depends on the storage of the server
+func (s *ExternalPayloadStorage) payloadStore() (model.PayloadStore, error) {
+	if s.settings.PayloadStore == nil {
+		return nil, ErrNoPayloadStore
+	}
+	return s.settings.PayloadStore, nil
+}
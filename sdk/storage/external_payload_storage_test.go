@@ -0,0 +1,149 @@
+package storage
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestServer serves the external storage locations and stands in for S3, storing the payloads by path
+func newTestServer(payloads map[string][]byte) *httptest.Server {
+	var server *httptest.Server
+	server = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasSuffix(r.URL.Path, "/externalstoragelocation"):
+			path := r.URL.Query().Get("path")
+			if path == "" {
+				path = strings.ToLower(r.URL.Query().Get("payloadType")) + ".json"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(model.ExternalStorageLocation{Path: path, Uri: server.URL + "/s3/" + path})
+		case strings.HasPrefix(r.URL.Path, "/s3/") && r.Method == http.MethodPut:
+			payloads[strings.TrimPrefix(r.URL.Path, "/s3/")], _ = io.ReadAll(r.Body)
+		case strings.HasPrefix(r.URL.Path, "/s3/"):
+			payload, found := payloads[strings.TrimPrefix(r.URL.Path, "/s3/")]
+			if !found {
+				w.WriteHeader(http.StatusNotFound)
+			}
+			w.Write(payload)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	return server
+}
+
+func newTestStorage(server *httptest.Server, externalStorageSettings *settings.ExternalStorageSettings) *ExternalPayloadStorage {
+	return NewExternalPayloadStorage(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)), externalStorageSettings)
+}
+
+func largePayload(sizeKB int) map[string]interface{} {
+	return map[string]interface{}{"data": strings.Repeat("x", sizeKB*1024)}
+}
+
+func TestUploadTaskOutput(t *testing.T) {
+	payloads := map[string][]byte{}
+	server := newTestServer(payloads)
+	defer server.Close()
+	storage := newTestStorage(server, settings.NewExternalPayloadStorageSettings(1, 4, NewS3PayloadStore(nil)))
+
+	small := &model.TaskResult{OutputData: map[string]interface{}{"key": "value"}}
+	assert.NoError(t, storage.UploadTaskOutput(context.Background(), "task", small))
+	assert.Equal(t, "value", small.OutputData["key"])
+	assert.Empty(t, small.ExternalOutputPayloadStoragePath)
+
+	large := &model.TaskResult{OutputData: largePayload(2)}
+	assert.NoError(t, storage.UploadTaskOutput(context.Background(), "task", large))
+	assert.Nil(t, large.OutputData)
+	assert.Equal(t, "task_output.json", large.ExternalOutputPayloadStoragePath)
+	assert.Contains(t, string(payloads["task_output.json"]), "xxxx")
+
+	tooLarge := &model.TaskResult{OutputData: largePayload(5)}
+	err := storage.UploadTaskOutput(context.Background(), "task", tooLarge)
+	assert.IsType(t, &PayloadTooLargeError{}, err)
+	assert.Equal(t, "the TASK_OUTPUT payload of task is 5 KB, larger than the max of 4 KB", err.Error())
+}
+
+func TestDownloadPayloads(t *testing.T) {
+	payloads := map[string][]byte{
+		"input.json":  []byte(`{"userId":"user_1"}`),
+		"output.json": []byte(`{"status":"done"}`),
+	}
+	server := newTestServer(payloads)
+	defer server.Close()
+	storage := newTestStorage(server, settings.NewExternalPayloadStorageSettings(1, 4, NewS3PayloadStore(nil)))
+
+	task := &model.Task{ExternalInputPayloadStoragePath: "input.json"}
+	assert.NoError(t, storage.DownloadTaskInput(context.Background(), task))
+	assert.Equal(t, map[string]interface{}{"userId": "user_1"}, task.InputData)
+	assert.Empty(t, task.ExternalInputPayloadStoragePath)
+
+	workflow := &model.Workflow{
+		ExternalInputPayloadStoragePath:  "input.json",
+		ExternalOutputPayloadStoragePath: "output.json",
+		Tasks:                            []model.Task{{ExternalOutputPayloadStoragePath: "output.json"}},
+	}
+	assert.NoError(t, storage.DownloadWorkflowPayloads(context.Background(), workflow))
+	assert.Equal(t, "user_1", workflow.Input["userId"])
+	assert.Equal(t, "done", workflow.Output["status"])
+	assert.Equal(t, "done", workflow.Tasks[0].OutputData["status"])
+
+	missing := &model.Task{ExternalInputPayloadStoragePath: "missing.json"}
+	assert.Error(t, storage.DownloadTaskInput(context.Background(), missing))
+}
+
+func TestNoPayloadStore(t *testing.T) {
+	server := newTestServer(map[string][]byte{"input.json": []byte(`{}`)})
+	defer server.Close()
+	storage := newTestStorage(server, settings.NewExternalPayloadStorageSettings(1, 4, nil))
+
+	task := &model.Task{ExternalInputPayloadStoragePath: "input.json"}
+	assert.Equal(t, ErrNoPayloadStore, storage.DownloadTaskInput(context.Background(), task))
+	large := &model.TaskResult{OutputData: largePayload(2)}
+	assert.Equal(t, ErrNoPayloadStore, storage.UploadTaskOutput(context.Background(), "task", large))
+	assert.NotNil(t, large.OutputData)
+}
+
+func TestUploadWorkflowInputToFileSystem(t *testing.T) {
+	server := newTestServer(map[string][]byte{})
+	defer server.Close()
+	rootDir := t.TempDir()
+	fileSystemStore := &locationAsPathStore{NewFileSystemPayloadStore(rootDir)}
+	externalStorageSettings := settings.NewExternalPayloadStorageSettings(1, 4, fileSystemStore)
+	externalStorageSettings.WorkflowInputPayloadThresholdKB = 1
+	storage := newTestStorage(server, externalStorageSettings)
+
+	type orderInput struct {
+		Items string `json:"items"`
+	}
+	request := &model.StartWorkflowRequest{Name: "order", Input: &orderInput{Items: strings.Repeat("x", 2048)}}
+	assert.NoError(t, storage.UploadWorkflowInput(context.Background(), request))
+	assert.Nil(t, request.Input)
+	assert.Equal(t, "workflow_input.json", request.ExternalInputPayloadStoragePath)
+
+	workflow := &model.Workflow{ExternalInputPayloadStoragePath: request.ExternalInputPayloadStoragePath}
+	assert.NoError(t, storage.DownloadWorkflowPayloads(context.Background(), workflow))
+	assert.Equal(t, strings.Repeat("x", 2048), workflow.Input["items"])
+}
+
+// locationAsPathStore ignores the URI of the test server and stores the payload by its path
+type locationAsPathStore struct {
+	*FileSystemPayloadStore
+}
+
+func (s *locationAsPathStore) Upload(ctx context.Context, uri string, payload []byte) error {
+	return s.FileSystemPayloadStore.Upload(ctx, uri[strings.Index(uri, "/s3/")+4:], payload)
+}
+
+func (s *locationAsPathStore) Download(ctx context.Context, uri string) ([]byte, error) {
+	return s.FileSystemPayloadStore.Download(ctx, uri[strings.Index(uri, "/s3/")+4:])
+}
@@ -0,0 +1,66 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// FileSystemPayloadStore stores the payloads as files, e.g. for a server using the local payload storage or a shared
+// volume.  Locations are paths relative to the root directory
+type FileSystemPayloadStore struct {
+	rootDir string
+}
+
+func NewFileSystemPayloadStore(rootDir string) *FileSystemPayloadStore {
+	return &FileSystemPayloadStore{rootDir: rootDir}
+}
+
+func (s *FileSystemPayloadStore) Upload(ctx context.Context, uri string, payload []byte) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	filePath, err := s.filePath(uri)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(filePath), 0755); err != nil {
+		return err
+	}
+	return os.WriteFile(filePath, payload, 0644)
+}
+
+func (s *FileSystemPayloadStore) Download(ctx context.Context, uri string) ([]byte, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+	filePath, err := s.filePath(uri)
+	if err != nil {
+		return nil, err
+	}
+	return os.ReadFile(filePath)
+}
+
+// filePath the file of the location under the root directory.  The locations escaping the root directory, absolute
+// paths and URIs are rejected, they are given by the server
+func (s *FileSystemPayloadStore) filePath(location string) (string, error) {
+	if strings.HasPrefix(location, "file:") {
+		return "", fmt.Errorf("invalid payload location %q, file URIs are not supported", location)
+	}
+	relativePath := filepath.Clean(filepath.FromSlash(location))
+	if !filepath.IsLocal(relativePath) || relativePath == "." {
+		return "", fmt.Errorf("invalid payload location %q, expected a path inside %s", location, s.rootDir)
+	}
+	return filepath.Join(s.rootDir, relativePath), nil
+}
@@ -0,0 +1,51 @@
+package storage
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestFileSystemPayloadStore(t *testing.T) {
+	rootDir := t.TempDir()
+	store := NewFileSystemPayloadStore(rootDir)
+	ctx := context.Background()
+
+	assert.NoError(t, store.Upload(ctx, "task/output.json", []byte(`{"status":"done"}`)))
+	payload, err := os.ReadFile(filepath.Join(rootDir, "task", "output.json"))
+	assert.NoError(t, err)
+	assert.Equal(t, `{"status":"done"}`, string(payload))
+
+	payload, err = store.Download(ctx, "task/../task/./output.json")
+	assert.NoError(t, err)
+	assert.Equal(t, `{"status":"done"}`, string(payload))
+}
+
+func TestFileSystemPayloadStoreLocations(t *testing.T) {
+	rootDir := t.TempDir()
+	store := NewFileSystemPayloadStore(filepath.Join(rootDir, "payloads"))
+	outside := filepath.Join(rootDir, "secret.json")
+	assert.NoError(t, os.WriteFile(outside, []byte(`{}`), 0644))
+
+	for _, location := range []string{
+		"",
+		".",
+		outside,
+		"/etc/passwd",
+		"file://" + filepath.ToSlash(outside),
+		"file:secret.json",
+		"../secret.json",
+		"task/../../secret.json",
+	} {
+		_, err := store.Download(context.Background(), location)
+		assert.ErrorContains(t, err, "invalid payload location", location)
+		err = store.Upload(context.Background(), location, []byte(`{}`))
+		assert.ErrorContains(t, err, "invalid payload location", location)
+	}
+	payload, err := os.ReadFile(outside)
+	assert.NoError(t, err)
+	assert.Equal(t, `{}`, string(payload))
+}
@@ -0,0 +1,70 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package storage
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+)
+
+// S3PayloadStore stores the payloads in S3, or any S3 compatible object storage, using the pre-signed URLs returned by
+// the server.  No credentials are required on the client
+type S3PayloadStore struct {
+	httpClient *http.Client
+}
+
+// NewS3PayloadStore httpClient is optional, http.DefaultClient is used when nil
+func NewS3PayloadStore(httpClient *http.Client) *S3PayloadStore {
+	if httpClient == nil {
+		httpClient = http.DefaultClient
+	}
+	return &S3PayloadStore{httpClient: httpClient}
+}
+
+func (s *S3PayloadStore) Upload(ctx context.Context, uri string, payload []byte) error {
+	request, err := http.NewRequestWithContext(ctx, http.MethodPut, uri, bytes.NewReader(payload))
+	if err != nil {
+		return err
+	}
+	request.Header.Set("Content-Type", "application/json")
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return err
+	}
+	defer response.Body.Close()
+	if response.StatusCode >= 300 {
+		body, _ := io.ReadAll(response.Body)
+		return fmt.Errorf("failed to upload the payload, status: %d, body: %s", response.StatusCode, body)
+	}
+	return nil
+}
+
+func (s *S3PayloadStore) Download(ctx context.Context, uri string) ([]byte, error) {
+	request, err := http.NewRequestWithContext(ctx, http.MethodGet, uri, nil)
+	if err != nil {
+		return nil, err
+	}
+	response, err := s.httpClient.Do(request)
+	if err != nil {
+		return nil, err
+	}
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	if err != nil {
+		return nil, err
+	}
+	if response.StatusCode >= 300 {
+		return nil, fmt.Errorf("failed to download the payload, status: %d, body: %s", response.StatusCode, body)
+	}
+	return body, nil
+}
@@ -0,0 +1,227 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// Manager reads and changes the tags of every kind of resource through one API, the tags of each kind are sent to
+// its own endpoints in the type they expect
+type Manager struct {
+	tagsClient        *client.TagsApiService
+	metadataClient    client.MetadataClient
+	schedulerClient   client.SchedulerClient
+	secretsClient     client.SecretsClient
+	environmentClient client.EnvironmentClient
+	applicationClient client.ApplicationClient
+	promptClient      client.PromptClient
+	integrationClient client.IntegrationClient
+	webhooksClient    client.WebhooksConfigClient
+}
+
+func NewManager(apiClient *client.APIClient) *Manager {
+	return &Manager{
+		tagsClient:        &client.TagsApiService{APIClient: apiClient},
+		metadataClient:    client.NewMetadataClient(apiClient),
+		schedulerClient:   client.NewSchedulerClient(apiClient),
+		secretsClient:     client.NewSecretsClient(apiClient),
+		environmentClient: client.NewEnvironmentClient(apiClient),
+		applicationClient: client.NewApplicationClient(apiClient),
+		promptClient:      client.NewPromptClient(apiClient),
+		integrationClient: client.NewIntegrationClient(apiClient),
+		webhooksClient:    client.NewWebhooksConfigClient(apiClient),
+	}
+}
+
+// Get the tags of the resource, sorted
+func (manager *Manager) Get(ctx context.Context, ref ResourceRef) ([]Tag, error) {
+	var tags []Tag
+	var err error
+	switch ref.Kind {
+	case WorkflowDefKind:
+		var result []model.TagObject
+		result, _, err = manager.tagsClient.GetWorkflowTags(ctx, ref.Name)
+		tags = FromTagObjects(result)
+	case TaskDefKind:
+		var result []model.TagObject
+		result, _, err = manager.tagsClient.GetTaskTags(ctx, ref.Name)
+		tags = FromTagObjects(result)
+	case ScheduleKind:
+		var result []model.Tag
+		result, _, err = manager.schedulerClient.GetTagsForSchedule(ctx, ref.Name)
+		tags = FromTags(result)
+	case SecretKind:
+		var result []model.Tag
+		result, _, err = manager.secretsClient.GetTags(ctx, ref.Name)
+		tags = FromTags(result)
+	case EnvVariableKind:
+		var result []model.Tag
+		result, _, err = manager.environmentClient.GetTagsForEnvVar(ctx, ref.Name)
+		tags = FromTags(result)
+	case ApplicationKind:
+		var result []model.Tag
+		result, _, err = manager.applicationClient.GetTagsForApplication(ctx, ref.Name)
+		tags = FromTags(result)
+	case PromptKind:
+		var result []model.Tag
+		result, _, err = manager.promptClient.GetTagsForPromptTemplate(ctx, ref.Name)
+		tags = FromTags(result)
+	case IntegrationProviderKind:
+		var result []model.TagObject
+		result, _, err = manager.integrationClient.GetTagsForIntegrationProvider(ctx, ref.Name)
+		tags = FromTagObjects(result)
+	case IntegrationModelKind:
+		var result []model.TagObject
+		result, _, err = manager.integrationClient.GetTagsForIntegration(ctx, ref.Provider, ref.Name)
+		tags = FromTagObjects(result)
+	case WebhookKind:
+		var result []model.Tag
+		result, _, err = manager.webhooksClient.GetTagsForWebhook(ctx, ref.Name)
+		tags = FromTags(result)
+	default:
+		return nil, unknownKind(ref)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the tags of %s: %w", ref, err)
+	}
+	return Sort(tags), nil
+}
+
+// Add adds the tags to the resource, the tags it already has are kept
+func (manager *Manager) Add(ctx context.Context, ref ResourceRef, tags ...Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	if err := manager.add(ctx, ref, tags); err != nil {
+		return fmt.Errorf("failed to add tags to %s: %w", ref, err)
+	}
+	return nil
+}
+
+// Remove removes the tags from the resource, the key and the value must both match
+func (manager *Manager) Remove(ctx context.Context, ref ResourceRef, tags ...Tag) error {
+	if len(tags) == 0 {
+		return nil
+	}
+	if err := manager.remove(ctx, ref, tags); err != nil {
+		return fmt.Errorf("failed to remove tags from %s: %w", ref, err)
+	}
+	return nil
+}
+
+// Set replaces the tags of the resource with the tags, no tags removes them all
+func (manager *Manager) Set(ctx context.Context, ref ResourceRef, tags ...Tag) error {
+	switch ref.Kind {
+	case WorkflowDefKind:
+		if _, _, err := manager.tagsClient.SetWorkflowTags(ctx, ToTagObjects(tags), ref.Name); err != nil {
+			return fmt.Errorf("failed to set the tags of %s: %w", ref, err)
+		}
+		return nil
+	case TaskDefKind:
+		if _, _, err := manager.tagsClient.SetTaskTags(ctx, ToTagObjects(tags), ref.Name); err != nil {
+			return fmt.Errorf("failed to set the tags of %s: %w", ref, err)
+		}
+		return nil
+	}
+	// the other APIs only add and remove tags
+	current, err := manager.Get(ctx, ref)
+	if err != nil {
+		return err
+	}
+	if err := manager.Remove(ctx, ref, difference(current, tags)...); err != nil {
+		return err
+	}
+	return manager.Add(ctx, ref, difference(tags, current)...)
+}
+
+func (manager *Manager) add(ctx context.Context, ref ResourceRef, tags []Tag) error {
+	var err error
+	switch ref.Kind {
+	case WorkflowDefKind:
+		// the PUT endpoint replaces the tags of the workflow
+		for _, tag := range ToTagObjects(tags) {
+			if _, _, err = manager.tagsClient.AddWorkflowTag(ctx, tag, ref.Name); err != nil {
+				return err
+			}
+		}
+	case TaskDefKind:
+		for _, tag := range ToTagObjects(tags) {
+			if _, _, err = manager.tagsClient.AddTaskTag(ctx, tag, ref.Name); err != nil {
+				return err
+			}
+		}
+	case ScheduleKind:
+		_, err = manager.schedulerClient.PutTagForSchedule(ctx, ToTags(tags), ref.Name)
+	case SecretKind:
+		_, err = manager.secretsClient.PutTagForSecret(ctx, ToTags(tags), ref.Name)
+	case EnvVariableKind:
+		_, err = manager.environmentClient.PutTagForEnvVar(ctx, ToTags(tags), ref.Name)
+	case ApplicationKind:
+		_, err = manager.applicationClient.PutTagForApplication(ctx, ToTags(tags), ref.Name)
+	case PromptKind:
+		_, err = manager.promptClient.PutTagForPromptTemplate(ctx, ToTags(tags), ref.Name)
+	case IntegrationProviderKind:
+		_, err = manager.integrationClient.UpdateTagForIntegrationProvider(ctx, ToTagObjects(tags), ref.Name)
+	case IntegrationModelKind:
+		_, err = manager.integrationClient.UpdateTagForIntegration(ctx, ToTagObjects(tags), ref.Provider, ref.Name)
+	case WebhookKind:
+		_, err = manager.webhooksClient.PutTagForWebhook(ctx, ToTags(tags), ref.Name)
+	default:
+		return unknownKind(ref)
+	}
+	return err
+}
+
+func (manager *Manager) remove(ctx context.Context, ref ResourceRef, tags []Tag) error {
+	var err error
+	switch ref.Kind {
+	case WorkflowDefKind:
+		for _, tag := range ToTagObjects(tags) {
+			if _, _, err = manager.tagsClient.DeleteWorkflowTag(ctx, tag, ref.Name); err != nil {
+				return err
+			}
+		}
+	case TaskDefKind:
+		for _, tag := range tags {
+			tagString := model.TagString{Key: tag.Key, Value: tag.Value, Type_: metadataTagType}
+			if _, _, err = manager.tagsClient.DeleteTaskTag(ctx, tagString, ref.Name); err != nil {
+				return err
+			}
+		}
+	case ScheduleKind:
+		_, err = manager.schedulerClient.DeleteTagForSchedule(ctx, ToTags(tags), ref.Name)
+	case SecretKind:
+		_, err = manager.secretsClient.DeleteTagForSecret(ctx, ToTags(tags), ref.Name)
+	case EnvVariableKind:
+		_, err = manager.environmentClient.DeleteTagForEnvVar(ctx, ToTags(tags), ref.Name)
+	case ApplicationKind:
+		_, err = manager.applicationClient.DeleteTagForApplication(ctx, ToTags(tags), ref.Name)
+	case PromptKind:
+		_, err = manager.promptClient.DeleteTagForPromptTemplate(ctx, ToTags(tags), ref.Name)
+	case IntegrationProviderKind:
+		_, err = manager.integrationClient.DeleteTagForIntegrationProvider(ctx, ToTagObjects(tags), ref.Name)
+	case IntegrationModelKind:
+		_, err = manager.integrationClient.DeleteTagForIntegration(ctx, ToTagObjects(tags), ref.Provider, ref.Name)
+	case WebhookKind:
+		_, err = manager.webhooksClient.DeleteTagForWebhook(ctx, ref.Name, ToTags(tags))
+	default:
+		return unknownKind(ref)
+	}
+	return err
+}
+
+func unknownKind(ref ResourceRef) error {
+	return fmt.Errorf("unknown resource kind %q of %s", ref.Kind, ref.Name)
+}
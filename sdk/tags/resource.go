@@ -0,0 +1,87 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package tags
+
+// Kind the kind of a tagged resource
+type Kind string
+
+const (
+	WorkflowDefKind         Kind = "WorkflowDef"
+	TaskDefKind             Kind = "TaskDef"
+	ScheduleKind            Kind = "Schedule"
+	SecretKind              Kind = "Secret"
+	EnvVariableKind         Kind = "EnvVariable"
+	ApplicationKind         Kind = "Application"
+	PromptKind              Kind = "Prompt"
+	IntegrationProviderKind Kind = "IntegrationProvider"
+	IntegrationModelKind    Kind = "IntegrationModel"
+	WebhookKind             Kind = "Webhook"
+)
+
+// Kinds every kind of tagged resource, in the order Search returns them
+var Kinds = []Kind{
+	WorkflowDefKind, TaskDefKind, ScheduleKind, SecretKind, EnvVariableKind, ApplicationKind, PromptKind,
+	IntegrationProviderKind, IntegrationModelKind, WebhookKind,
+}
+
+// ResourceRef a tagged resource.  Name is the id of the applications and of the webhooks, and the model of the
+// integration models, whose provider is Provider
+type ResourceRef struct {
+	Kind     Kind   `json:"kind"`
+	Name     string `json:"name"`
+	Provider string `json:"provider,omitempty"`
+}
+
+func WorkflowDef(name string) ResourceRef {
+	return ResourceRef{Kind: WorkflowDefKind, Name: name}
+}
+
+func TaskDef(name string) ResourceRef {
+	return ResourceRef{Kind: TaskDefKind, Name: name}
+}
+
+func Schedule(name string) ResourceRef {
+	return ResourceRef{Kind: ScheduleKind, Name: name}
+}
+
+func Secret(name string) ResourceRef {
+	return ResourceRef{Kind: SecretKind, Name: name}
+}
+
+func EnvVariable(name string) ResourceRef {
+	return ResourceRef{Kind: EnvVariableKind, Name: name}
+}
+
+func Application(id string) ResourceRef {
+	return ResourceRef{Kind: ApplicationKind, Name: id}
+}
+
+func Prompt(name string) ResourceRef {
+	return ResourceRef{Kind: PromptKind, Name: name}
+}
+
+func IntegrationProvider(name string) ResourceRef {
+	return ResourceRef{Kind: IntegrationProviderKind, Name: name}
+}
+
+func IntegrationModel(provider string, model string) ResourceRef {
+	return ResourceRef{Kind: IntegrationModelKind, Name: model, Provider: provider}
+}
+
+func Webhook(id string) ResourceRef {
+	return ResourceRef{Kind: WebhookKind, Name: id}
+}
+
+func (ref ResourceRef) String() string {
+	if ref.Kind == IntegrationModelKind {
+		return string(ref.Kind) + " " + ref.Provider + "/" + ref.Name
+	}
+	return string(ref.Kind) + " " + ref.Name
+}
@@ -0,0 +1,168 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package tags
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sort"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+)
+
+// tagged a resource and its tags as listed by the server
+type tagged struct {
+	ref  ResourceRef
+	tags []Tag
+}
+
+// Search the resources of the kinds that have the tag, of every kind when none is given.  The resources are sorted by
+// kind, in the order of Kinds, then by name.  The kinds the server does not support are skipped
+func (manager *Manager) Search(ctx context.Context, tag Tag, kinds ...Kind) ([]ResourceRef, error) {
+	if len(kinds) == 0 {
+		kinds = Kinds
+	}
+	result := []ResourceRef{}
+	for _, kind := range kinds {
+		resources, response, err := manager.list(ctx, kind)
+		if internal.IsNotFound(response, err) {
+			continue
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to list the resources of kind %s: %w", kind, err)
+		}
+		var found []ResourceRef
+		for _, resource := range resources {
+			if contains(resource.tags, tag) && !containsRef(found, resource.ref) {
+				found = append(found, resource.ref)
+			}
+		}
+		sort.Slice(found, func(i, j int) bool {
+			if found[i].Provider != found[j].Provider {
+				return found[i].Provider < found[j].Provider
+			}
+			return found[i].Name < found[j].Name
+		})
+		result = append(result, found...)
+	}
+	return result, nil
+}
+
+// list the resources of the kind with their tags
+func (manager *Manager) list(ctx context.Context, kind Kind) ([]tagged, *http.Response, error) {
+	var resources []tagged
+	switch kind {
+	case WorkflowDefKind:
+		workflowDefs, response, err := manager.metadataClient.GetAll(ctx)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, workflowDef := range workflowDefs {
+			resources = append(resources, tagged{WorkflowDef(workflowDef.Name), FromTagObjects(workflowDef.Tags)})
+		}
+	case TaskDefKind:
+		taskDefs, response, err := manager.metadataClient.GetTaskDefs(ctx)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, taskDef := range taskDefs {
+			resources = append(resources, tagged{TaskDef(taskDef.Name), FromTagObjects(taskDef.Tags)})
+		}
+	case ScheduleKind:
+		schedules, response, err := manager.schedulerClient.GetAllSchedules(ctx, nil)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, schedule := range schedules {
+			resources = append(resources, tagged{Schedule(schedule.Name), FromTags(schedule.Tags)})
+		}
+	case SecretKind:
+		secrets, response, err := manager.secretsClient.ListSecretsWithTagsThatUserCanGrantAccessTo(ctx)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, secret := range secrets {
+			resources = append(resources, tagged{Secret(secret.Name), FromTags(secret.Tags)})
+		}
+	case EnvVariableKind:
+		variables, response, err := manager.environmentClient.GetAll(ctx)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, variable := range variables {
+			resources = append(resources, tagged{EnvVariable(variable.Name), FromTags(variable.Tags)})
+		}
+	case ApplicationKind:
+		applications, response, err := manager.applicationClient.ListApplications(ctx)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, application := range applications {
+			resources = append(resources, tagged{Application(application.Id), FromTags(application.Tags)})
+		}
+	case PromptKind:
+		templates, response, err := manager.promptClient.GetMessageTemplates(ctx)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, template := range templates {
+			resources = append(resources, tagged{Prompt(template.Name), FromTagObjects(template.Tags)})
+		}
+	case IntegrationProviderKind:
+		providers, response, err := manager.integrationClient.GetIntegrationProviders(ctx, nil)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, provider := range providers {
+			resources = append(resources, tagged{IntegrationProvider(provider.Name), FromTagObjects(provider.Tags)})
+		}
+	case IntegrationModelKind:
+		providers, response, err := manager.integrationClient.GetIntegrationProviders(ctx, nil)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, provider := range providers {
+			apis, response, err := manager.integrationClient.GetIntegrationApis(ctx, provider.Name, optional.EmptyBool())
+			if err != nil {
+				return nil, response, err
+			}
+			for _, api := range apis {
+				resources = append(resources, tagged{IntegrationModel(provider.Name, api.Api), FromTagObjects(api.Tags)})
+			}
+		}
+	case WebhookKind:
+		// the webhooks are listed without their tags
+		webhooks, response, err := manager.webhooksClient.GetAllWebhook(ctx)
+		if err != nil {
+			return nil, response, err
+		}
+		for _, webhook := range webhooks {
+			webhookTags, response, err := manager.webhooksClient.GetTagsForWebhook(ctx, webhook.Id)
+			if err != nil {
+				return nil, response, err
+			}
+			resources = append(resources, tagged{Webhook(webhook.Id), FromTags(webhookTags)})
+		}
+	default:
+		return nil, nil, fmt.Errorf("unknown resource kind %q", kind)
+	}
+	return resources, nil, nil
+}
+
+func containsRef(refs []ResourceRef, ref ResourceRef) bool {
+	for _, candidate := range refs {
+		if candidate == ref {
+			return true
+		}
+	}
+	return false
+}
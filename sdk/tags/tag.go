@@ -0,0 +1,123 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package tags
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+const metadataTagType = "METADATA"
+
+// Tag a key value tag, the same for every kind of resource whichever of model.Tag, model.TagObject or
+// model.MetadataTag its API uses
+type Tag struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+func NewTag(key string, value string) Tag {
+	return Tag{Key: key, Value: value}
+}
+
+// Parse the tag of key:value, the value may contain colons
+func Parse(value string) (Tag, error) {
+	key, tagValue, found := strings.Cut(value, ":")
+	if !found || key == "" {
+		return Tag{}, fmt.Errorf("invalid tag %q, expected key:value", value)
+	}
+	return Tag{Key: key, Value: tagValue}, nil
+}
+
+func (tag Tag) String() string {
+	return tag.Key + ":" + tag.Value
+}
+
+func FromTags(tags []model.Tag) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, Tag{Key: tag.Key, Value: tag.Value})
+	}
+	return result
+}
+
+func FromTagObjects(tags []model.TagObject) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, Tag{Key: tag.Key, Value: tag.Value})
+	}
+	return result
+}
+
+func FromMetadataTags(tags []model.MetadataTag) []Tag {
+	result := make([]Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, Tag{Key: tag.Key, Value: tag.Value})
+	}
+	return result
+}
+
+func ToTags(tags []Tag) []model.Tag {
+	result := make([]model.Tag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, model.Tag{Key: tag.Key, Value: tag.Value, Type_: metadataTagType})
+	}
+	return result
+}
+
+func ToTagObjects(tags []Tag) []model.TagObject {
+	result := make([]model.TagObject, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, model.TagObject{Key: tag.Key, Value: tag.Value, Type_: metadataTagType})
+	}
+	return result
+}
+
+func ToMetadataTags(tags []Tag) []model.MetadataTag {
+	result := make([]model.MetadataTag, 0, len(tags))
+	for _, tag := range tags {
+		result = append(result, model.MetadataTag{Key: tag.Key, Value: tag.Value})
+	}
+	return result
+}
+
+// Sort sorts the tags by key then value
+func Sort(tags []Tag) []Tag {
+	sort.Slice(tags, func(i, j int) bool {
+		if tags[i].Key != tags[j].Key {
+			return tags[i].Key < tags[j].Key
+		}
+		return tags[i].Value < tags[j].Value
+	})
+	return tags
+}
+
+func contains(tags []Tag, tag Tag) bool {
+	for _, candidate := range tags {
+		if candidate == tag {
+			return true
+		}
+	}
+	return false
+}
+
+// difference the tags of a that are not in b
+func difference(a []Tag, b []Tag) []Tag {
+	result := []Tag{}
+	for _, tag := range a {
+		if !contains(b, tag) && !contains(result, tag) {
+			result = append(result, tag)
+		}
+	}
+	return result
+}
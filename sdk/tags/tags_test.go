@@ -0,0 +1,237 @@
+package tags
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/model/integration"
+	"github.com/conductor-sdk/conductor-go/sdk/model/rbac"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	teamPayments = NewTag("team", "payments")
+	teamOrders   = NewTag("team", "orders")
+	tierCritical = NewTag("tier", "critical")
+)
+
+// fakeServer the tags of the resources in memory, by the path of their tags endpoint
+type fakeServer struct {
+	mutex sync.Mutex
+	tags  map[string][]Tag
+	// the requests changing tags, METHOD path
+	calls []string
+}
+
+func newFakeServer() *fakeServer {
+	return &fakeServer{tags: map[string][]Tag{}}
+}
+
+func (server *fakeServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	server.mutex.Lock()
+	defer server.mutex.Unlock()
+	path := r.URL.Path
+	if !strings.HasSuffix(path, "/tags") {
+		server.list(w, path)
+		return
+	}
+	if r.Method == http.MethodGet {
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(append([]Tag{}, server.tags[path]...))
+		return
+	}
+	server.calls = append(server.calls, r.Method+" "+path)
+	var body []Tag
+	if r.Method == http.MethodPost || strings.HasPrefix(path, "/metadata/task/") && r.Method == http.MethodDelete ||
+		strings.HasPrefix(path, "/metadata/workflow/") && r.Method == http.MethodDelete {
+		var tag Tag
+		json.NewDecoder(r.Body).Decode(&tag)
+		body = []Tag{tag}
+	} else {
+		json.NewDecoder(r.Body).Decode(&body)
+	}
+	switch {
+	case r.Method == http.MethodDelete:
+		server.tags[path] = difference(server.tags[path], body)
+	case r.Method == http.MethodPut && (strings.HasPrefix(path, "/metadata/workflow/") || strings.HasPrefix(path, "/metadata/task/")):
+		server.tags[path] = body
+	default:
+		server.tags[path] = append(server.tags[path], difference(body, server.tags[path])...)
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+func (server *fakeServer) list(w http.ResponseWriter, path string) {
+	tagsOf := func(path string) []model.Tag { return ToTags(server.tags[path]) }
+	tagObjectsOf := func(path string) []model.TagObject { return ToTagObjects(server.tags[path]) }
+	var result interface{}
+	switch path {
+	case "/metadata/workflow":
+		result = []model.WorkflowDef{
+			{Name: "charge", Version: 1, Tags: tagObjectsOf("/metadata/workflow/charge/tags")},
+			{Name: "charge", Version: 2, Tags: tagObjectsOf("/metadata/workflow/charge/tags")},
+			{Name: "ship", Version: 1, Tags: tagObjectsOf("/metadata/workflow/ship/tags")},
+		}
+	case "/metadata/taskdefs":
+		result = []model.TaskDef{{Name: "capture", Tags: tagObjectsOf("/metadata/task/capture/tags")}}
+	case "/scheduler/schedules":
+		result = []model.WorkflowScheduleModel{{Name: "nightly", Tags: tagsOf("/scheduler/schedules/nightly/tags")}}
+	case "/secrets-v2":
+		result = []model.Secret{{Name: "stripe_key", Tags: tagsOf("/secrets/stripe_key/tags")}}
+	case "/environment":
+		result = []model.EnvironmentVariable{{Name: "region", Tags: tagsOf("/environment/region/tags")}}
+	case "/applications":
+		result = []rbac.ConductorApplication{{Id: "app-1", Name: "billing", Tags: tagsOf("/applications/app-1/tags")}}
+	case "/prompts":
+		result = []integration.PromptTemplate{{Name: "summarize", Tags: tagObjectsOf("/prompts/summarize/tags")}}
+	case "/integrations/provider":
+		result = []integration.Integration{{Name: "openai", Tags: tagObjectsOf("/integrations/provider/openai/tags")}}
+	case "/integrations/provider/openai/integration":
+		result = []integration.IntegrationApi{
+			{Api: "gpt-4o", IntegrationName: "openai", Tags: tagObjectsOf("/integrations/provider/openai/integration/gpt-4o/tags")},
+		}
+	default:
+		// the webhooks are not supported
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(http.StatusNotFound)
+		w.Write([]byte(`{"message":"not found"}`))
+		return
+	}
+	w.Header().Set("Content-Type", "application/json")
+	json.NewEncoder(w).Encode(result)
+}
+
+func newTestManager(t *testing.T) (*Manager, *fakeServer) {
+	fake := newFakeServer()
+	server := httptest.NewServer(fake)
+	t.Cleanup(server.Close)
+	return NewManager(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))), fake
+}
+
+func TestParse(t *testing.T) {
+	tag, err := Parse("url:https://example.com")
+	assert.NoError(t, err)
+	assert.Equal(t, NewTag("url", "https://example.com"), tag)
+	assert.Equal(t, "url:https://example.com", tag.String())
+
+	_, err = Parse("team")
+	assert.EqualError(t, err, `invalid tag "team", expected key:value`)
+}
+
+func TestConversions(t *testing.T) {
+	tags := []Tag{teamPayments, tierCritical}
+	assert.Equal(t, []model.Tag{
+		{Key: "team", Value: "payments", Type_: "METADATA"},
+		{Key: "tier", Value: "critical", Type_: "METADATA"},
+	}, ToTags(tags))
+	assert.Equal(t, tags, FromTags(ToTags(tags)))
+	assert.Equal(t, tags, FromTagObjects(ToTagObjects(tags)))
+	assert.Equal(t, tags, FromMetadataTags(ToMetadataTags(tags)))
+	assert.Equal(t, model.NewTagObject(ToMetadataTags(tags)[0]), ToTagObjects(tags)[0])
+}
+
+func TestManager(t *testing.T) {
+	refs := []ResourceRef{
+		WorkflowDef("charge"),
+		TaskDef("capture"),
+		Schedule("nightly"),
+		Secret("stripe_key"),
+		EnvVariable("region"),
+		Application("app-1"),
+		Prompt("summarize"),
+		IntegrationProvider("openai"),
+		IntegrationModel("openai", "gpt-4o"),
+		Webhook("hook-1"),
+	}
+	for _, ref := range refs {
+		t.Run(string(ref.Kind), func(t *testing.T) {
+			manager, _ := newTestManager(t)
+			ctx := context.Background()
+
+			assert.NoError(t, manager.Add(ctx, ref, tierCritical, teamPayments))
+			tags, err := manager.Get(ctx, ref)
+			assert.NoError(t, err)
+			assert.Equal(t, []Tag{teamPayments, tierCritical}, tags)
+
+			assert.NoError(t, manager.Remove(ctx, ref, tierCritical))
+			tags, err = manager.Get(ctx, ref)
+			assert.NoError(t, err)
+			assert.Equal(t, []Tag{teamPayments}, tags)
+
+			assert.NoError(t, manager.Set(ctx, ref, teamOrders, tierCritical))
+			tags, err = manager.Get(ctx, ref)
+			assert.NoError(t, err)
+			assert.Equal(t, []Tag{teamOrders, tierCritical}, tags)
+
+			assert.NoError(t, manager.Set(ctx, ref))
+			tags, err = manager.Get(ctx, ref)
+			assert.NoError(t, err)
+			assert.Empty(t, tags)
+		})
+	}
+}
+
+func TestEndpoints(t *testing.T) {
+	manager, fake := newTestManager(t)
+	ctx := context.Background()
+
+	assert.NoError(t, manager.Add(ctx, IntegrationModel("openai", "gpt-4o"), teamPayments))
+	assert.NoError(t, manager.Set(ctx, WorkflowDef("charge"), teamPayments))
+	assert.NoError(t, manager.Set(ctx, Secret("stripe_key"), teamOrders))
+	assert.NoError(t, manager.Set(ctx, Secret("stripe_key"), teamPayments))
+	// no change
+	assert.NoError(t, manager.Set(ctx, Secret("stripe_key"), teamPayments))
+	assert.NoError(t, manager.Remove(ctx, Schedule("nightly")))
+	assert.Equal(t, []string{
+		"PUT /integrations/provider/openai/integration/gpt-4o/tags",
+		"PUT /metadata/workflow/charge/tags",
+		"PUT /secrets/stripe_key/tags",
+		"DELETE /secrets/stripe_key/tags",
+		"PUT /secrets/stripe_key/tags",
+	}, fake.calls)
+
+	err := manager.Add(ctx, ResourceRef{Kind: "Queue", Name: "orders"}, teamPayments)
+	assert.EqualError(t, err, `failed to add tags to Queue orders: unknown resource kind "Queue" of orders`)
+}
+
+func TestSearch(t *testing.T) {
+	manager, _ := newTestManager(t)
+	ctx := context.Background()
+	for _, ref := range []ResourceRef{
+		WorkflowDef("ship"),
+		WorkflowDef("charge"),
+		TaskDef("capture"),
+		Secret("stripe_key"),
+		Application("app-1"),
+		Prompt("summarize"),
+		IntegrationModel("openai", "gpt-4o"),
+	} {
+		assert.NoError(t, manager.Add(ctx, ref, teamPayments))
+	}
+	assert.NoError(t, manager.Add(ctx, EnvVariable("region"), teamOrders))
+
+	refs, err := manager.Search(ctx, teamPayments)
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceRef{
+		WorkflowDef("charge"),
+		WorkflowDef("ship"),
+		TaskDef("capture"),
+		Secret("stripe_key"),
+		Application("app-1"),
+		Prompt("summarize"),
+		IntegrationModel("openai", "gpt-4o"),
+	}, refs)
+
+	refs, err = manager.Search(ctx, teamOrders, EnvVariableKind, SecretKind)
+	assert.NoError(t, err)
+	assert.Equal(t, []ResourceRef{EnvVariable("region")}, refs)
+	assert.Equal(t, "IntegrationModel openai/gpt-4o", IntegrationModel("openai", "gpt-4o").String())
+}
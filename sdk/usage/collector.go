@@ -0,0 +1,192 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"sync"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+const (
+	promptTokenType     = "prompt"
+	completionTokenType = "completion"
+)
+
+// Usage the tokens used by a LLM call, or by several calls of the same model
+type Usage struct {
+	Integration  string `json:"integration"`
+	Model        string `json:"model"`
+	WorkflowType string `json:"workflowType,omitempty"`
+	TaskType     string `json:"taskType,omitempty"`
+
+	PromptTokens     int64 `json:"promptTokens,omitempty"`
+	CompletionTokens int64 `json:"completionTokens,omitempty"`
+	// TotalTokens optional, set when the split between prompt and completion is not known
+	TotalTokens int64 `json:"totalTokens,omitempty"`
+}
+
+// Tokens all the tokens of the usage
+func (usage Usage) Tokens() int64 {
+	return usage.PromptTokens + usage.CompletionTokens + usage.unsplitTokens()
+}
+
+func (usage Usage) unsplitTokens() int64 {
+	if split := usage.PromptTokens + usage.CompletionTokens; usage.TotalTokens > split {
+		return usage.TotalTokens - split
+	}
+	return 0
+}
+
+type usageKey struct {
+	integration  string
+	model        string
+	workflowType string
+}
+
+// Collector aggregates the token usage by integration, model and workflow type and prices it with the price table.
+// When the metrics are provided, see metrics.ProvideMetrics, the tokens and the costs are exported as the
+// llm_tokens_used and llm_cost counters
+type Collector struct {
+	prices            PriceTable
+	integrationClient client.IntegrationClient
+
+	mutex  sync.Mutex
+	totals map[usageKey]*Totals
+}
+
+func NewCollector(prices PriceTable) *Collector {
+	if prices == nil {
+		prices = PriceTable{}
+	}
+	return &Collector{prices: prices, totals: map[usageKey]*Totals{}}
+}
+
+// WithIntegrationClient registers the usage recorded with Record on the server as well, so that it is part of the
+// token usage of the integration returned by GetTokenUsageForIntegration
+func (collector *Collector) WithIntegrationClient(integrationClient client.IntegrationClient) *Collector {
+	collector.integrationClient = integrationClient
+	return collector
+}
+
+// Record adds the usage of a LLM call made by the application, e.g. by a worker calling the LLM directly
+func (collector *Collector) Record(ctx context.Context, usage Usage) error {
+	if usage.Integration == "" || usage.Model == "" {
+		return errors.New("the integration and the model of the usage are required")
+	}
+	collector.add(usage)
+	if collector.integrationClient == nil || usage.Tokens() == 0 {
+		return nil
+	}
+	tokens := usage.Tokens()
+	if tokens > math.MaxInt32 {
+		tokens = math.MaxInt32
+	}
+	if _, err := collector.integrationClient.RegisterTokenUsage(ctx, int32(tokens), usage.Integration, usage.Model); err != nil {
+		return fmt.Errorf("failed to register the token usage of %s:%s: %w", usage.Integration, usage.Model, err)
+	}
+	return nil
+}
+
+// RecordWorkflow adds the usage of the LLM tasks of the workflow, read from the input and the output of the tasks.
+// The server already accounts for the usage of its LLM tasks, it is not registered again
+func (collector *Collector) RecordWorkflow(workflow *model.Workflow) {
+	for _, task := range workflow.Tasks {
+		usage := Usage{
+			Integration:      stringValue(task.InputData["llmProvider"]),
+			Model:            stringValue(task.InputData["model"]),
+			WorkflowType:     workflow.WorkflowName,
+			TaskType:         task.TaskType,
+			PromptTokens:     intValue(task.OutputData["promptTokens"]),
+			CompletionTokens: intValue(task.OutputData["completionTokens"]),
+			TotalTokens:      intValue(task.OutputData["tokenUsed"]),
+		}
+		if usage.Integration != "" && usage.Model != "" && usage.Tokens() > 0 {
+			collector.add(usage)
+		}
+	}
+}
+
+func (collector *Collector) add(usage Usage) {
+	cost, priced := collector.prices.Cost(usage)
+	key := usageKey{integration: usage.Integration, model: usage.Model, workflowType: usage.WorkflowType}
+	collector.mutex.Lock()
+	totals, found := collector.totals[key]
+	if !found {
+		totals = &Totals{}
+		collector.totals[key] = totals
+	}
+	totals.add(Totals{
+		Calls:            1,
+		PromptTokens:     usage.PromptTokens,
+		CompletionTokens: usage.CompletionTokens,
+		Tokens:           usage.Tokens(),
+		Cost:             cost,
+		unpriced:         !priced,
+	})
+	collector.mutex.Unlock()
+
+	metrics.AddLlmTokensUsed(usage.Integration, usage.Model, usage.WorkflowType, promptTokenType, float64(usage.PromptTokens+usage.unsplitTokens()))
+	metrics.AddLlmTokensUsed(usage.Integration, usage.Model, usage.WorkflowType, completionTokenType, float64(usage.CompletionTokens))
+	metrics.AddLlmCost(usage.Integration, usage.Model, usage.WorkflowType, cost)
+}
+
+// Reset drops the usage collected so far, e.g. after a report is published.  The metrics are not reset
+func (collector *Collector) Reset() {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	collector.totals = map[usageKey]*Totals{}
+}
+
+// ProviderUsage the token usage of the models of the integration provider as counted by the server
+func ProviderUsage(ctx context.Context, integrationClient client.IntegrationClient, provider string) (map[string]int64, error) {
+	counts, _, err := integrationClient.GetTokenUsageForIntegrationProvider(ctx, provider)
+	if err != nil {
+		return nil, err
+	}
+	usage := make(map[string]int64, len(counts))
+	for model, count := range counts {
+		tokens, err := strconv.ParseInt(count, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid token usage %q for model %s of %s", count, model, provider)
+		}
+		usage[model] = tokens
+	}
+	return usage, nil
+}
+
+func stringValue(value interface{}) string {
+	text, _ := value.(string)
+	return text
+}
+
+func intValue(value interface{}) int64 {
+	switch value := value.(type) {
+	case float64:
+		return int64(value)
+	case int:
+		return int64(value)
+	case int32:
+		return int64(value)
+	case int64:
+		return value
+	case string:
+		parsed, _ := strconv.ParseInt(value, 10, 64)
+		return parsed
+	}
+	return 0
+}
@@ -0,0 +1,62 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package usage
+
+import (
+	"encoding/json"
+	"os"
+)
+
+const tokensPerPrice = 1_000_000
+
+// Price the price of a million tokens of a model
+type Price struct {
+	Prompt     float64 `json:"prompt"`
+	Completion float64 `json:"completion"`
+}
+
+// PriceTable the prices by model, the keys are integration:model, e.g. openai:gpt-4o, or integration:* for the
+// models of the integration without a price of their own
+type PriceTable map[string]Price
+
+// LoadPriceTable reads a price table from a JSON file:
+//
+//	{"openai:gpt-4o": {"prompt": 2.5, "completion": 10}, "openai:*": {"prompt": 1, "completion": 2}}
+func LoadPriceTable(path string) (PriceTable, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	prices := PriceTable{}
+	if err := json.Unmarshal(data, &prices); err != nil {
+		return nil, err
+	}
+	return prices, nil
+}
+
+// Price the price of the model, false when the table has none
+func (prices PriceTable) Price(integration string, model string) (Price, bool) {
+	if price, found := prices[integration+":"+model]; found {
+		return price, true
+	}
+	price, found := prices[integration+":*"]
+	return price, found
+}
+
+// Cost the cost of the usage, false when the model has no price.  The tokens reported without the split between
+// prompt and completion are priced as prompt tokens
+func (prices PriceTable) Cost(usage Usage) (float64, bool) {
+	price, found := prices.Price(usage.Integration, usage.Model)
+	if !found {
+		return 0, false
+	}
+	promptTokens := usage.PromptTokens + usage.unsplitTokens()
+	return (float64(promptTokens)*price.Prompt + float64(usage.CompletionTokens)*price.Completion) / tokensPerPrice, true
+}
@@ -0,0 +1,133 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package usage
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// Totals the usage of a group of LLM calls
+type Totals struct {
+	Calls            int64   `json:"calls"`
+	PromptTokens     int64   `json:"promptTokens"`
+	CompletionTokens int64   `json:"completionTokens"`
+	Tokens           int64   `json:"tokens"`
+	Cost             float64 `json:"cost"`
+	// some of the calls use models without a price, the cost is partial
+	unpriced bool
+}
+
+func (totals *Totals) add(other Totals) {
+	totals.Calls += other.Calls
+	totals.PromptTokens += other.PromptTokens
+	totals.CompletionTokens += other.CompletionTokens
+	totals.Tokens += other.Tokens
+	totals.Cost += other.Cost
+	totals.unpriced = totals.unpriced || other.unpriced
+}
+
+func (totals Totals) String() string {
+	cost := fmt.Sprintf("%.4f", totals.Cost)
+	if totals.unpriced {
+		cost += " (partial)"
+	}
+	return fmt.Sprintf("%d calls, %d tokens (%d prompt, %d completion), cost %s",
+		totals.Calls, totals.Tokens, totals.PromptTokens, totals.CompletionTokens, cost)
+}
+
+type ModelUsage struct {
+	Integration string `json:"integration"`
+	Model       string `json:"model"`
+	Totals
+}
+
+type WorkflowTypeUsage struct {
+	// WorkflowType empty for the usage recorded outside of a workflow
+	WorkflowType string `json:"workflowType"`
+	Totals
+	Models []ModelUsage `json:"models"`
+}
+
+// Report the usage collected grouped by workflow type, the most expensive first
+type Report struct {
+	WorkflowTypes []WorkflowTypeUsage `json:"workflowTypes"`
+	Total         Totals              `json:"total"`
+	// UnpricedModels the models used without a price in the price table, as integration:model
+	UnpricedModels []string `json:"unpricedModels"`
+}
+
+// Report the usage collected so far grouped by workflow type, then by model
+func (collector *Collector) Report() *Report {
+	collector.mutex.Lock()
+	defer collector.mutex.Unlock()
+	byWorkflowType := map[string]*WorkflowTypeUsage{}
+	unpriced := map[string]bool{}
+	report := &Report{WorkflowTypes: []WorkflowTypeUsage{}, UnpricedModels: []string{}}
+	for key, totals := range collector.totals {
+		workflowTypeUsage, found := byWorkflowType[key.workflowType]
+		if !found {
+			workflowTypeUsage = &WorkflowTypeUsage{WorkflowType: key.workflowType, Models: []ModelUsage{}}
+			byWorkflowType[key.workflowType] = workflowTypeUsage
+		}
+		workflowTypeUsage.Models = append(workflowTypeUsage.Models, ModelUsage{Integration: key.integration, Model: key.model, Totals: *totals})
+		workflowTypeUsage.Totals.add(*totals)
+		report.Total.add(*totals)
+		if totals.unpriced {
+			unpriced[key.integration+":"+key.model] = true
+		}
+	}
+	for _, workflowTypeUsage := range byWorkflowType {
+		sort.Slice(workflowTypeUsage.Models, func(i, j int) bool {
+			return byCost(workflowTypeUsage.Models[i].Totals, workflowTypeUsage.Models[i].Integration+":"+workflowTypeUsage.Models[i].Model,
+				workflowTypeUsage.Models[j].Totals, workflowTypeUsage.Models[j].Integration+":"+workflowTypeUsage.Models[j].Model)
+		})
+		report.WorkflowTypes = append(report.WorkflowTypes, *workflowTypeUsage)
+	}
+	sort.Slice(report.WorkflowTypes, func(i, j int) bool {
+		return byCost(report.WorkflowTypes[i].Totals, report.WorkflowTypes[i].WorkflowType, report.WorkflowTypes[j].Totals, report.WorkflowTypes[j].WorkflowType)
+	})
+	for model := range unpriced {
+		report.UnpricedModels = append(report.UnpricedModels, model)
+	}
+	sort.Strings(report.UnpricedModels)
+	return report
+}
+
+// byCost the most expensive first, then the most tokens, then by name
+func byCost(a Totals, aName string, b Totals, bName string) bool {
+	if a.Cost != b.Cost {
+		return a.Cost > b.Cost
+	}
+	if a.Tokens != b.Tokens {
+		return a.Tokens > b.Tokens
+	}
+	return aName < bName
+}
+
+func (report *Report) String() string {
+	var builder strings.Builder
+	for _, workflowTypeUsage := range report.WorkflowTypes {
+		name := workflowTypeUsage.WorkflowType
+		if name == "" {
+			name = "(no workflow)"
+		}
+		builder.WriteString(fmt.Sprintf("%s: %s\n", name, workflowTypeUsage.Totals))
+		for _, modelUsage := range workflowTypeUsage.Models {
+			builder.WriteString(fmt.Sprintf("  %s:%s: %s\n", modelUsage.Integration, modelUsage.Model, modelUsage.Totals))
+		}
+	}
+	builder.WriteString(fmt.Sprintf("total: %s\n", report.Total))
+	if len(report.UnpricedModels) > 0 {
+		builder.WriteString(fmt.Sprintf("no price for %s\n", strings.Join(report.UnpricedModels, ", ")))
+	}
+	return builder.String()
+}
@@ -0,0 +1,140 @@
+package usage
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+var prices = PriceTable{
+	"openai:gpt-4o":      {Prompt: 2.5, Completion: 10},
+	"openai:*":           {Prompt: 1, Completion: 2},
+	"anthropic:claude-3": {Prompt: 3, Completion: 15},
+}
+
+func TestPriceTable(t *testing.T) {
+	cost, priced := prices.Cost(Usage{Integration: "openai", Model: "gpt-4o", PromptTokens: 1000, CompletionTokens: 500})
+	assert.True(t, priced)
+	assert.InDelta(t, 0.0075, cost, 1e-9)
+
+	cost, priced = prices.Cost(Usage{Integration: "openai", Model: "gpt-4o-mini", PromptTokens: 1_000_000, CompletionTokens: 1_000_000})
+	assert.True(t, priced)
+	assert.InDelta(t, 3, cost, 1e-9)
+
+	// without the split the tokens are priced as prompt tokens
+	cost, _ = prices.Cost(Usage{Integration: "anthropic", Model: "claude-3", CompletionTokens: 1000, TotalTokens: 3000})
+	assert.InDelta(t, 0.021, cost, 1e-9)
+
+	_, priced = prices.Cost(Usage{Integration: "mistral", Model: "large"})
+	assert.False(t, priced)
+
+	path := filepath.Join(t.TempDir(), "prices.json")
+	assert.NoError(t, os.WriteFile(path, []byte(`{"openai:gpt-4o": {"prompt": 2.5, "completion": 10}}`), 0644))
+	loaded, err := LoadPriceTable(path)
+	assert.NoError(t, err)
+	assert.Equal(t, PriceTable{"openai:gpt-4o": {Prompt: 2.5, Completion: 10}}, loaded)
+}
+
+func TestReport(t *testing.T) {
+	collector := NewCollector(prices)
+	ctx := context.Background()
+	assert.NoError(t, collector.Record(ctx, Usage{Integration: "openai", Model: "gpt-4o", WorkflowType: "support", PromptTokens: 1000, CompletionTokens: 500}))
+	assert.NoError(t, collector.Record(ctx, Usage{Integration: "openai", Model: "gpt-4o", WorkflowType: "support", PromptTokens: 1000, CompletionTokens: 500}))
+	assert.NoError(t, collector.Record(ctx, Usage{Integration: "mistral", Model: "large", WorkflowType: "support", PromptTokens: 100}))
+	assert.NoError(t, collector.Record(ctx, Usage{Integration: "anthropic", Model: "claude-3", PromptTokens: 1000}))
+	assert.EqualError(t, collector.Record(ctx, Usage{Model: "gpt-4o"}), "the integration and the model of the usage are required")
+
+	collector.RecordWorkflow(&model.Workflow{
+		WorkflowName: "triage",
+		Tasks: []model.Task{
+			{
+				TaskType:   "LLM_TEXT_COMPLETE",
+				InputData:  map[string]interface{}{"llmProvider": "openai", "model": "gpt-4o", "promptName": "classify"},
+				OutputData: map[string]interface{}{"result": "bug", "tokenUsed": float64(2000)},
+			},
+			{TaskType: "SIMPLE", OutputData: map[string]interface{}{"tokenUsed": float64(10)}},
+		},
+	})
+
+	report := collector.Report()
+	assert.Equal(t, []string{"mistral:large"}, report.UnpricedModels)
+	assert.Len(t, report.WorkflowTypes, 3)
+	assert.Equal(t, "support", report.WorkflowTypes[0].WorkflowType)
+	assert.Equal(t, int64(3), report.WorkflowTypes[0].Calls)
+	assert.Equal(t, int64(3100), report.WorkflowTypes[0].Tokens)
+	assert.InDelta(t, 0.015, report.WorkflowTypes[0].Cost, 1e-9)
+	assert.Equal(t, "gpt-4o", report.WorkflowTypes[0].Models[0].Model)
+	assert.Equal(t, "triage", report.WorkflowTypes[1].WorkflowType)
+	assert.Equal(t, "", report.WorkflowTypes[2].WorkflowType)
+	assert.Equal(t, int64(5), report.Total.Calls)
+	assert.Equal(t, `support: 3 calls, 3100 tokens (2100 prompt, 1000 completion), cost 0.0150 (partial)
+  openai:gpt-4o: 2 calls, 3000 tokens (2000 prompt, 1000 completion), cost 0.0150
+  mistral:large: 1 calls, 100 tokens (100 prompt, 0 completion), cost 0.0000 (partial)
+triage: 1 calls, 2000 tokens (0 prompt, 0 completion), cost 0.0050
+  openai:gpt-4o: 1 calls, 2000 tokens (0 prompt, 0 completion), cost 0.0050
+(no workflow): 1 calls, 1000 tokens (1000 prompt, 0 completion), cost 0.0030
+  anthropic:claude-3: 1 calls, 1000 tokens (1000 prompt, 0 completion), cost 0.0030
+total: 5 calls, 6100 tokens (3100 prompt, 1000 completion), cost 0.0230 (partial)
+no price for mistral:large
+`, report.String())
+
+	data, err := json.Marshal(report.WorkflowTypes[1])
+	assert.NoError(t, err)
+	assert.JSONEq(t, `{"workflowType": "triage", "calls": 1, "promptTokens": 0, "completionTokens": 0, "tokens": 2000, "cost": 0.005,
+		"models": [{"integration": "openai", "model": "gpt-4o", "calls": 1, "promptTokens": 0, "completionTokens": 0, "tokens": 2000, "cost": 0.005}]}`, string(data))
+
+	collector.Reset()
+	assert.Empty(t, collector.Report().WorkflowTypes)
+}
+
+func TestWorker(t *testing.T) {
+	var mutex sync.Mutex
+	var registered []string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+		if r.Method == http.MethodGet {
+			w.Write([]byte(`{"gpt-4o": "1500", "gpt-4o-mini": "20"}`))
+			return
+		}
+		body, _ := io.ReadAll(r.Body)
+		mutex.Lock()
+		registered = append(registered, r.URL.Path+" "+strings.TrimSpace(string(body)))
+		mutex.Unlock()
+		w.Write([]byte("{}"))
+	}))
+	defer server.Close()
+	integrationClient := client.NewIntegrationClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+	collector := NewCollector(prices).WithIntegrationClient(integrationClient)
+
+	worker := collector.Worker(func(task *model.Task, meter *Meter) (interface{}, error) {
+		meter.Add("openai", "gpt-4o", 1000, 500)
+		meter.Add("openai", "gpt-4o", 200, 100)
+		return nil, errors.New("the answer is not valid JSON")
+	})
+	_, err := worker(&model.Task{TaskId: "t1", TaskDefName: "summarize", WorkflowType: "support"})
+	assert.EqualError(t, err, "the answer is not valid JSON")
+	assert.Equal(t, []string{
+		"/integrations/provider/openai/integration/gpt-4o/metrics 1500",
+		"/integrations/provider/openai/integration/gpt-4o/metrics 300",
+	}, registered)
+	report := collector.Report()
+	assert.Equal(t, "support", report.WorkflowTypes[0].WorkflowType)
+	assert.Equal(t, int64(1800), report.Total.Tokens)
+
+	usage, err := ProviderUsage(context.Background(), integrationClient, "openai")
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]int64{"gpt-4o": 1500, "gpt-4o-mini": 20}, usage)
+}
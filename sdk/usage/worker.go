@@ -0,0 +1,63 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package usage
+
+import (
+	"context"
+	"sync"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	log "github.com/sirupsen/logrus"
+)
+
+// Meter collects the usage of the LLM calls made while a task executes, it is safe for concurrent use
+type Meter struct {
+	mutex  sync.Mutex
+	usages []Usage
+}
+
+// Add the tokens used by a call of the model of the integration
+func (meter *Meter) Add(integration string, model string, promptTokens int64, completionTokens int64) {
+	meter.mutex.Lock()
+	defer meter.mutex.Unlock()
+	meter.usages = append(meter.usages, Usage{
+		Integration:      integration,
+		Model:            model,
+		PromptTokens:     promptTokens,
+		CompletionTokens: completionTokens,
+	})
+}
+
+// Worker adapts a worker calling LLMs directly to a task function: the usage added to the meter is recorded with the
+// workflow type and the task type of the task once the execution completes, whether it succeeds or fails.
+// Failures to record the usage are logged and do not fail the task
+//
+//	taskRunner.StartWorker("summarize", collector.Worker(func(task *model.Task, meter *usage.Meter) (interface{}, error) {
+//	    response, err := llm.Complete(...)
+//	    meter.Add("openai", "gpt-4o", response.Usage.PromptTokens, response.Usage.CompletionTokens)
+//	    return response.Text, err
+//	}), 1, time.Second)
+func (collector *Collector) Worker(execute func(task *model.Task, meter *Meter) (interface{}, error)) model.ExecuteTaskFunction {
+	return func(task *model.Task) (interface{}, error) {
+		meter := &Meter{}
+		output, err := execute(task, meter)
+		meter.mutex.Lock()
+		usages := meter.usages
+		meter.mutex.Unlock()
+		for _, usage := range usages {
+			usage.WorkflowType = task.WorkflowType
+			usage.TaskType = task.TaskDefName
+			if recordErr := collector.Record(context.Background(), usage); recordErr != nil {
+				log.Warning("Failed to record the token usage of task ", task.TaskId, ", error: ", recordErr)
+			}
+		}
+		return output, err
+	}
+}
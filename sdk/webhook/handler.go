@@ -0,0 +1,292 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package webhook
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	log "github.com/sirupsen/logrus"
+)
+
+const (
+	defaultTolerance    = 5 * time.Minute
+	defaultReplayWindow = 24 * time.Hour
+	defaultReplaySize   = 10000
+	defaultMaxBodyBytes = 1 << 20
+)
+
+// Executor the operations used by the handler, implemented by executor.WorkflowExecutor
+type Executor interface {
+	StartWorkflowWithContext(ctx context.Context, startWorkflowRequest *model.StartWorkflowRequest) (workflowId string, err error)
+	UpdateTaskByRefNameWithContext(ctx context.Context, taskRefName string, workflowInstanceId string, status model.TaskResultStatus, output interface{}) error
+}
+
+// Event a verified webhook request
+type Event struct {
+	WebhookId string
+	// DeliveryId identifies the delivery, empty when the source does not provide one
+	DeliveryId string
+	Headers    http.Header
+	// Payload the JSON or form encoded body as a map, the bodies that are not JSON objects are under the payload key
+	Payload map[string]interface{}
+}
+
+// Result of processing an event, sent as the JSON response
+type Result struct {
+	WorkflowIds         []string `json:"workflowIds,omitempty"`
+	SignaledWorkflowIds []string `json:"signaledWorkflowIds,omitempty"`
+	Duplicate           bool     `json:"duplicate,omitempty"`
+}
+
+type signal struct {
+	taskRefName     string
+	workflowIdField string
+	status          model.TaskResultStatus
+}
+
+// Handler receives the webhooks of a model.WebhookConfig: it verifies the requests according to the verifier and the
+// source platform of the config, rejects the replayed deliveries, then starts the workflowsToStart of the config and
+// signals the waiting workflows.  The URL verification challenges of Slack verified requests are answered
+type Handler struct {
+	config       model.WebhookConfig
+	verify       verifier
+	executor     Executor
+	tolerance    time.Duration
+	maxBodyBytes int64
+	replays      *replayCache
+	router       func(event *Event) []string
+	signals      []signal
+	now          func() time.Time
+}
+
+func NewHandler(config model.WebhookConfig, executor Executor) (*Handler, error) {
+	verify, err := newVerifier(config)
+	if err != nil {
+		return nil, err
+	}
+	return &Handler{
+		config:       config,
+		verify:       verify,
+		executor:     executor,
+		tolerance:    defaultTolerance,
+		maxBodyBytes: defaultMaxBodyBytes,
+		replays:      newReplayCache(defaultReplayWindow, defaultReplaySize),
+		now:          time.Now,
+	}, nil
+}
+
+// WithTolerance of the timestamps signed by the source (Slack, Stripe), older requests are rejected as replayed
+func (h *Handler) WithTolerance(tolerance time.Duration) *Handler {
+	h.tolerance = tolerance
+	return h
+}
+
+// WithReplayWindow during which the deliveries already processed are acknowledged without being processed again
+func (h *Handler) WithReplayWindow(window time.Duration) *Handler {
+	h.replays = newReplayCache(window, defaultReplaySize)
+	return h
+}
+
+// WithMaxBodyBytes larger requests are rejected
+func (h *Handler) WithMaxBodyBytes(maxBodyBytes int64) *Handler {
+	h.maxBodyBytes = maxBodyBytes
+	return h
+}
+
+// RouteBy selects the workflows to start among the workflowsToStart of the config, by default all of them are started
+func (h *Handler) RouteBy(router func(event *Event) []string) *Handler {
+	h.router = router
+	return h
+}
+
+// SignalTask updates the task of the workflow whose id is in the workflowIdField of the payload, using the payload
+// as the output.  Events without the field do not signal any workflow
+func (h *Handler) SignalTask(taskRefName string, workflowIdField string, status model.TaskResultStatus) *Handler {
+	h.signals = append(h.signals, signal{taskRefName: taskRefName, workflowIdField: workflowIdField, status: status})
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, "method not allowed", http.StatusMethodNotAllowed)
+		return
+	}
+	body, err := io.ReadAll(io.LimitReader(r.Body, h.maxBodyBytes+1))
+	if err != nil {
+		http.Error(w, "failed to read the request", http.StatusBadRequest)
+		return
+	}
+	if int64(len(body)) > h.maxBodyBytes {
+		http.Error(w, "request too large", http.StatusRequestEntityTooLarge)
+		return
+	}
+	verification, err := h.verify(r, body, h.now(), h.tolerance)
+	if err != nil {
+		log.Warning(fmt.Sprintf("Rejected request to webhook %s: %s", h.config.Name, err.Error()))
+		http.Error(w, "unauthorized", http.StatusUnauthorized)
+		return
+	}
+	payload, err := normalize(r.Header.Get("Content-Type"), body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	if verification.answersChallenge && payload["type"] == "url_verification" {
+		writeJson(w, map[string]interface{}{"challenge": payload["challenge"]})
+		return
+	}
+	event := &Event{
+		WebhookId:  h.config.Id,
+		DeliveryId: deliveryId(verification, payload),
+		Headers:    r.Header,
+		Payload:    payload,
+	}
+	if event.DeliveryId != "" {
+		switch h.replays.reserve(event.DeliveryId, h.now()) {
+		case delivered:
+			writeJson(w, &Result{Duplicate: true})
+			return
+		case inFlight:
+			// the source retries the delivery, which is lost if the request in flight fails
+			http.Error(w, "delivery in progress", http.StatusConflict)
+			return
+		}
+	}
+	result, err := h.Process(r.Context(), event)
+	if err != nil {
+		if event.DeliveryId != "" {
+			h.replays.release(event.DeliveryId)
+		}
+		log.Warning(fmt.Sprintf("Failed to process the request to webhook %s: %s", h.config.Name, err.Error()))
+		http.Error(w, "failed to process the request", http.StatusInternalServerError)
+		return
+	}
+	if event.DeliveryId != "" {
+		h.replays.complete(event.DeliveryId, h.now())
+	}
+	writeJson(w, result)
+}
+
+// Process starts and signals the workflows for a verified event.  The workflows are started with the delivery id as
+// the idempotency key so that a delivery retried after a failure does not start the workflows twice
+func (h *Handler) Process(ctx context.Context, event *Event) (*Result, error) {
+	result := &Result{}
+	for _, workflowName := range h.workflowsToStart(event) {
+		request := &model.StartWorkflowRequest{
+			Name:    workflowName,
+			Version: h.config.WorkflowsToStart[workflowName],
+			Input:   event.Payload,
+		}
+		if event.DeliveryId != "" {
+			request.IdempotencyKey = event.DeliveryId + ":" + workflowName
+			request.IdempotencyStrategy = model.ReturnExisting
+		}
+		workflowId, err := h.executor.StartWorkflowWithContext(ctx, request)
+		if err != nil {
+			return result, fmt.Errorf("failed to start workflow %s: %w", workflowName, err)
+		}
+		result.WorkflowIds = append(result.WorkflowIds, workflowId)
+	}
+	for _, signal := range h.signals {
+		workflowId, ok := event.Payload[signal.workflowIdField].(string)
+		if !ok || workflowId == "" {
+			continue
+		}
+		err := h.executor.UpdateTaskByRefNameWithContext(ctx, signal.taskRefName, workflowId, signal.status, event.Payload)
+		if err != nil {
+			return result, fmt.Errorf("failed to update task %s of workflow %s: %w", signal.taskRefName, workflowId, err)
+		}
+		result.SignaledWorkflowIds = append(result.SignaledWorkflowIds, workflowId)
+	}
+	return result, nil
+}
+
+func (h *Handler) workflowsToStart(event *Event) []string {
+	if h.router == nil {
+		names := make([]string, 0, len(h.config.WorkflowsToStart))
+		for name := range h.config.WorkflowsToStart {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		return names
+	}
+	names := make([]string, 0)
+	for _, name := range h.router(event) {
+		if _, ok := h.config.WorkflowsToStart[name]; !ok {
+			log.Warning(fmt.Sprintf("Workflow %s is not one of the workflows to start of webhook %s", name, h.config.Name))
+			continue
+		}
+		names = append(names, name)
+	}
+	return names
+}
+
+func deliveryId(verification *verification, payload map[string]interface{}) string {
+	if verification.eventIdField != "" {
+		if eventId, ok := payload[verification.eventIdField].(string); ok && eventId != "" {
+			return eventId
+		}
+	}
+	return verification.replayKey
+}
+
+// normalize decodes the JSON or form encoded body.  Form fields with a single value are strings, and a single payload
+// field holding JSON (e.g. Slack interactions) is decoded
+func normalize(contentType string, body []byte) (map[string]interface{}, error) {
+	if len(strings.TrimSpace(string(body))) == 0 {
+		return map[string]interface{}{}, nil
+	}
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType == "application/x-www-form-urlencoded" {
+		values, err := url.ParseQuery(string(body))
+		if err != nil {
+			return nil, errors.New("invalid form body")
+		}
+		if len(values) == 1 && len(values["payload"]) == 1 {
+			return normalize("application/json", []byte(values.Get("payload")))
+		}
+		payload := make(map[string]interface{}, len(values))
+		for key, value := range values {
+			if len(value) == 1 {
+				payload[key] = value[0]
+			} else {
+				payload[key] = value
+			}
+		}
+		return payload, nil
+	}
+	var decoded interface{}
+	if err := json.Unmarshal(body, &decoded); err != nil {
+		return nil, errors.New("body is not valid JSON")
+	}
+	if payload, ok := decoded.(map[string]interface{}); ok {
+		return payload, nil
+	}
+	return map[string]interface{}{"payload": decoded}, nil
+}
+
+func writeJson(w http.ResponseWriter, value interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(w).Encode(value); err != nil {
+		log.Warning("Failed to write the response: ", err.Error())
+	}
+}
@@ -0,0 +1,258 @@
+package webhook
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/stretchr/testify/assert"
+)
+
+const secret = "webhook_secret"
+
+var now = time.Unix(1700000000, 0)
+
+type fakeExecutor struct {
+	err     error
+	started []*model.StartWorkflowRequest
+	updated map[string]interface{}
+}
+
+func (e *fakeExecutor) StartWorkflowWithContext(ctx context.Context, request *model.StartWorkflowRequest) (string, error) {
+	if e.err != nil {
+		return "", e.err
+	}
+	e.started = append(e.started, request)
+	return fmt.Sprintf("%s_%d", request.Name, len(e.started)), nil
+}
+
+func (e *fakeExecutor) UpdateTaskByRefNameWithContext(ctx context.Context, taskRefName string, workflowInstanceId string, status model.TaskResultStatus, output interface{}) error {
+	if e.updated == nil {
+		e.updated = make(map[string]interface{})
+	}
+	e.updated[workflowInstanceId+"/"+taskRefName+"/"+string(status)] = output
+	return nil
+}
+
+func sign(payload string) []byte {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(payload))
+	return mac.Sum(nil)
+}
+
+func newTestHandler(t *testing.T, config model.WebhookConfig, executor Executor) *Handler {
+	config.Name = "test_webhook"
+	config.WorkflowsToStart = map[string]int32{"on_push": 1, "audit": 2}
+	handler, err := NewHandler(config, executor)
+	assert.NoError(t, err)
+	handler.now = func() time.Time { return now }
+	return handler
+}
+
+func post(handler http.Handler, body string, headers map[string]string) (*httptest.ResponseRecorder, *Result) {
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader(body))
+	request.Header.Set("Content-Type", "application/json")
+	for k, v := range headers {
+		request.Header.Set(k, v)
+	}
+	recorder := httptest.NewRecorder()
+	handler.ServeHTTP(recorder, request)
+	result := &Result{}
+	json.Unmarshal(recorder.Body.Bytes(), result)
+	return recorder, result
+}
+
+func TestGithubWebhook(t *testing.T) {
+	executor := &fakeExecutor{}
+	handler := newTestHandler(t, model.WebhookConfig{SourcePlatform: "GITHUB", SecretValue: secret}, executor)
+	body := `{"ref": "refs/heads/main"}`
+	headers := map[string]string{
+		"X-Hub-Signature-256": "sha256=" + hex.EncodeToString(sign(body)),
+		"X-GitHub-Delivery":   "delivery_1",
+	}
+
+	response, result := post(handler, body, headers)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"audit_1", "on_push_2"}, result.WorkflowIds)
+	assert.Equal(t, &model.StartWorkflowRequest{
+		Name:                "on_push",
+		Version:             1,
+		Input:               map[string]interface{}{"ref": "refs/heads/main"},
+		IdempotencyKey:      "delivery_1:on_push",
+		IdempotencyStrategy: model.ReturnExisting,
+	}, executor.started[1])
+
+	response, result = post(handler, body, headers)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.True(t, result.Duplicate)
+	assert.Len(t, executor.started, 2)
+
+	headers["X-Hub-Signature-256"] = "sha256=" + hex.EncodeToString(sign(body+" "))
+	response, _ = post(handler, body, headers)
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+}
+
+func TestSlackWebhook(t *testing.T) {
+	executor := &fakeExecutor{}
+	handler := newTestHandler(t, model.WebhookConfig{Verifier: "SLACK_BASED", SecretValue: secret}, executor).
+		WithTolerance(time.Minute)
+	slackHeaders := func(timestamp time.Time, body string) map[string]string {
+		ts := fmt.Sprint(timestamp.Unix())
+		return map[string]string{
+			"X-Slack-Request-Timestamp": ts,
+			"X-Slack-Signature":         "v0=" + hex.EncodeToString(sign("v0:"+ts+":"+body)),
+		}
+	}
+
+	challenge := `{"type": "url_verification", "challenge": "abc"}`
+	response, _ := post(handler, challenge, slackHeaders(now, challenge))
+	assert.JSONEq(t, `{"challenge": "abc"}`, response.Body.String())
+
+	event := `{"type": "event_callback", "event_id": "Ev1"}`
+	response, _ = post(handler, event, slackHeaders(now.Add(-2*time.Minute), event))
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+
+	response, result := post(handler, event, slackHeaders(now, event))
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Len(t, result.WorkflowIds, 2)
+	assert.Equal(t, "Ev1:audit", executor.started[0].IdempotencyKey)
+
+	// retries are signed again, the event id identifies the delivery
+	_, result = post(handler, event, slackHeaders(now.Add(time.Second), event))
+	assert.True(t, result.Duplicate)
+}
+
+func TestStripeWebhook(t *testing.T) {
+	executor := &fakeExecutor{}
+	handler := newTestHandler(t, model.WebhookConfig{SourcePlatform: "Stripe", SecretValue: secret}, executor).
+		RouteBy(func(event *Event) []string {
+			return []string{event.Payload["type"].(string), "unknown"}
+		})
+	body := `{"id": "evt_1", "type": "audit"}`
+	ts := fmt.Sprint(now.Unix())
+	signature := fmt.Sprintf("t=%s,v1=%s,v1=%s", ts, "deadbeef", hex.EncodeToString(sign(ts+"."+body)))
+
+	response, result := post(handler, body, map[string]string{"Stripe-Signature": signature})
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"audit_1"}, result.WorkflowIds)
+	assert.Equal(t, int32(2), executor.started[0].Version)
+}
+
+func TestHmacAndHeaderWebhooks(t *testing.T) {
+	executor := &fakeExecutor{}
+	hmacHandler := newTestHandler(t, model.WebhookConfig{Verifier: "HMAC_BASED", SecretKey: "X-Signature", SecretValue: secret}, executor).
+		SignalTask("wait_for_approval", "workflowId", model.CompletedTask)
+	body := `{"workflowId": "wf_1", "approved": true}`
+	response, result := post(hmacHandler, body, map[string]string{"X-Signature": base64.StdEncoding.EncodeToString(sign(body))})
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Equal(t, []string{"wf_1"}, result.SignaledWorkflowIds)
+	assert.Equal(t, map[string]interface{}{"workflowId": "wf_1", "approved": true}, executor.updated["wf_1/wait_for_approval/COMPLETED"])
+
+	headerHandler := newTestHandler(t, model.WebhookConfig{Verifier: "HEADER_BASED", Headers: map[string]string{"X-Token": "token"}}, executor)
+	request := httptest.NewRequest(http.MethodPost, "/webhook", strings.NewReader("payload=%7B%22a%22%3A1%7D"))
+	request.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	request.Header.Set("X-Token", "token")
+	recorder := httptest.NewRecorder()
+	headerHandler.ServeHTTP(recorder, request)
+	assert.Equal(t, http.StatusOK, recorder.Code)
+	assert.Equal(t, map[string]interface{}{"a": float64(1)}, executor.started[len(executor.started)-1].Input)
+
+	// only the challenges of Slack are answered, the others are processed as events
+	challenge := `{"type": "url_verification", "challenge": "abc"}`
+	response, result = post(headerHandler, challenge, map[string]string{"X-Token": "token"})
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Len(t, result.WorkflowIds, 2)
+	assert.Equal(t, map[string]interface{}{"type": "url_verification", "challenge": "abc"}, executor.started[len(executor.started)-1].Input)
+
+	response, _ = post(headerHandler, `{}`, map[string]string{"X-Token": "wrong"})
+	assert.Equal(t, http.StatusUnauthorized, response.Code)
+	response, _ = post(headerHandler, `not json`, map[string]string{"X-Token": "token"})
+	assert.Equal(t, http.StatusBadRequest, response.Code)
+	recorder = httptest.NewRecorder()
+	headerHandler.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/webhook", nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, recorder.Code)
+}
+
+func TestFailedDeliveryCanBeRetried(t *testing.T) {
+	executor := &fakeExecutor{err: errors.New("unavailable")}
+	handler := newTestHandler(t, model.WebhookConfig{SourcePlatform: "GITHUB", SecretValue: secret}, executor)
+	body := `{}`
+	headers := map[string]string{"X-Hub-Signature-256": "sha256=" + hex.EncodeToString(sign(body)), "X-GitHub-Delivery": "d"}
+	response, _ := post(handler, body, headers)
+	assert.Equal(t, http.StatusInternalServerError, response.Code)
+	assert.NotContains(t, response.Body.String(), "unavailable")
+
+	executor.err = nil
+	_, result := post(handler, body, headers)
+	assert.False(t, result.Duplicate)
+	assert.Len(t, result.WorkflowIds, 2)
+}
+
+func TestDeliveryInFlightIsRetried(t *testing.T) {
+	executor := &fakeExecutor{}
+	handler := newTestHandler(t, model.WebhookConfig{SourcePlatform: "GITHUB", SecretValue: secret}, executor)
+	body := `{}`
+	headers := map[string]string{"X-Hub-Signature-256": "sha256=" + hex.EncodeToString(sign(body)), "X-GitHub-Delivery": "d"}
+	// the first request is being processed
+	assert.Equal(t, reserved, handler.replays.reserve("d", now))
+	response, _ := post(handler, body, headers)
+	assert.Equal(t, http.StatusConflict, response.Code)
+	assert.Empty(t, executor.started)
+
+	// the first request failed
+	handler.replays.release("d")
+	response, result := post(handler, body, headers)
+	assert.Equal(t, http.StatusOK, response.Code)
+	assert.Len(t, result.WorkflowIds, 2)
+}
+
+func TestIdenticalHmacPayloads(t *testing.T) {
+	executor := &fakeExecutor{}
+	handler := newTestHandler(t, model.WebhookConfig{Verifier: "HMAC_BASED", SecretKey: "X-Signature", SecretValue: secret}, executor)
+	body := `{"amount": 10}`
+	headers := map[string]string{"X-Signature": hex.EncodeToString(sign(body))}
+	for i := 0; i < 2; i++ {
+		_, result := post(handler, body, headers)
+		assert.False(t, result.Duplicate)
+	}
+	assert.Len(t, executor.started, 4)
+}
+
+func TestInvalidConfigs(t *testing.T) {
+	_, err := NewHandler(model.WebhookConfig{Name: "w", Verifier: "UNKNOWN"}, &fakeExecutor{})
+	assert.EqualError(t, err, "unsupported verifier UNKNOWN")
+	_, err = NewHandler(model.WebhookConfig{Name: "w", Verifier: "HMAC_BASED", SecretKey: "X-Signature"}, &fakeExecutor{})
+	assert.EqualError(t, err, "webhook w requires the secret value to verify the signatures")
+	_, err = NewHandler(model.WebhookConfig{Name: "w"}, &fakeExecutor{})
+	assert.EqualError(t, err, "webhook w has no headers to verify")
+}
+
+func TestReplayCacheExpires(t *testing.T) {
+	cache := newReplayCache(time.Minute, 2)
+	assert.Equal(t, reserved, cache.reserve("a", now))
+	assert.Equal(t, inFlight, cache.reserve("a", now))
+	cache.complete("a", now)
+	assert.Equal(t, delivered, cache.reserve("a", now.Add(30*time.Second)))
+	assert.Equal(t, reserved, cache.reserve("a", now.Add(2*time.Minute)))
+	cache.release("a")
+	assert.Equal(t, reserved, cache.reserve("a", now.Add(2*time.Minute)))
+
+	later := now.Add(2 * time.Minute)
+	for _, key := range []string{"b", "c", "d"} {
+		cache.reserve(key, later)
+		cache.complete(key, later)
+	}
+	assert.Equal(t, reserved, cache.reserve("b", later))
+	assert.Equal(t, delivered, cache.reserve("c", later))
+}
@@ -0,0 +1,90 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package webhook
+
+import (
+	"sync"
+	"time"
+)
+
+// reservation of a delivery by the replay cache
+type reservation int
+
+const (
+	// reserved the delivery is new, it is processed by the caller
+	reserved reservation = iota
+	// inFlight the delivery is being processed by another request
+	inFlight
+	// delivered the delivery was processed within the window
+	delivered
+)
+
+// replayCache the deliveries being processed and the recently processed deliveries, expiring after the window and
+// bounded by size
+type replayCache struct {
+	mutex     sync.Mutex
+	window    time.Duration
+	size      int
+	inFlight  map[string]bool
+	delivered map[string]time.Time
+	order     []string
+}
+
+func newReplayCache(window time.Duration, size int) *replayCache {
+	return &replayCache{
+		window:    window,
+		size:      size,
+		inFlight:  make(map[string]bool),
+		delivered: make(map[string]time.Time),
+	}
+}
+
+// reserve marks the key in flight unless it is already in flight or was delivered within the window
+func (c *replayCache) reserve(key string, now time.Time) reservation {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	c.expire(now)
+	if _, ok := c.delivered[key]; ok {
+		return delivered
+	}
+	if c.inFlight[key] {
+		return inFlight
+	}
+	c.inFlight[key] = true
+	return reserved
+}
+
+// complete records the key reserved as delivered
+func (c *replayCache) complete(key string, now time.Time) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.inFlight, key)
+	c.expire(now)
+	if len(c.order) >= c.size {
+		delete(c.delivered, c.order[0])
+		c.order = c.order[1:]
+	}
+	c.delivered[key] = now
+	c.order = append(c.order, key)
+}
+
+// release forgets the key reserved so that the delivery can be retried
+func (c *replayCache) release(key string) {
+	c.mutex.Lock()
+	defer c.mutex.Unlock()
+	delete(c.inFlight, key)
+}
+
+func (c *replayCache) expire(now time.Time) {
+	for len(c.order) > 0 && now.Sub(c.delivered[c.order[0]]) > c.window {
+		delete(c.delivered, c.order[0])
+		c.order = c.order[1:]
+	}
+}
@@ -0,0 +1,245 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package webhook
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// Verifiers and source platforms of model.WebhookConfig
+const (
+	HeaderBasedVerifier    = "HEADER_BASED"
+	HmacBasedVerifier      = "HMAC_BASED"
+	SignatureBasedVerifier = "SIGNATURE_BASED"
+	SlackBasedVerifier     = "SLACK_BASED"
+	GithubBasedVerifier    = "GITHUB_BASED"
+	StripeBasedVerifier    = "STRIPE_BASED"
+
+	SlackPlatform  = "SLACK"
+	GithubPlatform = "GITHUB"
+	StripePlatform = "STRIPE"
+)
+
+const (
+	slackSignatureHeader  = "X-Slack-Signature"
+	slackTimestampHeader  = "X-Slack-Request-Timestamp"
+	githubSignatureHeader = "X-Hub-Signature-256"
+	githubDeliveryHeader  = "X-GitHub-Delivery"
+	stripeSignatureHeader = "Stripe-Signature"
+)
+
+// ErrInvalidSignature the request is not signed by the webhook source
+var ErrInvalidSignature = errors.New("invalid signature")
+
+// ErrExpiredTimestamp the request was signed outside the tolerated time window, e.g. a replayed request
+var ErrExpiredTimestamp = errors.New("timestamp outside the tolerance")
+
+// verification result of a request, the replay key identifies the delivery for the replay protection unless the
+// payload has the eventIdField, used by the platforms that sign the retries of a delivery again.  The signatures are
+// replay keys only when they sign a timestamp, identical payloads would be dropped otherwise.  answersChallenge is set
+// for the platforms whose URL verification challenges are echoed
+type verification struct {
+	replayKey        string
+	eventIdField     string
+	answersChallenge bool
+}
+
+// verifier verifies the requests of a webhook
+type verifier func(request *http.Request, body []byte, now time.Time, tolerance time.Duration) (*verification, error)
+
+// newVerifier returns the verifier of the config.  Platform specific verifiers take precedence, then the source
+// platform, then the generic HMAC and header based verifiers
+func newVerifier(config model.WebhookConfig) (verifier, error) {
+	switch strings.ToUpper(config.Verifier) {
+	case SlackBasedVerifier:
+		return slackVerifier(config)
+	case GithubBasedVerifier:
+		return githubVerifier(config)
+	case StripeBasedVerifier:
+		return stripeVerifier(config)
+	}
+	switch strings.ToUpper(config.SourcePlatform) {
+	case SlackPlatform:
+		return slackVerifier(config)
+	case GithubPlatform:
+		return githubVerifier(config)
+	case StripePlatform:
+		return stripeVerifier(config)
+	}
+	switch strings.ToUpper(config.Verifier) {
+	case HmacBasedVerifier, SignatureBasedVerifier:
+		return hmacVerifier(config)
+	case HeaderBasedVerifier, "":
+		return headerVerifier(config)
+	}
+	return nil, fmt.Errorf("unsupported verifier %s", config.Verifier)
+}
+
+func requireSecret(config model.WebhookConfig) error {
+	if config.SecretValue == "" {
+		return fmt.Errorf("webhook %s requires the secret value to verify the signatures", config.Name)
+	}
+	return nil
+}
+
+// hmacVerifier the header named by SecretKey (or HeaderKey) holds the HMAC-SHA256 of the body, hex or base64 encoded
+// with an optional sha256= prefix
+func hmacVerifier(config model.WebhookConfig) (verifier, error) {
+	if err := requireSecret(config); err != nil {
+		return nil, err
+	}
+	signatureHeader := config.SecretKey
+	if signatureHeader == "" {
+		signatureHeader = config.HeaderKey
+	}
+	if signatureHeader == "" {
+		return nil, fmt.Errorf("webhook %s requires the name of the signature header", config.Name)
+	}
+	return func(request *http.Request, body []byte, now time.Time, tolerance time.Duration) (*verification, error) {
+		if err := verifyHeaders(config, request); err != nil {
+			return nil, err
+		}
+		signature := strings.TrimPrefix(request.Header.Get(signatureHeader), "sha256=")
+		if !validSignature(config.SecretValue, body, signature) {
+			return nil, ErrInvalidSignature
+		}
+		return &verification{}, nil
+	}, nil
+}
+
+// headerVerifier the request must have all the Headers of the config and, if set, the SecretKey header with the
+// SecretValue
+func headerVerifier(config model.WebhookConfig) (verifier, error) {
+	if len(config.Headers) == 0 && config.SecretKey == "" {
+		return nil, fmt.Errorf("webhook %s has no headers to verify", config.Name)
+	}
+	return func(request *http.Request, body []byte, now time.Time, tolerance time.Duration) (*verification, error) {
+		if err := verifyHeaders(config, request); err != nil {
+			return nil, err
+		}
+		if config.SecretKey != "" && !hmac.Equal([]byte(request.Header.Get(config.SecretKey)), []byte(config.SecretValue)) {
+			return nil, ErrInvalidSignature
+		}
+		return &verification{}, nil
+	}, nil
+}
+
+// slackVerifier see https://api.slack.com/authentication/verifying-requests-from-slack
+func slackVerifier(config model.WebhookConfig) (verifier, error) {
+	if err := requireSecret(config); err != nil {
+		return nil, err
+	}
+	return func(request *http.Request, body []byte, now time.Time, tolerance time.Duration) (*verification, error) {
+		timestamp := request.Header.Get(slackTimestampHeader)
+		if err := verifyTimestamp(timestamp, now, tolerance); err != nil {
+			return nil, err
+		}
+		signature := strings.TrimPrefix(request.Header.Get(slackSignatureHeader), "v0=")
+		signedPayload := append([]byte("v0:"+timestamp+":"), body...)
+		if !validSignature(config.SecretValue, signedPayload, signature) {
+			return nil, ErrInvalidSignature
+		}
+		return &verification{replayKey: signature, eventIdField: "event_id", answersChallenge: true}, nil
+	}, nil
+}
+
+// githubVerifier see https://docs.github.com/en/webhooks/using-webhooks/validating-webhook-deliveries
+func githubVerifier(config model.WebhookConfig) (verifier, error) {
+	if err := requireSecret(config); err != nil {
+		return nil, err
+	}
+	return func(request *http.Request, body []byte, now time.Time, tolerance time.Duration) (*verification, error) {
+		signature := strings.TrimPrefix(request.Header.Get(githubSignatureHeader), "sha256=")
+		if !validSignature(config.SecretValue, body, signature) {
+			return nil, ErrInvalidSignature
+		}
+		return &verification{replayKey: request.Header.Get(githubDeliveryHeader)}, nil
+	}, nil
+}
+
+// stripeVerifier see https://docs.stripe.com/webhooks#verify-manually
+func stripeVerifier(config model.WebhookConfig) (verifier, error) {
+	if err := requireSecret(config); err != nil {
+		return nil, err
+	}
+	return func(request *http.Request, body []byte, now time.Time, tolerance time.Duration) (*verification, error) {
+		timestamp := ""
+		signatures := make([]string, 0)
+		for _, part := range strings.Split(request.Header.Get(stripeSignatureHeader), ",") {
+			keyValue := strings.SplitN(strings.TrimSpace(part), "=", 2)
+			if len(keyValue) != 2 {
+				continue
+			}
+			switch keyValue[0] {
+			case "t":
+				timestamp = keyValue[1]
+			case "v1":
+				signatures = append(signatures, keyValue[1])
+			}
+		}
+		if err := verifyTimestamp(timestamp, now, tolerance); err != nil {
+			return nil, err
+		}
+		signedPayload := append([]byte(timestamp+"."), body...)
+		for _, signature := range signatures {
+			if validSignature(config.SecretValue, signedPayload, signature) {
+				return &verification{replayKey: signature, eventIdField: "id"}, nil
+			}
+		}
+		return nil, ErrInvalidSignature
+	}, nil
+}
+
+func verifyHeaders(config model.WebhookConfig, request *http.Request) error {
+	for name, value := range config.Headers {
+		if request.Header.Get(name) != value {
+			return fmt.Errorf("%w: header %s does not match", ErrInvalidSignature, name)
+		}
+	}
+	return nil
+}
+
+func verifyTimestamp(timestamp string, now time.Time, tolerance time.Duration) error {
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("%w: missing or invalid timestamp", ErrInvalidSignature)
+	}
+	age := now.Sub(time.Unix(seconds, 0))
+	if age > tolerance || age < -tolerance {
+		return ErrExpiredTimestamp
+	}
+	return nil
+}
+
+// validSignature compares the HMAC-SHA256 of the payload with the signature, hex or base64 encoded
+func validSignature(secret string, payload []byte, signature string) bool {
+	if signature == "" {
+		return false
+	}
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(payload)
+	expected := mac.Sum(nil)
+	if decoded, err := hex.DecodeString(signature); err == nil && hmac.Equal(decoded, expected) {
+		return true
+	}
+	decoded, err := base64.StdEncoding.DecodeString(signature)
+	return err == nil && hmac.Equal(decoded, expected)
+}
@@ -22,6 +22,7 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/metrics"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
 	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/storage"
 
 	"github.com/antihax/optional"
 	log "github.com/sirupsen/logrus"
@@ -65,6 +66,8 @@ type TaskRunner struct {
 	pollTimeoutMutex      sync.RWMutex
 	pollTimeout           time.Duration
 	pollTimeoutByTaskName map[string]time.Duration
+
+	externalPayloadStorage *storage.ExternalPayloadStorage
 }
 
 // NewTaskRunner returns a new TaskRunner which authenticates via HTTP using the provided settings.
@@ -101,6 +104,12 @@ func (c *TaskRunner) SetSleepOnGenericError(duration time.Duration) {
 	sleepForOnGenericError = duration
 }
 
+// SetExternalStorageSettings enables the external payload storage.  The inputs stored externally are downloaded before
+// the task is executed, and the outputs larger than the threshold are uploaded instead of being sent to the server
+func (c *TaskRunner) SetExternalStorageSettings(externalStorageSettings *settings.ExternalStorageSettings) {
+	c.externalPayloadStorage = storage.NewExternalPayloadStorage(c.conductorTaskResourceClient.APIClient, externalStorageSettings)
+}
+
 // StartWorkerWithDomain starts a polling worker on a new goroutine, which only polls for tasks using the provided
 // domain. Equivalent to:
 //
@@ -328,7 +337,13 @@ func (c *TaskRunner) workOnce(taskName string, executeFunction model.ExecuteTask
 func (c *TaskRunner) executeAndUpdateTask(taskName string, task model.Task, executeFunction model.ExecuteTaskFunction) {
 	defer c.runningWorkerDone(taskName)
 	defer concurrency.HandlePanicError("execute_and_update_task " + string(task.TaskId) + ": " + string(task.Status))
-	taskResult := c.executeTask(&task, executeFunction)
+	var taskResult *model.TaskResult
+	if err := c.downloadTaskInput(&task); err != nil {
+		taskResult = model.NewTaskResultFromTaskWithError(&task, err)
+	} else {
+		taskResult = c.executeTask(&task, executeFunction)
+		c.uploadTaskOutput(&task, taskResult)
+	}
 	err := c.updateTaskWithRetry(taskName, taskResult)
 	if err != nil {
 		log.Error("failed to update task ", taskName, ",taskId = ", task.TaskId, ",workflowId = ", task.WorkflowInstanceId, ",", err)
@@ -425,6 +440,48 @@ func (c *TaskRunner) executeTask(t *model.Task, executeFunction model.ExecuteTas
 	return taskResult
 }
 
+func (c *TaskRunner) downloadTaskInput(t *model.Task) error {
+	if c.externalPayloadStorage == nil {
+		return nil
+	}
+	err := c.externalPayloadStorage.DownloadTaskInput(context.Background(), t)
+	if err != nil {
+		log.Warning(
+			"failed to download the input of the task from external storage",
+			", reason: ", err.Error(),
+			", taskName: ", t.TaskDefName,
+			", taskId: ", t.TaskId,
+			", workflowId: ", t.WorkflowInstanceId,
+		)
+	}
+	return err
+}
+
+// uploadTaskOutput fails the task when the output can not be stored externally, the server would reject it otherwise
+func (c *TaskRunner) uploadTaskOutput(t *model.Task, taskResult *model.TaskResult) {
+	if c.externalPayloadStorage == nil {
+		return
+	}
+	err := c.externalPayloadStorage.UploadTaskOutput(context.Background(), t.TaskDefName, taskResult)
+	if err == nil {
+		return
+	}
+	log.Warning(
+		"failed to upload the output of the task to external storage",
+		", reason: ", err.Error(),
+		", taskName: ", t.TaskDefName,
+		", taskId: ", t.TaskId,
+		", workflowId: ", t.WorkflowInstanceId,
+	)
+	taskResult.OutputData = nil
+	taskResult.ReasonForIncompletion = err.Error()
+	if _, tooLarge := err.(*storage.PayloadTooLargeError); tooLarge {
+		taskResult.Status = model.FailedWithTerminalErrorTask
+	} else {
+		taskResult.Status = model.FailedTask
+	}
+}
+
 func (c *TaskRunner) updateTaskWithRetry(taskName string, taskResult *model.TaskResult) error {
 	log.Debug(
 		"Updating task of type: ", taskName,
@@ -0,0 +1,78 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+type RuleExecutionStrategy string
+
+const (
+	// FireFirst the output is the first matching rule
+	FireFirst RuleExecutionStrategy = "FIRE_FIRST"
+	// FireAll the output contains all the matching rules
+	FireAll RuleExecutionStrategy = "FIRE_ALL"
+)
+
+// BusinessRuleTask evaluates the rules of a decision table (spreadsheet) against the input columns
+type BusinessRuleTask struct {
+	Task
+}
+
+// BusinessRuleInput RuleFileLocation is the URL of the rules file, e.g. https://example.com/rules/discounts.xlsx
+type BusinessRuleInput struct {
+	RuleFileLocation    string                 `json:"ruleFileLocation"`
+	ExecutionStrategy   RuleExecutionStrategy  `json:"executionStrategy,omitempty"`
+	InputColumns        map[string]interface{} `json:"inputColumns"`
+	OutputColumns       []string               `json:"outputColumns"`
+	CacheTimeoutMinutes int32                  `json:"cacheTimeoutMinutes,omitempty"`
+}
+
+// NewBusinessRuleTask the execution strategy defaults to FIRE_FIRST, the input is not modified.  A nil input leaves
+// the input parameters to be set with Input and InputMap
+func NewBusinessRuleTask(taskRefName string, input *BusinessRuleInput) *BusinessRuleTask {
+	inputParameters := map[string]interface{}{}
+	if input != nil {
+		ruleInput := *input
+		if len(ruleInput.ExecutionStrategy) == 0 {
+			ruleInput.ExecutionStrategy = FireFirst
+		}
+		inputParameters = getInputAsMap(ruleInput)
+	}
+	return &BusinessRuleTask{
+		Task{
+			name:              taskRefName,
+			taskReferenceName: taskRefName,
+			taskType:          BUSINESS_RULE,
+			inputParameters:   inputParameters,
+		},
+	}
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *BusinessRuleTask) Input(key string, value interface{}) *BusinessRuleTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *BusinessRuleTask) InputMap(inputMap map[string]interface{}) *BusinessRuleTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *BusinessRuleTask) Optional(optional bool) *BusinessRuleTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *BusinessRuleTask) Description(description string) *BusinessRuleTask {
+	task.Task.Description(description)
+	return task
+}
@@ -0,0 +1,35 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestBusinessRuleTask(t *testing.T) {
+	task := NewBusinessRuleTask("discount_ref", &BusinessRuleInput{
+		RuleFileLocation: "https://example.com/rules/discounts.xlsx",
+		InputColumns:     map[string]interface{}{"customerType": "${workflow.input.customerType}"},
+		OutputColumns:    []string{"discount"},
+	})
+	assertSerializedTask(t, `{
+		"name": "discount_ref", "taskReferenceName": "discount_ref", "type": "BUSINESS_RULE",
+		"inputParameters": {
+			"ruleFileLocation": "https://example.com/rules/discounts.xlsx", "executionStrategy": "FIRE_FIRST",
+			"inputColumns": {"customerType": "${workflow.input.customerType}"}, "outputColumns": ["discount"]
+		}
+	}`, task)
+}
+
+func TestBusinessRuleTaskInput(t *testing.T) {
+	input := &BusinessRuleInput{RuleFileLocation: "https://example.com/rules/discounts.xlsx"}
+	NewBusinessRuleTask("discount_ref", input)
+	assert.Empty(t, input.ExecutionStrategy)
+
+	task := NewBusinessRuleTask("discount_ref", nil).
+		Input("ruleFileLocation", "https://example.com/rules/discounts.xlsx")
+	assertSerializedTask(t, `{
+		"name": "discount_ref", "taskReferenceName": "discount_ref", "type": "BUSINESS_RULE",
+		"inputParameters": {"ruleFileLocation": "https://example.com/rules/discounts.xlsx"}
+	}`, task)
+}
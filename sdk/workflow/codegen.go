@@ -0,0 +1,587 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"go/format"
+	"go/token"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// GenerateGoSourceFromJson generates the Go source that builds the workflow defined by the JSON workflow definition.
+// See GenerateGoSource
+func GenerateGoSourceFromJson(workflowDefJson []byte, packageName string, functionName string) ([]byte, error) {
+	// the settings omitted from the JSON keep the defaults of the server, which are also the defaults of the builder
+	workflowDef := model.WorkflowDef{Restartable: true, OverwriteTags: true}
+	if err := json.Unmarshal(workflowDefJson, &workflowDef); err != nil {
+		return nil, err
+	}
+	return GenerateGoSource(&workflowDef, packageName, functionName)
+}
+
+// GenerateGoSource generates the Go source of a function named functionName that builds the workflow using the builders
+// of this package, e.g. func functionName(executor *executor.WorkflowExecutor) *workflow.ConductorWorkflow
+// Tasks that cannot be expressed with the builders are generated as NewRawTask with the complete task definition,
+// so that the generated workflow is always equivalent to workflowDef
+func GenerateGoSource(workflowDef *model.WorkflowDef, packageName string, functionName string) ([]byte, error) {
+	generator := &sourceGenerator{
+		body:  &bytes.Buffer{},
+		names: map[string]bool{"wf": true, "executor": true, "workflow": true, "model": true},
+	}
+	taskVars := generator.generateTasks(workflowDef.Tasks)
+
+	var body bytes.Buffer
+	body.WriteString("wf := workflow.NewConductorWorkflow(executor).\n")
+	body.WriteString(generator.workflowOptions(workflowDef))
+	body.WriteString("\n\n")
+	body.WriteString(generator.body.String())
+	for _, taskVar := range taskVars {
+		fmt.Fprintf(&body, "wf.Add(%s)\n", taskVar)
+	}
+	body.WriteString("return wf\n")
+
+	var source bytes.Buffer
+	fmt.Fprintf(&source, "// Code generated from the definition of the workflow %s. Review before editing.\n\n", workflowDef.Name)
+	fmt.Fprintf(&source, "package %s\n\n", packageName)
+	source.WriteString("import (\n")
+	if strings.Contains(body.String(), "model.") {
+		source.WriteString("\"github.com/conductor-sdk/conductor-go/sdk/model\"\n")
+	}
+	source.WriteString("\"github.com/conductor-sdk/conductor-go/sdk/workflow\"\n")
+	source.WriteString("\"github.com/conductor-sdk/conductor-go/sdk/workflow/executor\"\n")
+	source.WriteString(")\n\n")
+	fmt.Fprintf(&source, "// %s creates the %s workflow\n", functionName, workflowDef.Name)
+	fmt.Fprintf(&source, "func %s(executor *executor.WorkflowExecutor) *workflow.ConductorWorkflow {\n", functionName)
+	source.Write(body.Bytes())
+	source.WriteString("}\n")
+	return format.Source(source.Bytes())
+}
+
+type sourceGenerator struct {
+	body  *bytes.Buffer
+	names map[string]bool
+}
+
+// generatedTask the Go expression creating the task along with the task built by the same builder calls,
+// used to verify the expression is equivalent to the task definition
+type generatedTask struct {
+	task       TaskInterface
+	expression string
+	statements []string
+}
+
+func (g *sourceGenerator) workflowOptions(workflowDef *model.WorkflowDef) string {
+	options := []string{
+		fmt.Sprintf("Name(%s)", goLiteral(workflowDef.Name)),
+		fmt.Sprintf("Version(%d)", workflowDef.Version),
+	}
+	if workflowDef.Description != "" {
+		options = append(options, fmt.Sprintf("Description(%s)", goLiteral(workflowDef.Description)))
+	}
+	if workflowDef.OwnerEmail != "" {
+		options = append(options, fmt.Sprintf("OwnerEmail(%s)", goLiteral(workflowDef.OwnerEmail)))
+	}
+	if workflowDef.TimeoutPolicy != "" {
+		options = append(options, fmt.Sprintf("TimeoutPolicy(%s, %d)", timeoutPolicyLiteral(workflowDef.TimeoutPolicy), workflowDef.TimeoutSeconds))
+	} else if workflowDef.TimeoutSeconds != 0 {
+		options = append(options, fmt.Sprintf("TimeoutSeconds(%d)", workflowDef.TimeoutSeconds))
+	}
+	if workflowDef.FailureWorkflow != "" {
+		options = append(options, fmt.Sprintf("FailureWorkflow(%s)", goLiteral(workflowDef.FailureWorkflow)))
+	}
+	if !workflowDef.Restartable {
+		options = append(options, "Restartable(false)")
+	}
+	if workflowDef.WorkflowStatusListenerEnabled {
+		options = append(options, "WorkflowStatusListenerEnabled(true)")
+	}
+	if len(workflowDef.InputParameters) > 0 {
+		params := make([]string, len(workflowDef.InputParameters))
+		for i, param := range workflowDef.InputParameters {
+			params[i] = goLiteral(param)
+		}
+		options = append(options, fmt.Sprintf("InputParameters(%s)", strings.Join(params, ", ")))
+	}
+	if len(workflowDef.OutputParameters) > 0 {
+		options = append(options, fmt.Sprintf("OutputParameters(%s)", goLiteral(workflowDef.OutputParameters)))
+	}
+	if len(workflowDef.InputTemplate) > 0 {
+		options = append(options, fmt.Sprintf("InputTemplate(%s)", goLiteral(workflowDef.InputTemplate)))
+	}
+	if len(workflowDef.Variables) > 0 {
+		options = append(options, fmt.Sprintf("Variables(%s)", goLiteral(workflowDef.Variables)))
+	}
+	if len(workflowDef.Tags) > 0 {
+		tags := map[string]string{}
+		for _, tag := range workflowDef.Tags {
+			tags[tag.Key] = tag.Value
+		}
+		options = append(options, fmt.Sprintf("Tags(%s)", goLiteral(tags)))
+	}
+	if !workflowDef.OverwriteTags {
+		options = append(options, "OverwriteTags(false)")
+	}
+	if workflowDef.InputSchema != nil {
+		options = append(options, fmt.Sprintf("InputSchema(%s)", schemaRefLiteral(workflowDef.InputSchema)))
+	}
+	if workflowDef.OutputSchema != nil {
+		options = append(options, fmt.Sprintf("OutputSchema(%s)", schemaRefLiteral(workflowDef.OutputSchema)))
+	}
+	if workflowDef.EnforceSchema {
+		options = append(options, "EnforceSchema(true)")
+	}
+	return strings.Join(options, ".\n")
+}
+
+func schemaRefLiteral(schemaDef *model.SchemaDef) string {
+	if schemaDef.Type_ == "" || schemaDef.Type_ == model.JsonSchema {
+		return fmt.Sprintf("model.NewSchemaRef(%s, %d)", goLiteral(schemaDef.Name), schemaDef.Version)
+	}
+	return fmt.Sprintf("&model.SchemaDef{Name: %s, Version: %d, Type_: %s}",
+		goLiteral(schemaDef.Name), schemaDef.Version, goLiteral(string(schemaDef.Type_)))
+}
+
+func timeoutPolicyLiteral(timeoutPolicy string) string {
+	switch TimeoutPolicy(timeoutPolicy) {
+	case TimeOutWorkflow:
+		return "workflow.TimeOutWorkflow"
+	case AlertOnly:
+		return "workflow.AlertOnly"
+	}
+	return fmt.Sprintf("workflow.TimeoutPolicy(%s)", goLiteral(timeoutPolicy))
+}
+
+// generateTasks writes the declaration of the tasks and returns their variable names
+func (g *sourceGenerator) generateTasks(workflowTasks []model.WorkflowTask) []string {
+	vars := make([]string, 0, len(workflowTasks))
+	for i := 0; i < len(workflowTasks); i++ {
+		workflowTask := workflowTasks[i]
+		var join *model.WorkflowTask
+		if i+1 < len(workflowTasks) && workflowTasks[i+1].Type_ == string(JOIN) {
+			join = &workflowTasks[i+1]
+		}
+		expected := []model.WorkflowTask{workflowTask}
+		if isFork(workflowTask) && join != nil {
+			expected = append(expected, *join)
+		}
+		// the nested tasks are declared in a scratch buffer, kept only when the builder calls of the task are kept
+		body, names := g.body, copyNames(g.names)
+		g.body = &bytes.Buffer{}
+		generated := g.generateTypedTask(workflowTask, join)
+		nested := g.body
+		g.body = body
+		if generated == nil || !equivalentWorkflowTasks(expected, generated.task.toWorkflowTask()) {
+			generated = &generatedTask{expression: fmt.Sprintf("workflow.NewRawTask(%s)", goLiteral(workflowTask))}
+			expected = expected[:1]
+			g.names = names
+		} else {
+			g.body.Write(nested.Bytes())
+		}
+		if len(expected) == 2 {
+			i++
+		}
+		vars = append(vars, g.declare(workflowTask.TaskReferenceName, generated))
+	}
+	return vars
+}
+
+func copyNames(names map[string]bool) map[string]bool {
+	copied := make(map[string]bool, len(names))
+	for name := range names {
+		copied[name] = true
+	}
+	return copied
+}
+
+func (g *sourceGenerator) declare(taskRefName string, generated *generatedTask) string {
+	name := g.variableName(taskRefName)
+	fmt.Fprintf(g.body, "%s := %s\n", name, generated.expression)
+	for _, statement := range generated.statements {
+		fmt.Fprintf(g.body, "%s.%s\n", name, statement)
+	}
+	g.body.WriteString("\n")
+	return name
+}
+
+func (g *sourceGenerator) variableName(taskRefName string) string {
+	var name strings.Builder
+	upper := false
+	for _, r := range taskRefName {
+		if !unicode.IsLetter(r) && !unicode.IsDigit(r) {
+			upper = name.Len() > 0
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		} else if name.Len() == 0 {
+			r = unicode.ToLower(r)
+		}
+		name.WriteRune(r)
+	}
+	base := name.String()
+	if base == "" || unicode.IsDigit(rune(base[0])) || token.IsKeyword(base) || g.names[base] {
+		base = "task" + strings.Title(base)
+	}
+	candidate := base
+	for i := 2; g.names[candidate]; i++ {
+		candidate = fmt.Sprintf("%s%d", base, i)
+	}
+	g.names[candidate] = true
+	return candidate
+}
+
+// generateTypedTask generates the builder calls for the task types that have a builder, returns nil otherwise
+func (g *sourceGenerator) generateTypedTask(workflowTask model.WorkflowTask, join *model.WorkflowTask) *generatedTask {
+	ref := goLiteral(workflowTask.TaskReferenceName)
+	inputs := copyInputParameters(workflowTask.InputParameters)
+	var generated *generatedTask
+	switch TaskType(workflowTask.Type_) {
+	case SIMPLE:
+		task := NewSimpleTask(workflowTask.Name, workflowTask.TaskReferenceName)
+		generated = &generatedTask{task: task, expression: fmt.Sprintf("workflow.NewSimpleTask(%s, %s)", goLiteral(workflowTask.Name), ref)}
+		generated.taskDefOptions(task, workflowTask.TaskDefinition)
+		if workflowTask.CacheConfig != nil {
+			task.CacheConfig(workflowTask.CacheConfig.Key, workflowTask.CacheConfig.TtlInSeconds)
+			generated.statements = append(generated.statements,
+				fmt.Sprintf("CacheConfig(%s, %d)", goLiteral(workflowTask.CacheConfig.Key), workflowTask.CacheConfig.TtlInSeconds))
+		}
+	case HTTP:
+		var input HttpInput
+		if !decodeInput(inputs["http_request"], &input) {
+			return nil
+		}
+		delete(inputs, "http_request")
+		task := NewHttpTask(workflowTask.TaskReferenceName, &input)
+		generated = &generatedTask{task: task, expression: fmt.Sprintf("workflow.NewHttpTask(%s, %s)", ref, goLiteral(&input))}
+		generated.taskDefOptions(&task.SimpleTask, workflowTask.TaskDefinition)
+	case SWITCH:
+		generated = g.generateSwitchTask(workflowTask, inputs)
+	case FORK_JOIN:
+		generated = g.generateForkTask(workflowTask, join)
+	case JOIN:
+		generated = &generatedTask{
+			task:       NewJoinTask(workflowTask.TaskReferenceName, workflowTask.JoinOn...),
+			expression: fmt.Sprintf("workflow.NewJoinTask(%s)", strings.Join(literals(workflowTask.TaskReferenceName, workflowTask.JoinOn...), ", ")),
+		}
+	case DO_WHILE:
+		loopOver := g.generateTasks(workflowTask.LoopOver)
+		generated = &generatedTask{
+			task: NewDoWhileTask(workflowTask.TaskReferenceName, workflowTask.LoopCondition, fromWorkflowTasks(workflowTask.LoopOver)...),
+			expression: fmt.Sprintf("workflow.NewDoWhileTask(%s)",
+				strings.Join(append([]string{ref, goLiteral(workflowTask.LoopCondition)}, loopOver...), ", ")),
+		}
+	case SUB_WORKFLOW:
+		params := workflowTask.SubWorkflowParam
+		if params == nil || params.WorkflowDefinition != nil {
+			return nil
+		}
+		task := NewSubWorkflowTask(workflowTask.TaskReferenceName, params.Name, params.Version)
+		generated = &generatedTask{task: task, expression: fmt.Sprintf("workflow.NewSubWorkflowTask(%s, %s, %d)", ref, goLiteral(params.Name), params.Version)}
+		if len(params.TaskToDomain) > 0 {
+			task.TaskToDomain(params.TaskToDomain)
+			generated.expression += fmt.Sprintf(".\nTaskToDomain(%s)", goLiteral(params.TaskToDomain))
+		}
+	case WAIT:
+		generated = &generatedTask{task: NewWaitTask(workflowTask.TaskReferenceName), expression: fmt.Sprintf("workflow.NewWaitTask(%s)", ref)}
+	case HUMAN:
+		generated = &generatedTask{task: NewHumanTask(workflowTask.TaskReferenceName), expression: fmt.Sprintf("workflow.NewHumanTask(%s)", ref)}
+	case SET_VARIABLE:
+		generated = &generatedTask{task: NewSetVariableTask(workflowTask.TaskReferenceName), expression: fmt.Sprintf("workflow.NewSetVariableTask(%s)", ref)}
+	case INLINE:
+		script, isString := inputs["expression"].(string)
+		if !isString {
+			return nil
+		}
+		constructor := "NewInlineGraalJSTask"
+		task := NewInlineGraalJSTask(workflowTask.TaskReferenceName, script)
+		if inputs["evaluatorType"] == JavascriptEvaluator {
+			constructor = "NewInlineTask"
+			task = NewInlineTask(workflowTask.TaskReferenceName, script)
+		}
+		delete(inputs, "expression")
+		delete(inputs, "evaluatorType")
+		generated = &generatedTask{task: task, expression: fmt.Sprintf("workflow.%s(%s, %s)", constructor, ref, goLiteral(script))}
+	case JSON_JQ_TRANSFORM:
+		query, isString := inputs["queryExpression"].(string)
+		if !isString {
+			return nil
+		}
+		delete(inputs, "queryExpression")
+		generated = &generatedTask{task: NewJQTask(workflowTask.TaskReferenceName, query), expression: fmt.Sprintf("workflow.NewJQTask(%s, %s)", ref, goLiteral(query))}
+	case TERMINATE:
+		status, _ := inputs["terminationStatus"].(string)
+		reason, _ := inputs["terminationReason"].(string)
+		delete(inputs, "terminationStatus")
+		delete(inputs, "terminationReason")
+		generated = &generatedTask{
+			task:       NewTerminateTask(workflowTask.TaskReferenceName, model.WorkflowStatus(status), reason),
+			expression: fmt.Sprintf("workflow.NewTerminateTask(%s, model.WorkflowStatus(%s), %s)", ref, goLiteral(status), goLiteral(reason)),
+		}
+	case EVENT:
+		constructors := map[string]string{
+			sqsEventPrefix:       "NewSqsEventTask",
+			conductorEventPrefix: "NewConductorEventTask",
+			kafkaEventPrefix:     "NewKafkaEventTask",
+			amqpEventPrefix:      "NewAmqpEventTask",
+			natsEventPrefix:      "NewNatsEventTask",
+		}
+		for prefix, constructor := range constructors {
+			if strings.HasPrefix(workflowTask.Sink, prefix+":") {
+				target := strings.TrimPrefix(workflowTask.Sink, prefix+":")
+				generated = &generatedTask{
+					task:       newEventTask(workflowTask.TaskReferenceName, prefix, target),
+					expression: fmt.Sprintf("workflow.%s(%s, %s)", constructor, ref, goLiteral(target)),
+				}
+			}
+		}
+	}
+	if generated == nil {
+		return nil
+	}
+	if TaskType(workflowTask.Type_) != SWITCH {
+		generated.commonOptions(workflowTask, inputs)
+	}
+	return generated
+}
+
+func (g *sourceGenerator) generateSwitchTask(workflowTask model.WorkflowTask, inputs map[string]interface{}) *generatedTask {
+	typed, isSwitch := toTypedTask(workflowTask, nil).(*SwitchTask)
+	if !isSwitch || (typed.useJavascript && typed.evaluatorType != EvaluatorTypeJavaScript) {
+		return nil
+	}
+	delete(inputs, switchCaseValueParam)
+	task := NewSwitchTask(workflowTask.TaskReferenceName, typed.expression)
+	generated := &generatedTask{
+		task:       task,
+		expression: fmt.Sprintf("workflow.NewSwitchTask(%s, %s)", goLiteral(workflowTask.TaskReferenceName), goLiteral(typed.expression)),
+	}
+	caseValues := make([]string, 0, len(workflowTask.DecisionCases))
+	for caseValue := range workflowTask.DecisionCases {
+		caseValues = append(caseValues, caseValue)
+	}
+	sort.Strings(caseValues)
+	for _, caseValue := range caseValues {
+		caseVars := g.generateTasks(workflowTask.DecisionCases[caseValue])
+		task.SwitchCase(caseValue, typed.DecisionCases[caseValue]...)
+		generated.expression += fmt.Sprintf(".\nSwitchCase(%s)", strings.Join(append([]string{goLiteral(caseValue)}, caseVars...), ", "))
+	}
+	if len(workflowTask.DefaultCase) > 0 {
+		defaultVars := g.generateTasks(workflowTask.DefaultCase)
+		task.DefaultCase(typed.defaultCase...)
+		generated.expression += fmt.Sprintf(".\nDefaultCase(%s)", strings.Join(defaultVars, ", "))
+	}
+	if typed.useJavascript {
+		task.UseJavascript(true)
+		generated.expression += ".\nUseJavascript(true)"
+	}
+	generated.commonOptions(workflowTask, inputs)
+	return generated
+}
+
+func (g *sourceGenerator) generateForkTask(workflowTask model.WorkflowTask, join *model.WorkflowTask) *generatedTask {
+	if join == nil {
+		return nil
+	}
+	branches := make([]string, len(workflowTask.ForkTasks))
+	forkedTasks := make([][]TaskInterface, len(workflowTask.ForkTasks))
+	for i, branch := range workflowTask.ForkTasks {
+		branchVars := g.generateTasks(branch)
+		branches[i] = fmt.Sprintf("[]workflow.TaskInterface{%s}", strings.Join(branchVars, ", "))
+		forkedTasks[i] = fromWorkflowTasks(branch)
+	}
+	ref := goLiteral(workflowTask.TaskReferenceName)
+	defaultJoin := NewJoinTask(workflowTask.TaskReferenceName + "_join")
+	if equivalentWorkflowTasks([]model.WorkflowTask{*join}, defaultJoin.toWorkflowTask()) {
+		return &generatedTask{
+			task:       NewForkTask(workflowTask.TaskReferenceName, forkedTasks...),
+			expression: fmt.Sprintf("workflow.NewForkTask(%s)", strings.Join(append([]string{ref}, branches...), ", ")),
+		}
+	}
+	joinVar := g.declare(join.TaskReferenceName, &generatedTask{
+		expression: fmt.Sprintf("workflow.NewJoinTask(%s)", strings.Join(literals(join.TaskReferenceName, join.JoinOn...), ", ")),
+	})
+	return &generatedTask{
+		task:       NewForkTaskWithJoin(workflowTask.TaskReferenceName, toJoinTask(*join), forkedTasks...),
+		expression: fmt.Sprintf("workflow.NewForkTaskWithJoin(%s)", strings.Join(append([]string{ref, joinVar}, branches...), ", ")),
+	}
+}
+
+// commonOptions applies the input, description and optional flag to the task and its expression
+func (generated *generatedTask) commonOptions(workflowTask model.WorkflowTask, inputs map[string]interface{}) {
+	task := reflect.ValueOf(generated.task)
+	if len(inputs) > 0 {
+		if method := task.MethodByName("InputMap"); method.IsValid() {
+			method.Call([]reflect.Value{reflect.ValueOf(inputs)})
+			generated.expression += fmt.Sprintf(".\nInputMap(%s)", goLiteral(inputs))
+		}
+	}
+	if workflowTask.Description != "" {
+		task.MethodByName("Description").Call([]reflect.Value{reflect.ValueOf(workflowTask.Description)})
+		generated.expression += fmt.Sprintf(".\nDescription(%s)", goLiteral(workflowTask.Description))
+	}
+	if workflowTask.Optional {
+		task.MethodByName("Optional").Call([]reflect.Value{reflect.ValueOf(true)})
+		generated.expression += ".\nOptional(true)"
+	}
+}
+
+// taskDefOptions applies the task definition settings supported by the SimpleTask builder
+func (generated *generatedTask) taskDefOptions(task *SimpleTask, taskDef *model.TaskDef) {
+	if taskDef == nil {
+		return
+	}
+	if taskDef.RetryCount != 0 || taskDef.RetryLogic != "" || taskDef.RetryDelaySeconds != 0 || taskDef.BackoffScaleFactor != 0 {
+		task.RetryPolicy(taskDef.RetryCount, RetryLogic(taskDef.RetryLogic), taskDef.RetryDelaySeconds, taskDef.BackoffScaleFactor)
+		generated.expression += fmt.Sprintf(".\nRetryPolicy(%d, workflow.RetryLogic(%s), %d, %d)",
+			taskDef.RetryCount, goLiteral(taskDef.RetryLogic), taskDef.RetryDelaySeconds, taskDef.BackoffScaleFactor)
+	}
+	if taskDef.RateLimitFrequencyInSeconds != 0 || taskDef.RateLimitPerFrequency != 0 {
+		task.RateLimitFrequency(taskDef.RateLimitFrequencyInSeconds, taskDef.RateLimitPerFrequency)
+		generated.expression += fmt.Sprintf(".\nRateLimitFrequency(%d, %d)", taskDef.RateLimitFrequencyInSeconds, taskDef.RateLimitPerFrequency)
+	}
+	if taskDef.ConcurrentExecLimit != 0 {
+		task.ConcurrentExecutionLimit(taskDef.ConcurrentExecLimit)
+		generated.expression += fmt.Sprintf(".\nConcurrentExecutionLimit(%d)", taskDef.ConcurrentExecLimit)
+	}
+	if taskDef.TimeoutSeconds != 0 {
+		task.ExecutionTimeout(taskDef.TimeoutSeconds)
+		generated.expression += fmt.Sprintf(".\nExecutionTimeout(%d)", taskDef.TimeoutSeconds)
+	}
+	if taskDef.PollTimeoutSeconds != 0 {
+		task.PollTimeout(taskDef.PollTimeoutSeconds)
+		generated.expression += fmt.Sprintf(".\nPollTimeout(%d)", taskDef.PollTimeoutSeconds)
+	}
+	if taskDef.ResponseTimeoutSeconds != 0 {
+		task.ResponseTimeout(taskDef.ResponseTimeoutSeconds)
+		generated.expression += fmt.Sprintf(".\nResponseTimeout(%d)", taskDef.ResponseTimeoutSeconds)
+	}
+	if taskDef.TimeoutPolicy != "" {
+		task.TimeoutPolicy(TaskTimeoutPolicy(taskDef.TimeoutPolicy))
+		generated.expression += fmt.Sprintf(".\nTimeoutPolicy(workflow.TaskTimeoutPolicy(%s))", goLiteral(taskDef.TimeoutPolicy))
+	}
+}
+
+// decodeInput decodes the input into the typed input struct, returns false if the struct does not represent the input exactly
+func decodeInput(input interface{}, typedInput interface{}) bool {
+	data, err := json.Marshal(input)
+	if err != nil {
+		return false
+	}
+	if err = json.Unmarshal(data, typedInput); err != nil {
+		return false
+	}
+	expected, err := toComparableJson(input)
+	if err != nil {
+		return false
+	}
+	actual, err := toComparableJson(typedInput)
+	return err == nil && reflect.DeepEqual(expected, actual)
+}
+
+func literals(first string, rest ...string) []string {
+	values := []string{goLiteral(first)}
+	for _, value := range rest {
+		values = append(values, goLiteral(value))
+	}
+	return values
+}
+
+// goLiteral returns the Go source of the value, zero values of the struct fields are omitted
+func goLiteral(value interface{}) string {
+	if value == nil {
+		return "nil"
+	}
+	return literalOf(reflect.ValueOf(value))
+}
+
+func literalOf(value reflect.Value) string {
+	switch value.Kind() {
+	case reflect.Interface:
+		if value.IsNil() {
+			return "nil"
+		}
+		return literalOf(value.Elem())
+	case reflect.Ptr:
+		if value.IsNil() {
+			return "nil"
+		}
+		return "&" + literalOf(value.Elem())
+	case reflect.String:
+		literal := strconv.Quote(value.String())
+		if value.Type().Name() != "string" {
+			return fmt.Sprintf("%s(%s)", value.Type().String(), literal)
+		}
+		return literal
+	case reflect.Bool:
+		return strconv.FormatBool(value.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return strconv.FormatInt(value.Int(), 10)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return strconv.FormatUint(value.Uint(), 10)
+	case reflect.Float32, reflect.Float64:
+		return strconv.FormatFloat(value.Float(), 'f', -1, 64)
+	case reflect.Slice, reflect.Array:
+		if value.Kind() == reflect.Slice && value.IsNil() {
+			return "nil"
+		}
+		elements := make([]string, value.Len())
+		for i := 0; i < value.Len(); i++ {
+			elements[i] = literalOf(value.Index(i))
+		}
+		return fmt.Sprintf("%s{\n%s}", value.Type().String(), joinElements(elements))
+	case reflect.Map:
+		if value.IsNil() {
+			return "nil"
+		}
+		keys := value.MapKeys()
+		sort.Slice(keys, func(i, j int) bool { return fmt.Sprint(keys[i].Interface()) < fmt.Sprint(keys[j].Interface()) })
+		elements := make([]string, len(keys))
+		for i, key := range keys {
+			elements[i] = literalOf(key) + ": " + literalOf(value.MapIndex(key))
+		}
+		return fmt.Sprintf("%s{\n%s}", value.Type().String(), joinElements(elements))
+	case reflect.Struct:
+		fields := make([]string, 0)
+		for i := 0; i < value.NumField(); i++ {
+			field := value.Type().Field(i)
+			if field.PkgPath != "" || isEmptyValue(value.Field(i)) {
+				continue
+			}
+			fields = append(fields, field.Name+": "+literalOf(value.Field(i)))
+		}
+		return fmt.Sprintf("%s{\n%s}", value.Type().String(), joinElements(fields))
+	}
+	return fmt.Sprintf("%#v", value.Interface())
+}
+
+func isEmptyValue(value reflect.Value) bool {
+	switch value.Kind() {
+	case reflect.Slice, reflect.Map:
+		return value.Len() == 0
+	}
+	return value.IsZero()
+}
+
+func joinElements(elements []string) string {
+	if len(elements) == 0 {
+		return ""
+	}
+	return strings.Join(elements, ",\n") + ",\n"
+}
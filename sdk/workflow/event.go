@@ -16,6 +16,9 @@ import (
 const (
 	sqsEventPrefix       = "sqs"
 	conductorEventPrefix = "conductor"
+	kafkaEventPrefix     = "kafka"
+	amqpEventPrefix      = "amqp"
+	natsEventPrefix      = "nats"
 )
 
 // EventTask Task to publish Events to external queuing systems like SQS, NATS, AMQP etc.
@@ -40,12 +43,40 @@ func NewConductorEventTask(taskRefName string, eventName string) *EventTask {
 	)
 }
 
+// NewKafkaEventTask publishes the input of the task to the Kafka topic
+func NewKafkaEventTask(taskRefName string, topic string) *EventTask {
+	return newEventTask(
+		taskRefName,
+		kafkaEventPrefix,
+		topic,
+	)
+}
+
+// NewAmqpEventTask publishes the input of the task to the AMQP queue
+func NewAmqpEventTask(taskRefName string, queueName string) *EventTask {
+	return newEventTask(
+		taskRefName,
+		amqpEventPrefix,
+		queueName,
+	)
+}
+
+// NewNatsEventTask publishes the input of the task to the NATS subject
+func NewNatsEventTask(taskRefName string, subject string) *EventTask {
+	return newEventTask(
+		taskRefName,
+		natsEventPrefix,
+		subject,
+	)
+}
+
 func newEventTask(taskRefName string, eventPrefix string, eventSuffix string) *EventTask {
 	return &EventTask{
 		Task: Task{
 			name:              taskRefName,
 			taskReferenceName: taskRefName,
 			taskType:          EVENT,
+			inputParameters:   map[string]interface{}{},
 		},
 		sink: eventPrefix + ":" + eventSuffix,
 	}
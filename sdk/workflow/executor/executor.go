@@ -22,6 +22,8 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/concurrency"
 	"github.com/conductor-sdk/conductor-go/sdk/event/queue"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/storage"
 
 	log "github.com/sirupsen/logrus"
 )
@@ -32,11 +34,15 @@ type WorkflowExecutor struct {
 	tagsClient     *client.TagsApiService
 	workflowClient *client.WorkflowResourceApiService
 	eventClient    *client.EventResourceApiService
+	schemaClient   *client.SchemaResourceApiService
 
 	workflowMonitor *WorkflowMonitor
 
 	startWorkflowBatchSize   int
 	waitForWorkflowBatchSize int
+
+	externalPayloadStorage *storage.ExternalPayloadStorage
+	validateInputSchema    bool
 }
 
 const (
@@ -61,6 +67,9 @@ func NewWorkflowExecutor(apiClient *client.APIClient) *WorkflowExecutor {
 	eventClient := client.EventResourceApiService{
 		APIClient: apiClient,
 	}
+	schemaClient := client.SchemaResourceApiService{
+		APIClient: apiClient,
+	}
 	startWorkflowBatchSize, err := getEnvInt(startWorkflowBatchSizeEnv)
 	if err != nil {
 		startWorkflowBatchSize = 256
@@ -75,6 +84,7 @@ func NewWorkflowExecutor(apiClient *client.APIClient) *WorkflowExecutor {
 		taskClient:               &taskClient,
 		workflowClient:           &workflowClient,
 		eventClient:              &eventClient,
+		schemaClient:             &schemaClient,
 		workflowMonitor:          NewWorkflowMonitor(&workflowClient),
 		startWorkflowBatchSize:   startWorkflowBatchSize,
 		waitForWorkflowBatchSize: waitForWorkflowBatchSize,
@@ -82,6 +92,20 @@ func NewWorkflowExecutor(apiClient *client.APIClient) *WorkflowExecutor {
 	return &workflowExecutor
 }
 
+// SetExternalStorageSettings enables the external payload storage.  The workflow inputs larger than the threshold are
+// uploaded when starting or executing the workflows, and the payloads stored externally are downloaded when getting
+// the executions
+func (e *WorkflowExecutor) SetExternalStorageSettings(externalStorageSettings *settings.ExternalStorageSettings) {
+	e.externalPayloadStorage = storage.NewExternalPayloadStorage(e.workflowClient.APIClient, externalStorageSettings)
+}
+
+// SetInputSchemaValidation when enabled, the input of the workflows is validated against the input schema of the
+// workflow before starting them, see schema.ValidateStartWorkflowRequest.  Requires fetching the workflow definition
+// and the schema from the server for each workflow started
+func (e *WorkflowExecutor) SetInputSchemaValidation(enabled bool) {
+	e.validateInputSchema = enabled
+}
+
 // RegisterWorkflow Registers the workflow on the server.  Overwrites if the flag is set.  If the 'overwrite' flag is not set
 // and the workflow definition differs from the one on the server, the call will fail with response code 409
 func (e *WorkflowExecutor) RegisterWorkflow(overwrite bool, workflow *model.WorkflowDef) error {
@@ -105,6 +129,12 @@ func (e *WorkflowExecutor) ExecuteWorkflowWithReturnStrategy(startWorkflowReques
 	return e.ExecuteWorkflowWithReturnStrategyWithContext(context.Background(), startWorkflowRequest, consistency, returnStrategy, waitUntilTask, waitForSec)
 }
 
+// TestWorkflow runs the workflow on the server using the mocked task outputs in testRequest instead of
+// scheduling the tasks to workers.  Returns the resulting workflow execution including all the tasks
+func (e *WorkflowExecutor) TestWorkflow(testRequest *model.WorkflowTestRequest) (*model.Workflow, error) {
+	return e.TestWorkflowWithContext(context.Background(), testRequest)
+}
+
 // MonitorExecution monitors the workflow execution
 // Returns the channel with the execution result of the workflow
 // Note: Channels will continue to grow if the workflows do not complete and/or are not taken out
@@ -354,6 +384,41 @@ func (e *WorkflowExecutor) Signal(workflowId string, status model.WorkflowStatus
 	return e.SignalWithContext(context.Background(), workflowId, status, output, opts...)
 }
 
+// UpdateVariables of the running workflow, the variables not in the map are left unchanged
+func (e *WorkflowExecutor) UpdateVariables(workflowId string, variables map[string]interface{}) error {
+	return e.UpdateVariablesWithContext(context.Background(), workflowId, variables)
+}
+
+// Enterprise Feature: This feature requires Orkes Conductor Enterprise license, NOT AVAILABLE in OSS.
+// UpdateState updates the in progress task taskRefName with the result and the workflow variables in a single call,
+// then waits until one of the waitUntilTaskRefs tasks is reached.  The response depends on the returnStrategy
+func (e *WorkflowExecutor) UpdateState(workflowId string, taskRefName string, result *model.TaskResult, variables map[string]interface{}, waitUntilTaskRefs []string, returnStrategy model.ReturnStrategy) (*model.SignalResponse, error) {
+	return e.UpdateStateWithContext(context.Background(), workflowId, taskRefName, result, variables, waitUntilTaskRefs, returnStrategy)
+}
+
+// Enterprise Feature: This feature requires Orkes Conductor Enterprise license, NOT AVAILABLE in OSS.
+// SignalByRef sets the status and output of the task taskRefName, e.g. a WAIT or HUMAN task, and waits until one of the
+// waitUntilTaskRefs tasks is reached.  The output can be a struct serializable to a JSON map
+func (e *WorkflowExecutor) SignalByRef(workflowId string, taskRefName string, status model.TaskResultStatus, output interface{}, returnStrategy model.ReturnStrategy, waitUntilTaskRefs ...string) (*model.SignalResponse, error) {
+	return e.SignalByRefWithContext(context.Background(), workflowId, taskRefName, status, output, returnStrategy, waitUntilTaskRefs...)
+}
+
+// CompleteByRef completes the task taskRefName with the output
+func (e *WorkflowExecutor) CompleteByRef(workflowId string, taskRefName string, output interface{}) error {
+	return e.CompleteByRefWithContext(context.Background(), workflowId, taskRefName, output)
+}
+
+// FailByRef fails the pending task taskRefName with the reason for incompletion, the task is retried as per its
+// definition
+func (e *WorkflowExecutor) FailByRef(workflowId string, taskRefName string, reason string) error {
+	return e.FailByRefWithContext(context.Background(), workflowId, taskRefName, reason)
+}
+
+// JumpToTask moves the execution of the running workflow to the task taskRefName, using the input for the task
+func (e *WorkflowExecutor) JumpToTask(workflowId string, taskRefName string, input interface{}) error {
+	return e.JumpToTaskWithContext(context.Background(), workflowId, taskRefName, input)
+}
+
 func getEnvStr(key string) (string, error) {
 	v := os.Getenv(key)
 	if v == "" {
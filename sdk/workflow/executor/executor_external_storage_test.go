@@ -0,0 +1,95 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+// memoryPayloadStore keeps the uploaded payloads by location
+type memoryPayloadStore struct {
+	mutex    sync.Mutex
+	payloads map[string][]byte
+}
+
+func (s *memoryPayloadStore) Upload(ctx context.Context, uri string, payload []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	s.payloads[uri] = payload
+	return nil
+}
+
+func (s *memoryPayloadStore) Download(ctx context.Context, uri string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	return s.payloads[uri], nil
+}
+
+func TestStartPathsUploadLargeInputs(t *testing.T) {
+	var mutex sync.Mutex
+	started := make([]model.StartWorkflowRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.URL.Path == "/workflow/externalstoragelocation":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(model.ExternalStorageLocation{Path: "workflow_input.json", Uri: "memory://workflow_input.json"})
+		case r.URL.Path == "/workflow":
+			var request model.StartWorkflowRequest
+			data, _ := io.ReadAll(r.Body)
+			json.Unmarshal(data, &request)
+			mutex.Lock()
+			started = append(started, request)
+			mutex.Unlock()
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte("wf_1"))
+		case strings.HasPrefix(r.URL.Path, "/workflow/execute/"):
+			var request model.StartWorkflowRequest
+			json.NewDecoder(r.Body).Decode(&request)
+			mutex.Lock()
+			started = append(started, request)
+			mutex.Unlock()
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(model.WorkflowRun{WorkflowId: "wf_1"})
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	store := &memoryPayloadStore{payloads: map[string][]byte{}}
+	externalStorageSettings := settings.NewExternalPayloadStorageSettings(1, 4, store)
+	externalStorageSettings.WorkflowInputPayloadThresholdKB = 1
+	executor := NewWorkflowExecutor(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+	executor.SetExternalStorageSettings(externalStorageSettings)
+
+	newRequest := func() *model.StartWorkflowRequest {
+		return &model.StartWorkflowRequest{Name: "orders", Version: 1, Input: map[string]interface{}{"items": strings.Repeat("x", 2048)}}
+	}
+	request := newRequest()
+	_, err := executor.StartWorkflow(request)
+	assert.NoError(t, err)
+	_, err = executor.ExecuteWorkflow(request, "")
+	assert.NoError(t, err)
+	for _, runningWorkflow := range executor.StartWorkflows(false, newRequest()) {
+		assert.NoError(t, runningWorkflow.Err)
+	}
+
+	assert.Len(t, started, 3)
+	for _, startedRequest := range started {
+		assert.Nil(t, startedRequest.Input)
+		assert.Equal(t, "workflow_input.json", startedRequest.ExternalInputPayloadStoragePath)
+	}
+	assert.Contains(t, string(store.payloads["memory://workflow_input.json"]), "xxxx")
+	// the request of the caller is not modified
+	assert.NotNil(t, request.Input)
+}
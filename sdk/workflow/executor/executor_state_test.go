@@ -0,0 +1,148 @@
+package executor
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+type recordedRequest struct {
+	method string
+	path   string
+	query  map[string][]string
+	body   map[string]interface{}
+}
+
+func newTestExecutor(t *testing.T, response interface{}) (*WorkflowExecutor, *[]recordedRequest) {
+	requests := make([]recordedRequest, 0)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		data, _ := io.ReadAll(r.Body)
+		var body map[string]interface{}
+		json.Unmarshal(data, &body)
+		requests = append(requests, recordedRequest{method: r.Method, path: r.URL.Path, query: r.URL.Query(), body: body})
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(response)
+	}))
+	t.Cleanup(server.Close)
+	return NewWorkflowExecutor(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))), &requests
+}
+
+func TestUpdateVariables(t *testing.T) {
+	executor, requests := newTestExecutor(t, model.WorkflowRun{WorkflowId: "wf_1"})
+
+	assert.NoError(t, executor.UpdateVariables("wf_1", map[string]interface{}{"approved": true}))
+	assert.Len(t, *requests, 1)
+	request := (*requests)[0]
+	assert.Equal(t, "POST", request.method)
+	assert.Equal(t, "/workflow/wf_1/state", request.path)
+	assert.NotEmpty(t, request.query["requestId"][0])
+	assert.Equal(t, map[string]interface{}{"variables": map[string]interface{}{"approved": true}}, request.body)
+
+	assert.NoError(t, executor.UpdateVariables("wf_1", nil))
+	assert.Len(t, *requests, 1)
+}
+
+func TestUpdateState(t *testing.T) {
+	executor, requests := newTestExecutor(t, model.SignalResponse{
+		ResponseType:      model.ReturnBlockingTask,
+		WorkflowId:        "wf_1",
+		TaskId:            "task_2",
+		ReferenceTaskName: "review",
+	})
+
+	result := &model.TaskResult{Status: model.CompletedTask, OutputData: map[string]interface{}{"amount": 10}}
+	response, err := executor.UpdateState("wf_1", "collect", result, map[string]interface{}{"step": 2}, []string{"review", "notify"}, model.ReturnBlockingTask)
+	assert.NoError(t, err)
+	task, err := response.GetBlockingTask()
+	assert.NoError(t, err)
+	assert.Equal(t, "review", task.ReferenceTaskName)
+	assert.Empty(t, result.WorkflowInstanceId)
+
+	request := (*requests)[0]
+	assert.Equal(t, "/workflow/wf_1/state", request.path)
+	assert.Equal(t, []string{"review,notify"}, request.query["waitUntilTaskRef"])
+	assert.Equal(t, []string{"BLOCKING_TASK"}, request.query["returnStrategy"])
+	assert.Equal(t, "collect", request.body["taskReferenceName"])
+	assert.Equal(t, map[string]interface{}{"step": float64(2)}, request.body["variables"])
+	taskResult := request.body["taskResult"].(map[string]interface{})
+	assert.Equal(t, "wf_1", taskResult["workflowInstanceId"])
+	assert.Equal(t, "COMPLETED", taskResult["status"])
+
+	_, err = executor.UpdateState("wf_1", "", result, nil, nil, "")
+	assert.EqualError(t, err, "task reference name is required to update the task")
+}
+
+func TestSignalByRef(t *testing.T) {
+	type Approval struct {
+		Approved bool `json:"approved"`
+	}
+	executor, requests := newTestExecutor(t, model.SignalResponse{ResponseType: model.ReturnTargetWorkflow, WorkflowId: "wf_1", Status: model.RunningWorkflow})
+
+	response, err := executor.SignalByRef("wf_1", "wait_for_approval", model.CompletedTask, Approval{Approved: true}, "")
+	assert.NoError(t, err)
+	assert.True(t, response.IsTargetWorkflow())
+	request := (*requests)[0]
+	assert.Equal(t, []string{"TARGET_WORKFLOW"}, request.query["returnStrategy"])
+	assert.Nil(t, request.query["waitUntilTaskRef"])
+	assert.Equal(t, map[string]interface{}{"approved": true}, request.body["taskResult"].(map[string]interface{})["outputData"])
+
+	assert.NoError(t, executor.CompleteByRef("wf_1", "wait_for_approval", Approval{Approved: true}))
+	assert.Equal(t, "/tasks/wf_1/wait_for_approval/COMPLETED", (*requests)[1].path)
+}
+
+func TestFailByRef(t *testing.T) {
+	var taskResults []model.TaskResult
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/workflow/wf_1":
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(model.Workflow{WorkflowId: "wf_1", Tasks: []model.Task{
+				{TaskId: "task_1", ReferenceTaskName: "wait_for_approval", Status: model.FailedTask},
+				{TaskId: "task_2", ReferenceTaskName: "wait_for_approval", Status: model.InProgressTask},
+				{TaskId: "task_3", ReferenceTaskName: "notify", Status: model.ScheduledTask},
+			}})
+		case r.Method == http.MethodPost && r.URL.Path == "/tasks":
+			var taskResult model.TaskResult
+			json.NewDecoder(r.Body).Decode(&taskResult)
+			taskResults = append(taskResults, taskResult)
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(taskResult.TaskId))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+	executor := NewWorkflowExecutor(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+
+	assert.NoError(t, executor.FailByRef("wf_1", "wait_for_approval", "rejected"))
+	assert.Len(t, taskResults, 1)
+	assert.Equal(t, "task_2", taskResults[0].TaskId)
+	assert.Equal(t, "wf_1", taskResults[0].WorkflowInstanceId)
+	assert.Equal(t, model.FailedTask, taskResults[0].Status)
+	assert.Equal(t, "rejected", taskResults[0].ReasonForIncompletion)
+	assert.Empty(t, taskResults[0].OutputData)
+
+	assert.EqualError(t, executor.FailByRef("wf_1", "charge", "rejected"), "no pending task charge in workflow wf_1")
+	assert.Len(t, taskResults, 1)
+}
+
+func TestJumpToTask(t *testing.T) {
+	executor, requests := newTestExecutor(t, map[string]interface{}{})
+
+	assert.NoError(t, executor.JumpToTask("wf_1", "retry_payment", map[string]interface{}{"attempt": 2}))
+	request := (*requests)[0]
+	assert.Equal(t, "POST", request.method)
+	assert.Equal(t, "/workflow/wf_1/jump/retry_payment", request.path)
+	assert.Equal(t, map[string]interface{}{"attempt": float64(2)}, request.body)
+
+	_, err := client.NewWorkflowClient(client.NewAPIClient(nil, settings.NewHttpSettings("http://localhost"))).JumpToTask(context.Background(), nil, "wf_1", nil)
+	assert.EqualError(t, err, "task reference name is required")
+}
@@ -8,6 +8,8 @@ import (
 	"github.com/conductor-sdk/conductor-go/sdk/client"
 	"github.com/conductor-sdk/conductor-go/sdk/event/queue"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/schema"
+	"github.com/google/uuid"
 	log "github.com/sirupsen/logrus"
 	"net/http"
 	"strings"
@@ -36,6 +38,10 @@ func (e *WorkflowExecutor) ExecuteWorkflowWithContext(ctx context.Context, start
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	startWorkflowRequest, err = e.withExternalInput(ctx, startWorkflowRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	requestId := ""
 	version := startWorkflowRequest.Version
@@ -51,6 +57,10 @@ func (e *WorkflowExecutor) ExecuteWorkflowWithReturnStrategyWithContext(ctx cont
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	startWorkflowRequest, err = e.withExternalInput(ctx, startWorkflowRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	resp, err := e.workflowClient.ExecuteWorkflowWithReturnStrategy(ctx, *startWorkflowRequest, client.ExecuteWorkflowOpts{
 		ReturnStrategy:   returnStrategy,
@@ -70,6 +80,10 @@ func (e *WorkflowExecutor) ExecuteAndGetTargetWithContext(ctx context.Context, s
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	startWorkflowRequest, err = e.withExternalInput(ctx, startWorkflowRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	requestId := ""
 	version := startWorkflowRequest.Version
@@ -93,6 +107,10 @@ func (e *WorkflowExecutor) ExecuteAndGetBlockingWorkflowWithContext(ctx context.
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	startWorkflowRequest, err = e.withExternalInput(ctx, startWorkflowRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	requestId := ""
 	version := startWorkflowRequest.Version
@@ -116,6 +134,10 @@ func (e *WorkflowExecutor) ExecuteAndGetBlockingTaskWithContext(ctx context.Cont
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	startWorkflowRequest, err = e.withExternalInput(ctx, startWorkflowRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	requestId := ""
 	version := startWorkflowRequest.Version
@@ -140,6 +162,10 @@ func (e *WorkflowExecutor) ExecuteAndGetBlockingTaskInputWithContext(ctx context
 	if err := ctx.Err(); err != nil {
 		return nil, err
 	}
+	startWorkflowRequest, err = e.withExternalInput(ctx, startWorkflowRequest)
+	if err != nil {
+		return nil, err
+	}
 
 	requestId := ""
 	version := startWorkflowRequest.Version
@@ -159,11 +185,34 @@ func (e *WorkflowExecutor) ExecuteAndGetBlockingTaskInputWithContext(ctx context
 	return &taskRun, nil
 }
 
+func (e *WorkflowExecutor) TestWorkflowWithContext(ctx context.Context, testRequest *model.WorkflowTestRequest) (*model.Workflow, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	workflow, _, err := e.workflowClient.TestWorkflow(ctx, *testRequest)
+	if err != nil {
+		return nil, err
+	}
+	return &workflow, nil
+}
+
 func (e *WorkflowExecutor) StartWorkflowWithContext(ctx context.Context, startWorkflowRequest *model.StartWorkflowRequest) (workflowId string, err error) {
 	if err := ctx.Err(); err != nil {
 		return "", err
 	}
 
+	if e.validateInputSchema {
+		if err := schema.ValidateStartWorkflowRequest(ctx, e.metadataClient, e.schemaClient, startWorkflowRequest); err != nil {
+			return "", err
+		}
+	}
+
+	startWorkflowRequest, err = e.withExternalInput(ctx, startWorkflowRequest)
+	if err != nil {
+		return "", err
+	}
+
 	id, _, err := e.workflowClient.StartWorkflowWithRequest(
 		ctx,
 		*startWorkflowRequest,
@@ -208,6 +257,11 @@ func (e *WorkflowExecutor) getWorkflowWithContext(ctx context.Context, retry int
 		}
 	}
 
+	if e.externalPayloadStorage != nil {
+		if err := e.externalPayloadStorage.DownloadWorkflowPayloads(ctx, &workflow); err != nil {
+			return nil, err
+		}
+	}
 	return &workflow, nil
 }
 
@@ -471,6 +525,15 @@ func (e *WorkflowExecutor) GetTaskWithContext(ctx context.Context, taskId string
 		return nil, err
 	}
 
+	if e.externalPayloadStorage != nil {
+		if err := e.externalPayloadStorage.DownloadTaskInput(ctx, &t); err != nil {
+			return nil, err
+		}
+		if err := e.externalPayloadStorage.DownloadTaskOutput(ctx, &t); err != nil {
+			return nil, err
+		}
+	}
+
 	return &t, nil
 }
 
@@ -556,6 +619,116 @@ func (e *WorkflowExecutor) SignalWithContext(ctx context.Context, workflowId str
 	return signalResponse, nil
 }
 
+// UpdateVariablesWithContext updates the variables of the running workflow
+func (e *WorkflowExecutor) UpdateVariablesWithContext(ctx context.Context, workflowId string, variables map[string]interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	if len(variables) == 0 {
+		return nil
+	}
+
+	_, _, err := e.workflowClient.UpdateWorkflowAndTaskState(ctx, model.WorkflowStateUpdate{Variables: variables}, uuid.New().String(), workflowId, nil)
+	return err
+}
+
+// Enterprise Feature: This feature requires Orkes Conductor Enterprise license, NOT AVAILABLE in OSS.
+// UpdateStateWithContext updates the task and the variables of the workflow and waits for the waitUntilTaskRefs
+func (e *WorkflowExecutor) UpdateStateWithContext(ctx context.Context, workflowId string, taskRefName string, result *model.TaskResult, variables map[string]interface{}, waitUntilTaskRefs []string, returnStrategy model.ReturnStrategy) (*model.SignalResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	stateUpdate := model.WorkflowStateUpdate{Variables: variables}
+	if result != nil {
+		if taskRefName == "" {
+			return nil, fmt.Errorf("task reference name is required to update the task")
+		}
+		taskResult := *result
+		if taskResult.WorkflowInstanceId == "" {
+			taskResult.WorkflowInstanceId = workflowId
+		}
+		stateUpdate.TaskReferenceName = taskRefName
+		stateUpdate.TaskResult = &taskResult
+	}
+
+	return e.workflowClient.UpdateWorkflowStateWithReturnStrategy(ctx, stateUpdate, workflowId, client.UpdateWorkflowStateOpts{
+		RequestId:        uuid.New().String(),
+		WaitUntilTaskRef: waitUntilTaskRefs,
+		ReturnStrategy:   returnStrategy,
+	})
+}
+
+// Enterprise Feature: This feature requires Orkes Conductor Enterprise license, NOT AVAILABLE in OSS.
+// SignalByRefWithContext sets the status and output of the task and waits for the waitUntilTaskRefs
+func (e *WorkflowExecutor) SignalByRefWithContext(ctx context.Context, workflowId string, taskRefName string, status model.TaskResultStatus, output interface{}, returnStrategy model.ReturnStrategy, waitUntilTaskRefs ...string) (*model.SignalResponse, error) {
+	if err := ctx.Err(); err != nil {
+		return nil, err
+	}
+
+	outputData, err := model.ConvertToMap(output)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &model.TaskResult{Status: status, OutputData: outputData}
+	return e.UpdateStateWithContext(ctx, workflowId, taskRefName, result, nil, waitUntilTaskRefs, returnStrategy)
+}
+
+// CompleteByRefWithContext completes the task taskRefName with the output
+func (e *WorkflowExecutor) CompleteByRefWithContext(ctx context.Context, workflowId string, taskRefName string, output interface{}) error {
+	return e.UpdateTaskByRefNameWithContext(ctx, taskRefName, workflowId, model.CompletedTask, output)
+}
+
+// FailByRefWithContext fails the pending task taskRefName with the reason for incompletion
+func (e *WorkflowExecutor) FailByRefWithContext(ctx context.Context, workflowId string, taskRefName string, reason string) error {
+	workflow, err := e.GetWorkflowWithContext(ctx, workflowId, true)
+	if err != nil {
+		return err
+	}
+	task := pendingTask(workflow, taskRefName)
+	if task == nil {
+		return fmt.Errorf("no pending task %s in workflow %s", taskRefName, workflowId)
+	}
+
+	taskResult := model.NewTaskResult(task.TaskId, workflowId)
+	taskResult.Status = model.FailedTask
+	taskResult.ReasonForIncompletion = reason
+	_, _, err = e.taskClient.UpdateTask(ctx, taskResult)
+	return err
+}
+
+// pendingTask the last scheduled or in progress task taskRefName of the workflow, nil if there is none
+func pendingTask(workflow *model.Workflow, taskRefName string) *model.Task {
+	for i := len(workflow.Tasks) - 1; i >= 0; i-- {
+		task := &workflow.Tasks[i]
+		if task.ReferenceTaskName == taskRefName && (task.Status == model.ScheduledTask || task.Status == model.InProgressTask) {
+			return task
+		}
+	}
+	return nil
+}
+
+// JumpToTaskWithContext moves the execution of the running workflow to the task taskRefName
+func (e *WorkflowExecutor) JumpToTaskWithContext(ctx context.Context, workflowId string, taskRefName string, input interface{}) error {
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+
+	inputData, err := model.ConvertToMap(input)
+	if err != nil {
+		return err
+	}
+	if inputData == nil {
+		inputData = map[string]interface{}{}
+	}
+
+	_, err = e.workflowClient.JumpToTask(ctx, inputData, workflowId, &client.WorkflowResourceApiJumpToTaskOpts{
+		TaskReferenceName: optional.NewString(taskRefName),
+	})
+	return err
+}
+
 func getTaskResultFromOutput(taskId string, workflowInstanceId string, taskExecutionOutput interface{}) (*model.TaskResult, error) {
 	taskResult, ok := taskExecutionOutput.(*model.TaskResult)
 	if !ok {
@@ -571,6 +744,19 @@ func getTaskResultFromOutput(taskId string, workflowInstanceId string, taskExecu
 	return taskResult, nil
 }
 
+// withExternalInput the request with the input stored externally when it is larger than the threshold of the external
+// storage, the request of the caller is not modified
+func (e *WorkflowExecutor) withExternalInput(ctx context.Context, startWorkflowRequest *model.StartWorkflowRequest) (*model.StartWorkflowRequest, error) {
+	if e.externalPayloadStorage == nil {
+		return startWorkflowRequest, nil
+	}
+	request := *startWorkflowRequest
+	if err := e.externalPayloadStorage.UploadWorkflowInput(ctx, &request); err != nil {
+		return nil, err
+	}
+	return &request, nil
+}
+
 func (e *WorkflowExecutor) executeWorkflowWithContext(ctx context.Context, workflow *model.WorkflowDef, request *model.StartWorkflowRequest) (workflowId string, err error) {
 	startWorkflowRequest := model.StartWorkflowRequest{
 		Name:                            request.Name,
@@ -584,6 +770,11 @@ func (e *WorkflowExecutor) executeWorkflowWithContext(ctx context.Context, workf
 	if workflow != nil {
 		startWorkflowRequest.WorkflowDef = workflow
 	}
+	if e.externalPayloadStorage != nil {
+		if err := e.externalPayloadStorage.UploadWorkflowInput(ctx, &startWorkflowRequest); err != nil {
+			return "", err
+		}
+	}
 	workflowId, response, err := e.workflowClient.StartWorkflowWithRequest(
 		ctx,
 		startWorkflowRequest,
@@ -0,0 +1,304 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import (
+	"encoding/json"
+	"reflect"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow/executor"
+)
+
+const switchCaseValueParam = "switchCaseValue"
+
+// FromWorkflowDef reconstructs the workflow from its definition, e.g. a workflow definition stored as JSON.
+// Tasks are converted to their typed counterparts (SimpleTask, SwitchTask, ForkTask etc.) so that they can be modified
+// using the builders.  Tasks with unknown types, or using properties that the builders do not support, are kept as RawTask.
+// Calling ToWorkflowDef on the returned workflow produces a definition equivalent to workflowDef
+func FromWorkflowDef(workflowDef *model.WorkflowDef) *ConductorWorkflow {
+	return FromWorkflowDefWithExecutor(nil, workflowDef)
+}
+
+// FromWorkflowDefWithExecutor same as FromWorkflowDef, the returned workflow uses the executor to register and execute
+func FromWorkflowDefWithExecutor(executor *executor.WorkflowExecutor, workflowDef *model.WorkflowDef) *ConductorWorkflow {
+	workflow := NewConductorWorkflow(executor)
+	workflow.name = workflowDef.Name
+	workflow.version = workflowDef.Version
+	workflow.description = workflowDef.Description
+	workflow.ownerEmail = workflowDef.OwnerEmail
+	workflow.timeoutPolicy = TimeoutPolicy(workflowDef.TimeoutPolicy)
+	workflow.timeoutSeconds = workflowDef.TimeoutSeconds
+	workflow.failureWorkflow = workflowDef.FailureWorkflow
+	workflow.inputParameters = workflowDef.InputParameters
+	workflow.outputParameters = workflowDef.OutputParameters
+	workflow.inputTemplate = workflowDef.InputTemplate
+	workflow.variables = workflowDef.Variables
+	workflow.restartable = workflowDef.Restartable
+	workflow.workflowStatusListenerEnabled = workflowDef.WorkflowStatusListenerEnabled
+	workflow.tags = workflowDef.Tags
+	workflow.overwiteTags = workflowDef.OverwriteTags
+	workflow.inputSchema = workflowDef.InputSchema
+	workflow.outputSchema = workflowDef.OutputSchema
+	workflow.enforceSchema = workflowDef.EnforceSchema
+	workflow.tasks = fromWorkflowTasks(workflowDef.Tasks)
+	return workflow
+}
+
+// FromWorkflowTask converts a single workflow task to its typed counterpart.
+// FORK_JOIN and FORK_JOIN_DYNAMIC are followed by a JOIN in the definition, use FromWorkflowDef to convert them
+func FromWorkflowTask(workflowTask model.WorkflowTask) TaskInterface {
+	return fromWorkflowTasks([]model.WorkflowTask{workflowTask})[0]
+}
+
+func fromWorkflowTasks(workflowTasks []model.WorkflowTask) []TaskInterface {
+	tasks := make([]TaskInterface, 0, len(workflowTasks))
+	for i := 0; i < len(workflowTasks); i++ {
+		workflowTask := workflowTasks[i]
+		var join *model.WorkflowTask
+		if i+1 < len(workflowTasks) && workflowTasks[i+1].Type_ == string(JOIN) {
+			join = &workflowTasks[i+1]
+		}
+		task, consumedJoin := fromWorkflowTask(workflowTask, join)
+		tasks = append(tasks, task)
+		if consumedJoin {
+			i++
+		}
+	}
+	return tasks
+}
+
+// fromWorkflowTask converts the task, falling back to RawTask when the typed task does not serialize to the same definition.
+// Returns true if the JOIN following a fork was consumed as part of the typed fork task
+func fromWorkflowTask(workflowTask model.WorkflowTask, join *model.WorkflowTask) (TaskInterface, bool) {
+	task := toTypedTask(workflowTask, join)
+	if task == nil {
+		return NewRawTask(workflowTask), false
+	}
+	expected := []model.WorkflowTask{workflowTask}
+	if isFork(workflowTask) {
+		if join == nil {
+			return NewRawTask(workflowTask), false
+		}
+		expected = append(expected, *join)
+	}
+	if !equivalentWorkflowTasks(expected, task.toWorkflowTask()) {
+		return NewRawTask(workflowTask), false
+	}
+	return task, len(expected) == 2
+}
+
+func isFork(workflowTask model.WorkflowTask) bool {
+	return workflowTask.Type_ == string(FORK_JOIN) || workflowTask.Type_ == string(FORK_JOIN_DYNAMIC)
+}
+
+func toTypedTask(workflowTask model.WorkflowTask, join *model.WorkflowTask) TaskInterface {
+	base := Task{
+		name:              workflowTask.Name,
+		taskReferenceName: workflowTask.TaskReferenceName,
+		description:       workflowTask.Description,
+		taskType:          TaskType(workflowTask.Type_),
+		optional:          workflowTask.Optional,
+		inputParameters:   copyInputParameters(workflowTask.InputParameters),
+		cacheConfig:       workflowTask.CacheConfig,
+	}
+	switch TaskType(workflowTask.Type_) {
+	case SIMPLE:
+		return &SimpleTask{Task: base, workflowTask: workflowTask}
+	case HTTP:
+		return &HttpTask{SimpleTask{Task: base, workflowTask: workflowTask}}
+	case HTTP_POLL:
+		return &HttpPollTask{SimpleTask{Task: base, workflowTask: workflowTask}}
+	case SWITCH:
+		return toSwitchTask(base, workflowTask)
+	case DO_WHILE:
+		return &DoWhileTask{
+			Task:          base,
+			loopCondition: workflowTask.LoopCondition,
+			loopOver:      fromWorkflowTasks(workflowTask.LoopOver),
+		}
+	case FORK_JOIN:
+		forkedTasks := make([][]TaskInterface, len(workflowTask.ForkTasks))
+		for i, branch := range workflowTask.ForkTasks {
+			forkedTasks[i] = fromWorkflowTasks(branch)
+		}
+		fork := &ForkTask{Task: base, forkedTasks: forkedTasks}
+		if join != nil {
+			fork.join = toJoinTask(*join)
+		}
+		return fork
+	case FORK_JOIN_DYNAMIC:
+		return &DynamicForkTask{Task: base}
+	case JOIN:
+		return toJoinTask(workflowTask)
+	case SUB_WORKFLOW:
+		return toSubWorkflowTask(base, workflowTask)
+	case DYNAMIC:
+		return &DynamicTask{Task: base}
+	case EVENT:
+		return &EventTask{Task: base, sink: workflowTask.Sink}
+	case START_WORKFLOW:
+		return &StartWorkflowTask{Task: base}
+	case WAIT:
+		return &WaitTask{base}
+	case HUMAN:
+		return &HumanTask{base}
+	case INLINE:
+		return &InlineTask{base}
+	case UPDATE:
+		return &UpdateTask{base}
+	case TERMINATE:
+		return &TerminateTask{base}
+	case KAFKA_PUBLISH:
+		return &KafkaPublishTask{base}
+	case JSON_JQ_TRANSFORM:
+		return &JQTask{base}
+	case SET_VARIABLE:
+		return &SetVariableTask{base}
+	case NOOP:
+		return &NoopTask{base}
+	case GET_WORKFLOW:
+		return &GetWorkflowTask{base}
+	case BUSINESS_RULE:
+		return &BusinessRuleTask{base}
+	case GRPC:
+		return &GrpcTask{base}
+	case JDBC:
+		return &JdbcTask{base}
+	case QUERY_PROCESSOR:
+		return &QueryProcessorTask{base}
+	case LLM_TEXT_COMPLETE:
+		return &LlmTextCompleteTask{base}
+	case LLM_CHAT_COMPLETE:
+		return &LlmChatCompleteTask{base}
+	case LLM_GENERATE_EMBEDDINGS:
+		return &LlmGenerateEmbeddingsTask{base}
+	case LLM_STORE_EMBEDDINGS:
+		return &LlmStoreEmbeddingsTask{base}
+	case LLM_INDEX_DOCUMENT:
+		return &LlmIndexDocumentTask{base}
+	case LLM_SEARCH_INDEX:
+		return &LlmSearchIndexTask{base}
+	}
+	return nil
+}
+
+func toSwitchTask(base Task, workflowTask model.WorkflowTask) *SwitchTask {
+	switchTask := &SwitchTask{
+		Task:          base,
+		DecisionCases: make(map[string][]TaskInterface),
+		defaultCase:   fromWorkflowTasks(workflowTask.DefaultCase),
+		evaluatorType: workflowTask.EvaluatorType,
+	}
+	for caseValue, tasks := range workflowTask.DecisionCases {
+		switchTask.DecisionCases[caseValue] = fromWorkflowTasks(tasks)
+	}
+	caseValue, isString := workflowTask.InputParameters[switchCaseValueParam].(string)
+	if workflowTask.EvaluatorType == EvaluatorTypeValueParam && workflowTask.Expression == switchCaseValueParam && isString {
+		delete(switchTask.inputParameters, switchCaseValueParam)
+		switchTask.expression = caseValue
+	} else {
+		// expression is used as is along with the evaluator type
+		switchTask.expression = workflowTask.Expression
+		switchTask.useJavascript = true
+	}
+	return switchTask
+}
+
+func toJoinTask(workflowTask model.WorkflowTask) *JoinTask {
+	return &JoinTask{
+		Task: Task{
+			name:              workflowTask.Name,
+			taskReferenceName: workflowTask.TaskReferenceName,
+			description:       workflowTask.Description,
+			taskType:          JOIN,
+			optional:          workflowTask.Optional,
+			inputParameters:   copyInputParameters(workflowTask.InputParameters),
+		},
+		joinOn: workflowTask.JoinOn,
+	}
+}
+
+func toSubWorkflowTask(base Task, workflowTask model.WorkflowTask) TaskInterface {
+	params := workflowTask.SubWorkflowParam
+	if params == nil {
+		return nil
+	}
+	subWorkflow := &SubWorkflowTask{
+		Task:            base,
+		workflowName:    params.Name,
+		version:         params.Version,
+		taskToDomainMap: params.TaskToDomain,
+	}
+	if params.WorkflowDefinition != nil {
+		subWorkflow.workflow = FromWorkflowDef(params.WorkflowDefinition)
+	}
+	return subWorkflow
+}
+
+func copyInputParameters(inputParameters map[string]interface{}) map[string]interface{} {
+	copied := make(map[string]interface{}, len(inputParameters))
+	for k, v := range inputParameters {
+		copied[k] = v
+	}
+	return copied
+}
+
+// equivalentWorkflowTasks compares the JSON representation of the tasks, which is what gets sent to the server
+func equivalentWorkflowTasks(expected []model.WorkflowTask, actual []model.WorkflowTask) bool {
+	expectedJson, err := toComparableJson(expected)
+	if err != nil {
+		return false
+	}
+	actualJson, err := toComparableJson(actual)
+	if err != nil {
+		return false
+	}
+	return reflect.DeepEqual(expectedJson, actualJson)
+}
+
+// toComparableJson decodes the JSON representation of the value, dropping empty arrays and objects of the properties
+// as the server treats them the same as absent properties
+func toComparableJson(value interface{}) (interface{}, error) {
+	data, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var comparable interface{}
+	err = json.Unmarshal(data, &comparable)
+	return withoutEmptyProperties(comparable), err
+}
+
+func withoutEmptyProperties(value interface{}) interface{} {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		for k, v := range typed {
+			typed[k] = withoutEmptyProperties(v)
+			if isEmptyCollection(typed[k]) {
+				delete(typed, k)
+			}
+		}
+	case []interface{}:
+		for i, v := range typed {
+			typed[i] = withoutEmptyProperties(v)
+		}
+	}
+	return value
+}
+
+func isEmptyCollection(value interface{}) bool {
+	switch typed := value.(type) {
+	case map[string]interface{}:
+		return len(typed) == 0
+	case []interface{}:
+		return len(typed) == 0
+	}
+	return false
+}
@@ -0,0 +1,204 @@
+package workflow
+
+import (
+	"encoding/json"
+	"go/parser"
+	"go/token"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func newTestWorkflow() *ConductorWorkflow {
+	return NewConductorWorkflow(nil).
+		Name("order_fulfillment").
+		Version(2).
+		Description("fulfills the orders").
+		OwnerEmail("orders@example.com").
+		TimeoutPolicy(TimeOutWorkflow, 3600).
+		InputParameters("orderId").
+		OutputParameters(map[string]interface{}{"status": "${ship_ref.output.status}"}).
+		Add(NewSimpleTask("get_order", "get_order_ref").
+			Input("orderId", "${workflow.input.orderId}").
+			RetryPolicy(3, "FIXED", 10, 1)).
+		Add(NewSwitchTask("payment_ref", "${get_order_ref.output.paymentType}").
+			SwitchCase("CARD", NewSimpleTask("charge_card", "charge_card_ref")).
+			DefaultCase(NewTerminateTask("unsupported_ref", model.FailedWorkflow, "unsupported payment"))).
+		Add(NewForkTask("fork_ref",
+			[]TaskInterface{NewHttpTask("notify_ref", &HttpInput{Uri: "https://example.com/notify", Method: POST})},
+			[]TaskInterface{NewWaitTask("wait_ref")},
+		)).
+		Add(NewDoWhileTask("ship_loop_ref", "$.ship_ref['done'] == true", NewSimpleTask("ship", "ship_ref"))).
+		Add(NewRawTask(model.WorkflowTask{
+			Name:              "custom",
+			TaskReferenceName: "custom_ref",
+			Type_:             "CUSTOM_TYPE",
+			InputParameters:   map[string]interface{}{"key": "value"},
+			RetryCount:        2,
+		}))
+}
+
+func TestFromWorkflowDefRoundTrip(t *testing.T) {
+	workflowDef := newTestWorkflow().ToWorkflowDef()
+	converted := FromWorkflowDef(workflowDef)
+	assert.Equal(t, toJson(t, workflowDef), toJson(t, converted.ToWorkflowDef()))
+
+	assert.IsType(t, &SimpleTask{}, converted.tasks[0])
+	assert.IsType(t, &SwitchTask{}, converted.tasks[1])
+	assert.IsType(t, &ForkTask{}, converted.tasks[2])
+	assert.IsType(t, &DoWhileTask{}, converted.tasks[3])
+	assert.IsType(t, &RawTask{}, converted.tasks[4])
+	assert.Equal(t, 5, len(converted.tasks))
+}
+
+func TestFromWorkflowDefUnsupportedProperties(t *testing.T) {
+	workflowTask := model.WorkflowTask{
+		Name:              "simple",
+		TaskReferenceName: "simple_ref",
+		Type_:             string(WAIT),
+		InputParameters:   map[string]interface{}{"duration": "10s"},
+		AsyncComplete:     true,
+	}
+	task := FromWorkflowTask(workflowTask)
+	assert.IsType(t, &RawTask{}, task)
+	assert.Equal(t, []model.WorkflowTask{workflowTask}, task.toWorkflowTask())
+}
+
+func TestFromWorkflowDefJson(t *testing.T) {
+	data, err := os.ReadFile("../../test/integration_tests/complex_wf_signal_test.json")
+	assert.NoError(t, err)
+	var workflowDef model.WorkflowDef
+	assert.NoError(t, json.Unmarshal(data, &workflowDef))
+
+	converted := FromWorkflowDef(&workflowDef)
+	assert.Equal(t, toJson(t, workflowDef.Tasks), toJson(t, converted.ToWorkflowDef().Tasks))
+}
+
+func TestGenerateGoSource(t *testing.T) {
+	source, err := GenerateGoSource(newTestWorkflow().ToWorkflowDef(), "orders", "NewOrderFulfillment")
+	assert.NoError(t, err)
+
+	_, err = parser.ParseFile(token.NewFileSet(), "orders.go", source, 0)
+	assert.NoError(t, err)
+	code := string(source)
+	assert.Contains(t, code, "package orders")
+	assert.Contains(t, code, "func NewOrderFulfillment(executor *executor.WorkflowExecutor) *workflow.ConductorWorkflow")
+	assert.Contains(t, code, `getOrderRef := workflow.NewSimpleTask("get_order", "get_order_ref")`)
+	assert.Contains(t, code, `RetryPolicy(3, workflow.RetryLogic("FIXED"), 10, 1)`)
+	assert.Contains(t, code, `workflow.NewSwitchTask("payment_ref", "${get_order_ref.output.paymentType}")`)
+	assert.Contains(t, code, `SwitchCase("CARD", chargeCardRef)`)
+	assert.Contains(t, code, `workflow.NewForkTask("fork_ref", []workflow.TaskInterface{notifyRef}, []workflow.TaskInterface{waitRef})`)
+	assert.Contains(t, code, `workflow.NewDoWhileTask("ship_loop_ref", "$.ship_ref['done'] == true", shipRef)`)
+	assert.Contains(t, code, "workflow.NewRawTask(model.WorkflowTask{")
+	assert.Contains(t, code, "wf.Add(getOrderRef)")
+}
+
+func TestGenerateGoSourceFromJson(t *testing.T) {
+	data, err := os.ReadFile("../../test/integration_tests/complex_wf_signal_test.json")
+	assert.NoError(t, err)
+
+	source, err := GenerateGoSourceFromJson(data, "signals", "NewSignalWorkflow")
+	assert.NoError(t, err)
+	_, err = parser.ParseFile(token.NewFileSet(), "signals.go", source, 0)
+	assert.NoError(t, err)
+}
+
+func TestGenerateGoSourceTaskDefinition(t *testing.T) {
+	// the builders only create the task definitions of the tasks with the settings they support
+	workflowDef := &model.WorkflowDef{Name: "orders", Tasks: []model.WorkflowTask{{
+		Name: "get_order", TaskReferenceName: "get_order_ref", Type_: string(SIMPLE),
+		TaskDefinition: &model.TaskDef{Name: "get_order"},
+	}}}
+	source, err := GenerateGoSource(workflowDef, "orders", "NewOrders")
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), "getOrderRef := workflow.NewRawTask(model.WorkflowTask{")
+}
+
+func TestGenerateGoSourceRawTaskChildren(t *testing.T) {
+	// the builders do not support startDelay, the switch and its cases are generated as a single raw task
+	data := []byte(`{"name": "payments", "version": 1, "tasks": [{
+		"name": "payment", "taskReferenceName": "payment_ref", "type": "SWITCH", "startDelay": 5,
+		"evaluatorType": "value-param", "expression": "switchCaseValue", "inputParameters": {"switchCaseValue": "${workflow.input.type}"},
+		"decisionCases": {"CARD": [{"name": "charge_card", "taskReferenceName": "charge_card_ref", "type": "SIMPLE"}]}
+	}]}`)
+	source, err := GenerateGoSourceFromJson(data, "payments", "NewPayments")
+	assert.NoError(t, err)
+	code := string(source)
+	assert.Contains(t, code, "paymentRef := workflow.NewRawTask(model.WorkflowTask{")
+	assert.NotContains(t, code, "chargeCardRef :=")
+	assert.NotContains(t, code, "Restartable(false)")
+	assert.NotContains(t, code, "OverwriteTags(false)")
+}
+
+func TestGeneratedGoSourceRuns(t *testing.T) {
+	if testing.Short() {
+		t.Skip("builds the generated source")
+	}
+	goTool, err := exec.LookPath("go")
+	if err != nil {
+		t.Skip("the go tool is not available")
+	}
+	data, err := os.ReadFile("../../test/integration_tests/complex_wf_signal_test.json")
+	assert.NoError(t, err)
+	source, err := GenerateGoSourceFromJson(data, "main", "NewSignalWorkflow")
+	assert.NoError(t, err)
+
+	// a module using this one, printing the definition of the generated workflow
+	moduleDir, err := filepath.Abs("../..")
+	assert.NoError(t, err)
+	goSum, err := os.ReadFile(filepath.Join(moduleDir, "go.sum"))
+	assert.NoError(t, err)
+	dir := t.TempDir()
+	files := map[string]string{
+		"go.mod": "module signals\n\ngo 1.23\n\nrequire github.com/conductor-sdk/conductor-go v0.0.0\n\n" +
+			"replace github.com/conductor-sdk/conductor-go => " + moduleDir + "\n",
+		"go.sum":     string(goSum),
+		"signals.go": string(source),
+		"main.go": `package main
+
+import (
+	"encoding/json"
+	"os"
+)
+
+func main() {
+	json.NewEncoder(os.Stdout).Encode(NewSignalWorkflow(nil).ToWorkflowDef())
+}
+`,
+	}
+	for name, content := range files {
+		assert.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0644))
+	}
+	command := exec.Command(goTool, "run", "-mod=mod", ".")
+	command.Dir = dir
+	var stderr strings.Builder
+	command.Stderr = &stderr
+	output, err := command.Output()
+	if !assert.NoError(t, err, stderr.String()) {
+		return
+	}
+
+	// the settings omitted from the JSON have the defaults of the server
+	expected := model.WorkflowDef{Restartable: true, OverwriteTags: true}
+	var actual model.WorkflowDef
+	assert.NoError(t, json.Unmarshal(data, &expected))
+	assert.NoError(t, json.Unmarshal(output, &actual))
+	// set by the server
+	expected.CreateTime, expected.UpdateTime = 0, 0
+	expectedJson, err := toComparableJson(expected)
+	assert.NoError(t, err)
+	actualJson, err := toComparableJson(actual)
+	assert.NoError(t, err)
+	assert.Equal(t, expectedJson, actualJson)
+}
+
+func toJson(t *testing.T, value interface{}) string {
+	data, err := json.Marshal(value)
+	assert.NoError(t, err)
+	return string(data)
+}
@@ -0,0 +1,54 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+// GetWorkflowTask gets the execution of the workflow with the given id, the output of the task is the workflow execution
+type GetWorkflowTask struct {
+	Task
+}
+
+// NewGetWorkflowTask workflowId is usually a reference, e.g. the output of a START_WORKFLOW task
+func NewGetWorkflowTask(taskRefName string, workflowId string, includeTasks bool) *GetWorkflowTask {
+	return &GetWorkflowTask{
+		Task{
+			name:              taskRefName,
+			taskReferenceName: taskRefName,
+			taskType:          GET_WORKFLOW,
+			inputParameters: map[string]interface{}{
+				"id":           workflowId,
+				"includeTasks": includeTasks,
+			},
+		},
+	}
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *GetWorkflowTask) Input(key string, value interface{}) *GetWorkflowTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *GetWorkflowTask) InputMap(inputMap map[string]interface{}) *GetWorkflowTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *GetWorkflowTask) Optional(optional bool) *GetWorkflowTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *GetWorkflowTask) Description(description string) *GetWorkflowTask {
+	task.Task.Description(description)
+	return task
+}
@@ -0,0 +1,27 @@
+package workflow
+
+import (
+	"testing"
+)
+
+func TestGetWorkflowTask(t *testing.T) {
+	task := NewGetWorkflowTask("get_ref", "${start_ref.output.workflowId}", true)
+	assertSerializedTask(t, `{
+		"name": "get_ref", "taskReferenceName": "get_ref", "type": "GET_WORKFLOW",
+		"inputParameters": {"id": "${start_ref.output.workflowId}", "includeTasks": true}
+	}`, task)
+}
+
+func TestNoopTask(t *testing.T) {
+	assertSerializedTask(t, `{"name": "noop_ref", "taskReferenceName": "noop_ref", "type": "NOOP"}`, NewNoopTask("noop_ref"))
+}
+
+func TestDynamicSubWorkflowTask(t *testing.T) {
+	task := NewDynamicSubWorkflowTask("sub_ref", WorkflowInput("workflowName").String()).
+		Input("orderId", WorkflowInput("orderId"))
+	assertSerializedTask(t, `{
+		"name": "sub_ref", "taskReferenceName": "sub_ref", "type": "SUB_WORKFLOW",
+		"inputParameters": {"orderId": "${workflow.input.orderId}"},
+		"subWorkflowParam": {"name": "${workflow.input.workflowName}"}
+	}`, task)
+}
@@ -0,0 +1,84 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import (
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// GrpcTask calls a method of a gRPC service registered in the service registry
+type GrpcTask struct {
+	Task
+}
+
+// GrpcInput Service is the name of the service in the service registry, Request the message sent to the method as JSON
+type GrpcInput struct {
+	Service    string                 `json:"service"`
+	Method     string                 `json:"method"`
+	MethodType string                 `json:"methodType,omitempty"`
+	InputType  string                 `json:"inputType,omitempty"`
+	OutputType string                 `json:"outputType,omitempty"`
+	Host       string                 `json:"host,omitempty"`
+	Headers    map[string]string      `json:"headers,omitempty"`
+	Request    map[string]interface{} `json:"request,omitempty"`
+}
+
+// NewGrpcTask a nil input leaves the input parameters to be set with Input and InputMap
+func NewGrpcTask(taskRefName string, input *GrpcInput) *GrpcTask {
+	inputParameters := map[string]interface{}{}
+	if input != nil {
+		inputParameters = getInputAsMap(input)
+	}
+	return &GrpcTask{
+		Task{
+			name:              taskRefName,
+			taskReferenceName: taskRefName,
+			taskType:          GRPC,
+			inputParameters:   inputParameters,
+		},
+	}
+}
+
+// NewGrpcServiceTask calls the method of the service using the definitions of the service registry
+func NewGrpcServiceTask(taskRefName string, service *model.ServiceRegistry, method *model.ServiceMethod, request map[string]interface{}) *GrpcTask {
+	return NewGrpcTask(taskRefName, &GrpcInput{
+		Service:    service.Name,
+		Method:     method.MethodName,
+		MethodType: method.MethodType,
+		InputType:  method.InputType,
+		OutputType: method.OutputType,
+		Host:       service.ServiceURI,
+		Request:    request,
+	})
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *GrpcTask) Input(key string, value interface{}) *GrpcTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *GrpcTask) InputMap(inputMap map[string]interface{}) *GrpcTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *GrpcTask) Optional(optional bool) *GrpcTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *GrpcTask) Description(description string) *GrpcTask {
+	task.Task.Description(description)
+	return task
+}
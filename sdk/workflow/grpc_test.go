@@ -0,0 +1,53 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGrpcServiceTask(t *testing.T) {
+	service := &model.ServiceRegistry{Name: "users", Type_: "gRPC", ServiceURI: "users.internal:50051"}
+	method := &model.ServiceMethod{
+		MethodName: "GetUser",
+		MethodType: "UNARY",
+		InputType:  "users.GetUserRequest",
+		OutputType: "users.User",
+	}
+	task := NewGrpcServiceTask("get_user_ref", service, method, map[string]interface{}{"id": "${workflow.input.userId}"})
+	assertSerializedTask(t, `{
+		"name": "get_user_ref", "taskReferenceName": "get_user_ref", "type": "GRPC",
+		"inputParameters": {
+			"service": "users", "method": "GetUser", "methodType": "UNARY", "host": "users.internal:50051",
+			"inputType": "users.GetUserRequest", "outputType": "users.User",
+			"request": {"id": "${workflow.input.userId}"}
+		}
+	}`, task)
+}
+
+func TestHttpServiceTask(t *testing.T) {
+	service := &model.ServiceRegistry{Name: "orders", Type_: "HTTP", ServiceURI: "https://orders.example.com/api/"}
+	method := &model.ServiceMethod{MethodName: "/orders", MethodType: "post"}
+	input := &HttpInput{Body: map[string]interface{}{"sku": "A1"}}
+	task := NewHttpServiceTask("create_order_ref", service, method, input)
+	assertSerializedTask(t, `{
+		"name": "HTTP", "taskReferenceName": "create_order_ref", "type": "HTTP",
+		"inputParameters": {
+			"http_request": {"method": "POST", "uri": "https://orders.example.com/api/orders", "body": {"sku": "A1"}}
+		}
+	}`, task)
+	// the input can be reused for the other methods of the service
+	assert.Empty(t, input.Uri)
+	assert.Empty(t, input.Method)
+}
+
+func TestGrpcTaskNilInput(t *testing.T) {
+	task := NewGrpcTask("get_user_ref", nil).
+		Input("service", "users").
+		Input("method", "GetUser")
+	assertSerializedTask(t, `{
+		"name": "get_user_ref", "taskReferenceName": "get_user_ref", "type": "GRPC",
+		"inputParameters": {"service": "users", "method": "GetUser"}
+	}`, task)
+}
@@ -9,7 +9,11 @@
 
 package workflow
 
-import "github.com/conductor-sdk/conductor-go/sdk/model"
+import (
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
 
 type HttpTask struct {
 	SimpleTask
@@ -52,6 +56,18 @@ func NewHttpTask(taskRefName string, input *HttpInput) *HttpTask {
 	return http
 }
 
+// NewHttpServiceTask Create a new HTTP Task calling the method of a HTTP service registered in the service registry.
+// The URI and the HTTP method are taken from the registry, the rest of the request (headers, body etc.) from input
+func NewHttpServiceTask(taskRefName string, service *model.ServiceRegistry, method *model.ServiceMethod, input *HttpInput) *HttpTask {
+	request := HttpInput{}
+	if input != nil {
+		request = *input
+	}
+	request.Uri = strings.TrimSuffix(service.ServiceURI, "/") + "/" + strings.TrimPrefix(method.MethodName, "/")
+	request.Method = HttpMethod(strings.ToUpper(method.MethodType))
+	return NewHttpTask(taskRefName, &request)
+}
+
 // HttpInput Input to the HTTP task
 type HttpInput struct {
 	Method            HttpMethod          `json:"method"`
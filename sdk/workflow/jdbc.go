@@ -0,0 +1,77 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+type JdbcStatementType string
+
+const (
+	JdbcSelect JdbcStatementType = "SELECT"
+	JdbcUpdate JdbcStatementType = "UPDATE"
+)
+
+// JdbcTask executes the SQL statement using the relational database integration IntegrationName
+type JdbcTask struct {
+	Task
+}
+
+// JdbcInput Parameters are bound to the ? placeholders of the statement in order.
+// ExpectedUpdateCount fails the task when an UPDATE statement modifies a different number of rows
+type JdbcInput struct {
+	IntegrationName     string            `json:"integrationName"`
+	Statement           string            `json:"statement"`
+	Parameters          []interface{}     `json:"parameters,omitempty"`
+	Type                JdbcStatementType `json:"type"`
+	ExpectedUpdateCount int32             `json:"expectedUpdateCount,omitempty"`
+}
+
+// NewJdbcTask the statement type defaults to SELECT, the input is not modified.  A nil input leaves the input
+// parameters to be set with Input and InputMap
+func NewJdbcTask(taskRefName string, input *JdbcInput) *JdbcTask {
+	inputParameters := map[string]interface{}{}
+	if input != nil {
+		jdbcInput := *input
+		if len(jdbcInput.Type) == 0 {
+			jdbcInput.Type = JdbcSelect
+		}
+		inputParameters = getInputAsMap(jdbcInput)
+	}
+	return &JdbcTask{
+		Task{
+			name:              taskRefName,
+			taskReferenceName: taskRefName,
+			taskType:          JDBC,
+			inputParameters:   inputParameters,
+		},
+	}
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *JdbcTask) Input(key string, value interface{}) *JdbcTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *JdbcTask) InputMap(inputMap map[string]interface{}) *JdbcTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *JdbcTask) Optional(optional bool) *JdbcTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *JdbcTask) Description(description string) *JdbcTask {
+	task.Task.Description(description)
+	return task
+}
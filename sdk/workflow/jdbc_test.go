@@ -0,0 +1,44 @@
+package workflow
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestJdbcTask(t *testing.T) {
+	task := NewJdbcTask("update_ref", &JdbcInput{
+		IntegrationName:     "orders_db",
+		Statement:           "UPDATE orders SET status = ? WHERE id = ?",
+		Parameters:          []interface{}{"SHIPPED", "${workflow.input.orderId}"},
+		Type:                JdbcUpdate,
+		ExpectedUpdateCount: 1,
+	})
+	assertSerializedTask(t, `{
+		"name": "update_ref", "taskReferenceName": "update_ref", "type": "JDBC",
+		"inputParameters": {
+			"integrationName": "orders_db", "statement": "UPDATE orders SET status = ? WHERE id = ?",
+			"parameters": ["SHIPPED", "${workflow.input.orderId}"], "type": "UPDATE", "expectedUpdateCount": 1
+		}
+	}`, task)
+
+	task = NewJdbcTask("select_ref", &JdbcInput{IntegrationName: "orders_db", Statement: "SELECT * FROM orders"})
+	assertSerializedTask(t, `{
+		"name": "select_ref", "taskReferenceName": "select_ref", "type": "JDBC",
+		"inputParameters": {"integrationName": "orders_db", "statement": "SELECT * FROM orders", "type": "SELECT"}
+	}`, task)
+}
+
+func TestJdbcTaskInput(t *testing.T) {
+	input := &JdbcInput{IntegrationName: "orders_db", Statement: "SELECT * FROM orders"}
+	NewJdbcTask("select_ref", input)
+	assert.Empty(t, input.Type)
+
+	task := NewJdbcTask("select_ref", nil).
+		Input("integrationName", "orders_db").
+		Input("statement", "SELECT * FROM orders")
+	assertSerializedTask(t, `{
+		"name": "select_ref", "taskReferenceName": "select_ref", "type": "JDBC",
+		"inputParameters": {"integrationName": "orders_db", "statement": "SELECT * FROM orders"}
+	}`, task)
+}
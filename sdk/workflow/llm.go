@@ -0,0 +1,303 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+// LLM tasks use the AI integrations (LLM providers and vector databases) configured on the server.
+// Numeric inputs left as zero use the defaults of the integration.
+
+type ChatRole string
+
+const (
+	ChatRoleSystem    ChatRole = "system"
+	ChatRoleUser      ChatRole = "user"
+	ChatRoleAssistant ChatRole = "assistant"
+)
+
+type ChatMessage struct {
+	Role    ChatRole `json:"role"`
+	Message string   `json:"message"`
+}
+
+// LlmTextCompleteInput completes the text of the prompt template promptName
+type LlmTextCompleteInput struct {
+	LlmProvider     string                 `json:"llmProvider"`
+	Model           string                 `json:"model"`
+	PromptName      string                 `json:"promptName"`
+	PromptVariables map[string]interface{} `json:"promptVariables,omitempty"`
+	Temperature     float64                `json:"temperature,omitempty"`
+	TopP            float64                `json:"topP,omitempty"`
+	MaxTokens       int32                  `json:"maxTokens,omitempty"`
+	StopWords       []string               `json:"stopWords,omitempty"`
+}
+
+// LlmChatCompleteInput continues the conversation in Messages.  Instructions is the name of the prompt template used as
+// the system instructions of the conversation
+type LlmChatCompleteInput struct {
+	LlmProvider     string                 `json:"llmProvider"`
+	Model           string                 `json:"model"`
+	Instructions    string                 `json:"instructions,omitempty"`
+	Messages        []ChatMessage          `json:"messages,omitempty"`
+	PromptVariables map[string]interface{} `json:"promptVariables,omitempty"`
+	Temperature     float64                `json:"temperature,omitempty"`
+	TopP            float64                `json:"topP,omitempty"`
+	MaxTokens       int32                  `json:"maxTokens,omitempty"`
+	StopWords       []string               `json:"stopWords,omitempty"`
+	JsonOutput      bool                   `json:"jsonOutput,omitempty"`
+}
+
+type LlmGenerateEmbeddingsInput struct {
+	LlmProvider string `json:"llmProvider"`
+	Model       string `json:"model"`
+	Text        string `json:"text"`
+}
+
+// LlmStoreEmbeddingsInput stores the embeddings in the index of the vector database.
+// Embeddings is usually a reference to the output of a LLM_GENERATE_EMBEDDINGS task
+type LlmStoreEmbeddingsInput struct {
+	VectorDB               string                 `json:"vectorDB"`
+	Index                  string                 `json:"index"`
+	Namespace              string                 `json:"namespace,omitempty"`
+	EmbeddingModelProvider string                 `json:"embeddingModelProvider,omitempty"`
+	EmbeddingModel         string                 `json:"embeddingModel,omitempty"`
+	Id                     string                 `json:"id,omitempty"`
+	Embeddings             interface{}            `json:"embeddings"`
+	Metadata               map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// LlmIndexDocumentInput downloads the document at Url, splits it in chunks and indexes their embeddings
+type LlmIndexDocumentInput struct {
+	VectorDB               string                 `json:"vectorDB"`
+	Index                  string                 `json:"index"`
+	Namespace              string                 `json:"namespace,omitempty"`
+	EmbeddingModelProvider string                 `json:"embeddingModelProvider"`
+	EmbeddingModel         string                 `json:"embeddingModel"`
+	Url                    string                 `json:"url"`
+	MediaType              string                 `json:"mediaType,omitempty"`
+	ChunkSize              int32                  `json:"chunkSize,omitempty"`
+	ChunkOverlap           int32                  `json:"chunkOverlap,omitempty"`
+	DocId                  string                 `json:"docId,omitempty"`
+	Metadata               map[string]interface{} `json:"metadata,omitempty"`
+}
+
+// LlmSearchIndexInput searches the index for the documents closest to Query
+type LlmSearchIndexInput struct {
+	VectorDB               string `json:"vectorDB"`
+	Index                  string `json:"index"`
+	Namespace              string `json:"namespace,omitempty"`
+	EmbeddingModelProvider string `json:"embeddingModelProvider"`
+	EmbeddingModel         string `json:"embeddingModel"`
+	Query                  string `json:"query"`
+	MaxResults             int32  `json:"maxResults,omitempty"`
+}
+
+type LlmTextCompleteTask struct {
+	Task
+}
+
+type LlmChatCompleteTask struct {
+	Task
+}
+
+type LlmGenerateEmbeddingsTask struct {
+	Task
+}
+
+type LlmStoreEmbeddingsTask struct {
+	Task
+}
+
+type LlmIndexDocumentTask struct {
+	Task
+}
+
+type LlmSearchIndexTask struct {
+	Task
+}
+
+func NewLlmTextCompleteTask(taskRefName string, input *LlmTextCompleteInput) *LlmTextCompleteTask {
+	return &LlmTextCompleteTask{newLlmTask(taskRefName, LLM_TEXT_COMPLETE, input)}
+}
+
+func NewLlmChatCompleteTask(taskRefName string, input *LlmChatCompleteInput) *LlmChatCompleteTask {
+	return &LlmChatCompleteTask{newLlmTask(taskRefName, LLM_CHAT_COMPLETE, input)}
+}
+
+func NewLlmGenerateEmbeddingsTask(taskRefName string, input *LlmGenerateEmbeddingsInput) *LlmGenerateEmbeddingsTask {
+	return &LlmGenerateEmbeddingsTask{newLlmTask(taskRefName, LLM_GENERATE_EMBEDDINGS, input)}
+}
+
+func NewLlmStoreEmbeddingsTask(taskRefName string, input *LlmStoreEmbeddingsInput) *LlmStoreEmbeddingsTask {
+	return &LlmStoreEmbeddingsTask{newLlmTask(taskRefName, LLM_STORE_EMBEDDINGS, input)}
+}
+
+func NewLlmIndexDocumentTask(taskRefName string, input *LlmIndexDocumentInput) *LlmIndexDocumentTask {
+	return &LlmIndexDocumentTask{newLlmTask(taskRefName, LLM_INDEX_DOCUMENT, input)}
+}
+
+func NewLlmSearchIndexTask(taskRefName string, input *LlmSearchIndexInput) *LlmSearchIndexTask {
+	return &LlmSearchIndexTask{newLlmTask(taskRefName, LLM_SEARCH_INDEX, input)}
+}
+
+func newLlmTask(taskRefName string, taskType TaskType, input interface{}) Task {
+	inputParameters := getInputAsMap(input)
+	if inputParameters == nil {
+		inputParameters = map[string]interface{}{}
+	}
+	return Task{
+		name:              taskRefName,
+		taskReferenceName: taskRefName,
+		taskType:          taskType,
+		inputParameters:   inputParameters,
+	}
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmTextCompleteTask) Input(key string, value interface{}) *LlmTextCompleteTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmTextCompleteTask) InputMap(inputMap map[string]interface{}) *LlmTextCompleteTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *LlmTextCompleteTask) Optional(optional bool) *LlmTextCompleteTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *LlmTextCompleteTask) Description(description string) *LlmTextCompleteTask {
+	task.Task.Description(description)
+	return task
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmChatCompleteTask) Input(key string, value interface{}) *LlmChatCompleteTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmChatCompleteTask) InputMap(inputMap map[string]interface{}) *LlmChatCompleteTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *LlmChatCompleteTask) Optional(optional bool) *LlmChatCompleteTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *LlmChatCompleteTask) Description(description string) *LlmChatCompleteTask {
+	task.Task.Description(description)
+	return task
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmGenerateEmbeddingsTask) Input(key string, value interface{}) *LlmGenerateEmbeddingsTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmGenerateEmbeddingsTask) InputMap(inputMap map[string]interface{}) *LlmGenerateEmbeddingsTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *LlmGenerateEmbeddingsTask) Optional(optional bool) *LlmGenerateEmbeddingsTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *LlmGenerateEmbeddingsTask) Description(description string) *LlmGenerateEmbeddingsTask {
+	task.Task.Description(description)
+	return task
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmStoreEmbeddingsTask) Input(key string, value interface{}) *LlmStoreEmbeddingsTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmStoreEmbeddingsTask) InputMap(inputMap map[string]interface{}) *LlmStoreEmbeddingsTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *LlmStoreEmbeddingsTask) Optional(optional bool) *LlmStoreEmbeddingsTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *LlmStoreEmbeddingsTask) Description(description string) *LlmStoreEmbeddingsTask {
+	task.Task.Description(description)
+	return task
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmIndexDocumentTask) Input(key string, value interface{}) *LlmIndexDocumentTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmIndexDocumentTask) InputMap(inputMap map[string]interface{}) *LlmIndexDocumentTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *LlmIndexDocumentTask) Optional(optional bool) *LlmIndexDocumentTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *LlmIndexDocumentTask) Description(description string) *LlmIndexDocumentTask {
+	task.Task.Description(description)
+	return task
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmSearchIndexTask) Input(key string, value interface{}) *LlmSearchIndexTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *LlmSearchIndexTask) InputMap(inputMap map[string]interface{}) *LlmSearchIndexTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *LlmSearchIndexTask) Optional(optional bool) *LlmSearchIndexTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *LlmSearchIndexTask) Description(description string) *LlmSearchIndexTask {
+	task.Task.Description(description)
+	return task
+}
@@ -0,0 +1,122 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func assertSerializedTask(t *testing.T, expected string, task TaskInterface) {
+	workflowTasks := task.toWorkflowTask()
+	assert.Len(t, workflowTasks, 1)
+	data, err := json.Marshal(workflowTasks[0])
+	assert.NoError(t, err)
+	assert.JSONEq(t, expected, string(data))
+}
+
+func TestLlmTextCompleteTask(t *testing.T) {
+	task := NewLlmTextCompleteTask("summarize_ref", &LlmTextCompleteInput{
+		LlmProvider:     "openai",
+		Model:           "gpt-4o",
+		PromptName:      "summarize",
+		PromptVariables: map[string]interface{}{"text": WorkflowInput("text")},
+		Temperature:     0.2,
+		MaxTokens:       500,
+		StopWords:       []string{"END"},
+	})
+	assertSerializedTask(t, `{
+		"name": "summarize_ref", "taskReferenceName": "summarize_ref", "type": "LLM_TEXT_COMPLETE",
+		"inputParameters": {
+			"llmProvider": "openai", "model": "gpt-4o", "promptName": "summarize",
+			"promptVariables": {"text": "${workflow.input.text}"},
+			"temperature": 0.2, "maxTokens": 500, "stopWords": ["END"]
+		}
+	}`, task)
+}
+
+func TestLlmChatCompleteTask(t *testing.T) {
+	task := NewLlmChatCompleteTask("chat_ref", &LlmChatCompleteInput{
+		LlmProvider:  "anthropic",
+		Model:        "claude",
+		Instructions: "support_agent",
+		Messages: []ChatMessage{
+			{Role: ChatRoleUser, Message: "${workflow.input.question}"},
+		},
+		JsonOutput: true,
+	}).Optional(true)
+	assertSerializedTask(t, `{
+		"name": "chat_ref", "taskReferenceName": "chat_ref", "type": "LLM_CHAT_COMPLETE", "optional": true,
+		"inputParameters": {
+			"llmProvider": "anthropic", "model": "claude", "instructions": "support_agent",
+			"messages": [{"role": "user", "message": "${workflow.input.question}"}],
+			"jsonOutput": true
+		}
+	}`, task)
+}
+
+func TestLlmEmbeddingTasks(t *testing.T) {
+	generate := NewLlmGenerateEmbeddingsTask("embed_ref", &LlmGenerateEmbeddingsInput{
+		LlmProvider: "openai",
+		Model:       "text-embedding-3-small",
+		Text:        "${workflow.input.text}",
+	})
+	assertSerializedTask(t, `{
+		"name": "embed_ref", "taskReferenceName": "embed_ref", "type": "LLM_GENERATE_EMBEDDINGS",
+		"inputParameters": {"llmProvider": "openai", "model": "text-embedding-3-small", "text": "${workflow.input.text}"}
+	}`, generate)
+
+	store := NewLlmStoreEmbeddingsTask("store_ref", &LlmStoreEmbeddingsInput{
+		VectorDB:   "pinecone",
+		Index:      "docs",
+		Namespace:  "faq",
+		Id:         "${workflow.input.docId}",
+		Embeddings: generate.Output("result"),
+		Metadata:   map[string]interface{}{"source": "faq"},
+	})
+	assertSerializedTask(t, `{
+		"name": "store_ref", "taskReferenceName": "store_ref", "type": "LLM_STORE_EMBEDDINGS",
+		"inputParameters": {
+			"vectorDB": "pinecone", "index": "docs", "namespace": "faq", "id": "${workflow.input.docId}",
+			"embeddings": "${embed_ref.output.result}", "metadata": {"source": "faq"}
+		}
+	}`, store)
+}
+
+func TestLlmIndexAndSearchTasks(t *testing.T) {
+	index := NewLlmIndexDocumentTask("index_ref", &LlmIndexDocumentInput{
+		VectorDB:               "pgvector",
+		Index:                  "docs",
+		EmbeddingModelProvider: "openai",
+		EmbeddingModel:         "text-embedding-3-small",
+		Url:                    "https://example.com/manual.pdf",
+		MediaType:              "application/pdf",
+		ChunkSize:              1000,
+		ChunkOverlap:           100,
+	})
+	assertSerializedTask(t, `{
+		"name": "index_ref", "taskReferenceName": "index_ref", "type": "LLM_INDEX_DOCUMENT",
+		"inputParameters": {
+			"vectorDB": "pgvector", "index": "docs", "embeddingModelProvider": "openai",
+			"embeddingModel": "text-embedding-3-small", "url": "https://example.com/manual.pdf",
+			"mediaType": "application/pdf", "chunkSize": 1000, "chunkOverlap": 100
+		}
+	}`, index)
+
+	search := NewLlmSearchIndexTask("search_ref", &LlmSearchIndexInput{
+		VectorDB:               "pgvector",
+		Index:                  "docs",
+		EmbeddingModelProvider: "openai",
+		EmbeddingModel:         "text-embedding-3-small",
+		Query:                  "${workflow.input.question}",
+		MaxResults:             5,
+	}).Description("find the relevant documents")
+	assertSerializedTask(t, `{
+		"name": "search_ref", "taskReferenceName": "search_ref", "type": "LLM_SEARCH_INDEX",
+		"description": "find the relevant documents",
+		"inputParameters": {
+			"vectorDB": "pgvector", "index": "docs", "embeddingModelProvider": "openai",
+			"embeddingModel": "text-embedding-3-small", "query": "${workflow.input.question}", "maxResults": 5
+		}
+	}`, search)
+}
@@ -0,0 +1,38 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+// NoopTask does nothing, e.g. a placeholder for the SWITCH cases with no tasks
+type NoopTask struct {
+	Task
+}
+
+func NewNoopTask(taskRefName string) *NoopTask {
+	return &NoopTask{
+		Task{
+			name:              taskRefName,
+			taskReferenceName: taskRefName,
+			taskType:          NOOP,
+			inputParameters:   map[string]interface{}{},
+		},
+	}
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *NoopTask) Optional(optional bool) *NoopTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *NoopTask) Description(description string) *NoopTask {
+	task.Task.Description(description)
+	return task
+}
@@ -0,0 +1,92 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+type QueryType string
+
+const (
+	// ConductorApiQuery searches the workflow executions
+	ConductorApiQuery QueryType = "CONDUCTOR_API"
+	// MetricsQuery runs a PromQL query against the metrics of the server
+	MetricsQuery QueryType = "METRICS"
+)
+
+// QueryProcessorTask queries the workflow executions or the metrics of the server, e.g. to alert on failed workflows
+type QueryProcessorTask struct {
+	Task
+}
+
+// ConductorApiQueryInput the times are relative to now in minutes, e.g. StartTimeFrom 60 finds the workflows started in the last hour
+type ConductorApiQueryInput struct {
+	WorkflowNames  []string `json:"workflowNames,omitempty"`
+	Statuses       []string `json:"statuses,omitempty"`
+	CorrelationIds []string `json:"correlationIds,omitempty"`
+	StartTimeFrom  int64    `json:"startTimeFrom,omitempty"`
+	StartTimeTo    int64    `json:"startTimeTo,omitempty"`
+	EndTimeFrom    int64    `json:"endTimeFrom,omitempty"`
+	EndTimeTo      int64    `json:"endTimeTo,omitempty"`
+	FreeText       string   `json:"freeText,omitempty"`
+}
+
+// MetricsQueryInput MetricsStart and MetricsEnd are relative to now, e.g. 1h
+type MetricsQueryInput struct {
+	MetricsQuery string `json:"metricsQuery"`
+	MetricsStart string `json:"metricsStart,omitempty"`
+	MetricsEnd   string `json:"metricsEnd,omitempty"`
+	MetricsStep  string `json:"metricsStep,omitempty"`
+}
+
+func NewConductorApiQueryTask(taskRefName string, input *ConductorApiQueryInput) *QueryProcessorTask {
+	return newQueryProcessorTask(taskRefName, ConductorApiQuery, input)
+}
+
+func NewMetricsQueryTask(taskRefName string, input *MetricsQueryInput) *QueryProcessorTask {
+	return newQueryProcessorTask(taskRefName, MetricsQuery, input)
+}
+
+func newQueryProcessorTask(taskRefName string, queryType QueryType, input interface{}) *QueryProcessorTask {
+	inputParameters := getInputAsMap(input)
+	if inputParameters == nil {
+		inputParameters = map[string]interface{}{}
+	}
+	inputParameters["queryType"] = queryType
+	return &QueryProcessorTask{
+		Task{
+			name:              taskRefName,
+			taskReferenceName: taskRefName,
+			taskType:          QUERY_PROCESSOR,
+			inputParameters:   inputParameters,
+		},
+	}
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *QueryProcessorTask) Input(key string, value interface{}) *QueryProcessorTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *QueryProcessorTask) InputMap(inputMap map[string]interface{}) *QueryProcessorTask {
+	task.Task.InputMap(inputMap)
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *QueryProcessorTask) Optional(optional bool) *QueryProcessorTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *QueryProcessorTask) Description(description string) *QueryProcessorTask {
+	task.Task.Description(description)
+	return task
+}
@@ -0,0 +1,30 @@
+package workflow
+
+import (
+	"testing"
+)
+
+func TestConductorApiQueryTask(t *testing.T) {
+	task := NewConductorApiQueryTask("failed_ref", &ConductorApiQueryInput{
+		WorkflowNames: []string{"order_fulfillment"},
+		Statuses:      []string{"FAILED"},
+		StartTimeFrom: 60,
+	})
+	assertSerializedTask(t, `{
+		"name": "failed_ref", "taskReferenceName": "failed_ref", "type": "QUERY_PROCESSOR",
+		"inputParameters": {
+			"queryType": "CONDUCTOR_API", "workflowNames": ["order_fulfillment"], "statuses": ["FAILED"], "startTimeFrom": 60
+		}
+	}`, task)
+}
+
+func TestMetricsQueryTask(t *testing.T) {
+	task := NewMetricsQueryTask("metrics_ref", &MetricsQueryInput{
+		MetricsQuery: "sum(workflow_failure_total)",
+		MetricsStart: "1h",
+	})
+	assertSerializedTask(t, `{
+		"name": "metrics_ref", "taskReferenceName": "metrics_ref", "type": "QUERY_PROCESSOR",
+		"inputParameters": {"queryType": "METRICS", "metricsQuery": "sum(workflow_failure_total)", "metricsStart": "1h"}
+	}`, task)
+}
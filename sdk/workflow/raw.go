@@ -0,0 +1,71 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import "github.com/conductor-sdk/conductor-go/sdk/model"
+
+// RawTask a task that is serialized exactly as the given model.WorkflowTask.
+// Used for the task types that do not have a builder or use properties not supported by the builders
+type RawTask struct {
+	Task
+	workflowTask model.WorkflowTask
+}
+
+func NewRawTask(workflowTask model.WorkflowTask) *RawTask {
+	inputParameters := map[string]interface{}{}
+	for k, v := range workflowTask.InputParameters {
+		inputParameters[k] = v
+	}
+	return &RawTask{
+		Task: Task{
+			name:              workflowTask.Name,
+			taskReferenceName: workflowTask.TaskReferenceName,
+			description:       workflowTask.Description,
+			taskType:          TaskType(workflowTask.Type_),
+			optional:          workflowTask.Optional,
+			inputParameters:   inputParameters,
+		},
+		workflowTask: workflowTask,
+	}
+}
+
+func (task *RawTask) toWorkflowTask() []model.WorkflowTask {
+	workflowTask := task.workflowTask
+	workflowTask.InputParameters = task.Task.toWorkflowTask()[0].InputParameters
+	workflowTask.Description = task.description
+	workflowTask.Optional = task.optional
+	return []model.WorkflowTask{workflowTask}
+}
+
+// Input to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *RawTask) Input(key string, value interface{}) *RawTask {
+	task.Task.Input(key, value)
+	return task
+}
+
+// InputMap to the task.  See https://conductor.netflix.com/how-tos/Tasks/task-inputs.html for details
+func (task *RawTask) InputMap(inputMap map[string]interface{}) *RawTask {
+	for k, v := range inputMap {
+		task.inputParameters[k] = v
+	}
+	return task
+}
+
+// Optional if set to true, the task will not fail the workflow if the task fails
+func (task *RawTask) Optional(optional bool) *RawTask {
+	task.Task.Optional(optional)
+	return task
+}
+
+// Description of the task
+func (task *RawTask) Description(description string) *RawTask {
+	task.Task.Description(description)
+	return task
+}
@@ -0,0 +1,96 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// Ref a reference to a value resolved by the server when the task is scheduled, e.g. ${workflow.input.userId}.
+// Use it as the value of the task inputs, Input("userId", WorkflowInput("userId")), or in expressions using String
+type Ref string
+
+// WorkflowInput reference to the input of the workflow, an empty path refers to the whole input
+func WorkflowInput(path string) Ref {
+	return newRef("workflow.input", path)
+}
+
+// Variable reference to the workflow variable set using SET_VARIABLE task
+func Variable(name string) Ref {
+	return newRef("workflow.variables", name)
+}
+
+// Secret reference to the secret stored on the server
+func Secret(name string) Ref {
+	return newRef("workflow.secrets", name)
+}
+
+// EnvVar reference to the environment variable configured on the server
+func EnvVar(name string) Ref {
+	return newRef("workflow.env", name)
+}
+
+// Output reference to the output of the task, an empty path refers to the whole output.
+// The path can navigate objects and arrays, e.g. task.Output("items[0].price")
+func (task *Task) Output(path string) Ref {
+	return newRef(task.taskReferenceName+".output", path)
+}
+
+// InputRef reference to the input of the task, an empty path refers to the whole input
+func (task *Task) InputRef(path string) Ref {
+	return newRef(task.taskReferenceName+".input", path)
+}
+
+func newRef(source string, path string) Ref {
+	return Ref("${" + source + "}").Path(path)
+}
+
+// Path reference to the value at path inside the referenced value, e.g. WorkflowInput("user").Path("address.city")
+func (ref Ref) Path(path string) Ref {
+	if path == "" {
+		return ref
+	}
+	expression := strings.TrimSuffix(string(ref), "}")
+	if !strings.HasPrefix(path, "[") {
+		expression += "."
+	}
+	return Ref(expression + path + "}")
+}
+
+func (ref Ref) String() string {
+	return string(ref)
+}
+
+var refPathSegment = regexp.MustCompile(`([^.\[\]]+)|\[(\d+)\]`)
+
+// JavaScript the reference in the syntax of DO_WHILE loop conditions and javascript evaluated expressions,
+// e.g. task.Output("items[0]") is $.task_ref['items'][0].
+// Loop conditions can only refer to the outputs of the tasks in the loop and the inputs of the DO_WHILE task, the
+// references to the workflow, e.g. its input, variables, secrets and environment variables, and to the inputs of the
+// tasks are rejected.  Pass them as inputs of the task instead, e.g. loop.Input("max", WorkflowInput("max")) is $.max
+func (ref Ref) JavaScript() (string, error) {
+	expression := strings.TrimSuffix(strings.TrimPrefix(string(ref), "${"), "}")
+	segments := refPathSegment.FindAllStringSubmatch(expression, -1)
+	if len(segments) < 2 || segments[0][1] == "" || segments[0][1] == "workflow" || segments[1][1] != "output" {
+		return "", fmt.Errorf("%s is not the output of a task, javascript expressions can only refer to the outputs of the tasks", ref)
+	}
+	var builder strings.Builder
+	builder.WriteString("$." + segments[0][1])
+	for _, segment := range segments[2:] {
+		if segment[2] != "" {
+			builder.WriteString("[" + segment[2] + "]")
+		} else {
+			builder.WriteString(fmt.Sprintf("['%s']", segment[1]))
+		}
+	}
+	return builder.String(), nil
+}
@@ -0,0 +1,105 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestReferences(t *testing.T) {
+	task := NewSimpleTask("get_user", "get_user_ref")
+	assert.Equal(t, "${workflow.input.userId}", WorkflowInput("userId").String())
+	assert.Equal(t, "${workflow.input}", WorkflowInput("").String())
+	assert.Equal(t, "${workflow.input.user.address.city}", WorkflowInput("user").Path("address.city").String())
+	assert.Equal(t, "${workflow.variables.counter}", Variable("counter").String())
+	assert.Equal(t, "${workflow.secrets.api_key}", Secret("api_key").String())
+	assert.Equal(t, "${workflow.env.region}", EnvVar("region").String())
+	assert.Equal(t, "${get_user_ref.output.a.b[0]}", task.Output("a.b[0]").String())
+	assert.Equal(t, "${get_user_ref.output}", task.Output("").String())
+	assert.Equal(t, "${get_user_ref.output.items[2]}", task.Output("items").Path("[2]").String())
+	assert.Equal(t, "${get_user_ref.input.userId}", task.InputRef("userId").String())
+	assert.Equal(t, task.OutputRef("a.b"), task.Output("a.b").String())
+}
+
+func TestReferenceJavaScript(t *testing.T) {
+	task := NewSimpleTask("get_user", "get_user_ref")
+	expression, err := task.Output("a.b[0]").JavaScript()
+	assert.NoError(t, err)
+	assert.Equal(t, "$.get_user_ref['a']['b'][0]", expression)
+	expression, err = task.Output("").JavaScript()
+	assert.NoError(t, err)
+	assert.Equal(t, "$.get_user_ref", expression)
+
+	for _, ref := range []Ref{WorkflowInput("userId"), Variable("counter"), Secret("api_key"), EnvVar("region"), task.InputRef("userId")} {
+		_, err = ref.JavaScript()
+		assert.EqualError(t, err, string(ref)+" is not the output of a task, javascript expressions can only refer to the outputs of the tasks")
+	}
+}
+
+func TestReferencesInInputs(t *testing.T) {
+	getUser := NewSimpleTask("get_user", "get_user_ref").
+		Input("userId", WorkflowInput("userId")).
+		Input("apiKey", Secret("api_key"))
+	notify := NewSimpleTask("notify", "notify_ref").
+		InputMap(map[string]interface{}{
+			"email":  getUser.Output("email"),
+			"region": EnvVar("region"),
+		})
+	done, err := getUser.Output("done").JavaScript()
+	assert.NoError(t, err)
+	wf := NewConductorWorkflow(nil).
+		Name("refs").
+		Add(getUser).
+		Add(NewSwitchTask("pref_ref", getUser.Output("pref").String()).
+			SwitchCase("EMAIL", notify)).
+		Add(NewDoWhileTask("loop_ref", done+" == false", NewSimpleTask("poll", "poll_ref")))
+
+	assert.NoError(t, wf.Validate())
+	data, err := json.Marshal(wf.ToWorkflowDef())
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"userId":"${workflow.input.userId}"`)
+	assert.Contains(t, string(data), `"apiKey":"${workflow.secrets.api_key}"`)
+	assert.Contains(t, string(data), `"email":"${get_user_ref.output.email}"`)
+	assert.Contains(t, string(data), `"switchCaseValue":"${get_user_ref.output.pref}"`)
+	assert.Contains(t, string(data), `"loopCondition":"$.get_user_ref['done'] == false"`)
+}
+
+func TestValidateUnknownReferences(t *testing.T) {
+	other := NewSimpleTask("other", "other_ref")
+	wf := NewConductorWorkflow(nil).
+		Name("refs").
+		OutputParameters(map[string]interface{}{"result": "${missing_ref.output.result}"}).
+		Add(NewSimpleTask("get_user", "get_user_ref").
+			Input("nested", map[string]interface{}{"list": []interface{}{other.Output("id")}})).
+		Add(NewDoWhileTask("loop_ref", "$.poll_ref['done'] == false && $.iterations > 0", NewSimpleTask("poll", "poll_ref")).
+			Input("iterations", WorkflowInput("iterations"))).
+		Add(NewDoWhileTask("loop2_ref", "$.missing_input > 0", NewSimpleTask("poll2", "poll2_ref")))
+
+	err := wf.Validate()
+	assert.Error(t, err)
+	assert.Equal(t, "workflow refs has invalid references: "+
+		"task get_user_ref input refers to unknown task other_ref; "+
+		"task loop2_ref loop condition refers to unknown task or input missing_input; "+
+		"workflow output refers to unknown task missing_ref", err.Error())
+
+	// not validated by default, Validate does not know the tasks created at runtime
+	_, defErr := wf.validWorkflowDef()
+	assert.NoError(t, defErr)
+
+	// the workflow is not sent to the server
+	wf.ValidateReferences(true)
+	assert.Equal(t, err, wf.Register(true))
+	_, startErr := wf.StartWorkflowWithInput(map[string]interface{}{})
+	assert.Equal(t, err, startErr)
+	_, startErr = wf.StartWorkflow(&model.StartWorkflowRequest{})
+	assert.Equal(t, err, startErr)
+	_, executeErr := wf.ExecuteWorkflowWithInput(map[string]interface{}{}, "")
+	assert.Equal(t, err, executeErr)
+
+	wf.Add(other)
+	wf.OutputParameters(map[string]interface{}{"result": other.Output("result")})
+	wf.tasks = append(wf.tasks[:2], wf.tasks[3])
+	assert.NoError(t, wf.Validate())
+}
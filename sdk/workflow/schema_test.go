@@ -0,0 +1,47 @@
+package workflow
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/schema"
+	"github.com/stretchr/testify/assert"
+)
+
+type chargeInput struct {
+	CustomerId string  `json:"customerId"`
+	Amount     float64 `json:"amount"`
+}
+
+func TestWorkflowSchemas(t *testing.T) {
+	inputSchema := schema.NewJsonSchemaDef("charge_input", 2, chargeInput{})
+	wf := NewConductorWorkflow(nil).
+		Name("charge").
+		InputSchema(&inputSchema).
+		OutputSchema(model.NewSchemaRef("charge_output", 1)).
+		EnforceSchema(true).
+		Add(NewSimpleTask("charge", "charge_ref"))
+
+	workflowDef := wf.ToWorkflowDef()
+	data, err := json.Marshal(workflowDef)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `"inputSchema":{"name":"charge_input","version":2,"type":"JSON"}`)
+	assert.Contains(t, string(data), `"outputSchema":{"name":"charge_output","version":1,"type":"JSON"}`)
+	assert.Contains(t, string(data), `"enforceSchema":true`)
+
+	_, err = wf.StartWorkflowWithInput(map[string]interface{}{"customerId": "c1"})
+	assert.EqualError(t, err, "invalid input: $: missing required property amount")
+	assert.NoError(t, wf.validateInput(&chargeInput{CustomerId: "c1", Amount: 10}))
+	assert.NoError(t, wf.EnforceSchema(false).validateInput(nil))
+	wf.EnforceSchema(true)
+
+	roundTrip := FromWorkflowDef(workflowDef).ToWorkflowDef()
+	assert.Equal(t, workflowDef.InputSchema, roundTrip.InputSchema)
+	assert.True(t, roundTrip.EnforceSchema)
+
+	source, err := GenerateGoSource(workflowDef, "workflows", "NewChargeWorkflow")
+	assert.NoError(t, err)
+	assert.Contains(t, string(source), `InputSchema(model.NewSchemaRef("charge_input", 2))`)
+	assert.Contains(t, string(source), `EnforceSchema(true)`)
+}
@@ -36,6 +36,12 @@ func NewSubWorkflowTask(taskRefName string, workflowName string, version int32)
 	}
 }
 
+// NewDynamicSubWorkflowTask starts the sub workflow whose name is resolved at runtime, workflowName is an expression,
+// e.g. WorkflowInput("workflowName").String().  The latest version of the workflow is started
+func NewDynamicSubWorkflowTask(taskRefName string, workflowName string) *SubWorkflowTask {
+	return NewSubWorkflowTask(taskRefName, workflowName, 0)
+}
+
 func NewSubWorkflowInlineTask(taskRefName string, workflow *ConductorWorkflow) *SubWorkflowTask {
 	return &SubWorkflowTask{
 		Task: Task{
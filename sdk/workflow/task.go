@@ -53,6 +53,19 @@ const (
 	KAFKA_PUBLISH     TaskType = "KAFKA_PUBLISH"
 	JSON_JQ_TRANSFORM TaskType = "JSON_JQ_TRANSFORM"
 	SET_VARIABLE      TaskType = "SET_VARIABLE"
+	NOOP              TaskType = "NOOP"
+	GET_WORKFLOW      TaskType = "GET_WORKFLOW"
+	BUSINESS_RULE     TaskType = "BUSINESS_RULE"
+	GRPC              TaskType = "GRPC"
+	JDBC              TaskType = "JDBC"
+	QUERY_PROCESSOR   TaskType = "QUERY_PROCESSOR"
+
+	LLM_TEXT_COMPLETE       TaskType = "LLM_TEXT_COMPLETE"
+	LLM_CHAT_COMPLETE       TaskType = "LLM_CHAT_COMPLETE"
+	LLM_GENERATE_EMBEDDINGS TaskType = "LLM_GENERATE_EMBEDDINGS"
+	LLM_STORE_EMBEDDINGS    TaskType = "LLM_STORE_EMBEDDINGS"
+	LLM_INDEX_DOCUMENT      TaskType = "LLM_INDEX_DOCUMENT"
+	LLM_SEARCH_INDEX        TaskType = "LLM_SEARCH_INDEX"
 )
 
 type TaskInterface interface {
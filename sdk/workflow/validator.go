@@ -0,0 +1,125 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import (
+	"fmt"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+var (
+	// ${task_ref.output...} or ${task_ref.input...}
+	taskRefExpression = regexp.MustCompile(`\$\{\s*([\w-]+)\.(?:output|input)\b`)
+	// $.task_ref in javascript expressions
+	javascriptRefExpression = regexp.MustCompile(`\$\.([\w-]+)`)
+	// tasks inside DO_WHILE loops get the iteration appended to the reference name, e.g. task_ref__2
+	iterationSuffix = regexp.MustCompile(`__\d+$`)
+)
+
+// Validate checks that the references to the tasks used in the inputs, expressions and outputs of the workflow refer to
+// tasks of the workflow, e.g. ${get_user_ref.output.id} requires a task with reference name get_user_ref
+func (workflow *ConductorWorkflow) Validate() error {
+	return validateReferences(workflow.toWorkflowDef())
+}
+
+func validateReferences(workflowDef *model.WorkflowDef) error {
+	knownRefs := map[string]bool{"workflow": true}
+	collectTaskRefs(workflowDef.Tasks, knownRefs)
+
+	problems := make([]string, 0)
+	check := func(location string, value interface{}) {
+		for _, ref := range taskRefsIn(value) {
+			if !knownRefs[ref] {
+				problems = append(problems, fmt.Sprintf("%s refers to unknown task %s", location, ref))
+			}
+		}
+	}
+	var validateTasks func(tasks []model.WorkflowTask)
+	validateTasks = func(tasks []model.WorkflowTask) {
+		for _, task := range tasks {
+			location := "task " + task.TaskReferenceName
+			check(location+" input", task.InputParameters)
+			check(location+" expression", task.Expression)
+			if task.LoopCondition != "" {
+				for _, name := range javascriptRefExpression.FindAllStringSubmatch(task.LoopCondition, -1) {
+					_, isInput := task.InputParameters[name[1]]
+					if !isInput && !knownRefs[name[1]] {
+						problems = append(problems, fmt.Sprintf("%s loop condition refers to unknown task or input %s", location, name[1]))
+					}
+				}
+			}
+			for _, caseTasks := range task.DecisionCases {
+				validateTasks(caseTasks)
+			}
+			validateTasks(task.DefaultCase)
+			for _, forkTasks := range task.ForkTasks {
+				validateTasks(forkTasks)
+			}
+			validateTasks(task.LoopOver)
+		}
+	}
+	validateTasks(workflowDef.Tasks)
+	check("workflow output", workflowDef.OutputParameters)
+
+	if len(problems) == 0 {
+		return nil
+	}
+	sort.Strings(problems)
+	return fmt.Errorf("workflow %s has invalid references: %s", workflowDef.Name, strings.Join(problems, "; "))
+}
+
+func collectTaskRefs(tasks []model.WorkflowTask, refs map[string]bool) {
+	for _, task := range tasks {
+		refs[task.TaskReferenceName] = true
+		for _, caseTasks := range task.DecisionCases {
+			collectTaskRefs(caseTasks, refs)
+		}
+		collectTaskRefs(task.DefaultCase, refs)
+		for _, forkTasks := range task.ForkTasks {
+			collectTaskRefs(forkTasks, refs)
+		}
+		collectTaskRefs(task.LoopOver, refs)
+	}
+}
+
+// taskRefsIn returns the task reference names used in the strings of the value, including nested maps and slices
+func taskRefsIn(value interface{}) []string {
+	refs := make([]string, 0)
+	var collect func(value reflect.Value)
+	collect = func(value reflect.Value) {
+		switch value.Kind() {
+		case reflect.Interface, reflect.Ptr:
+			if !value.IsNil() {
+				collect(value.Elem())
+			}
+		case reflect.String:
+			for _, match := range taskRefExpression.FindAllStringSubmatch(value.String(), -1) {
+				refs = append(refs, iterationSuffix.ReplaceAllString(match[1], ""))
+			}
+		case reflect.Map:
+			for _, key := range value.MapKeys() {
+				collect(value.MapIndex(key))
+			}
+		case reflect.Slice, reflect.Array:
+			for i := 0; i < value.Len(); i++ {
+				collect(value.Index(i))
+			}
+		}
+	}
+	if value != nil {
+		collect(reflect.ValueOf(value))
+	}
+	return refs
+}
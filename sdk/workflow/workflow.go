@@ -12,6 +12,7 @@ package workflow
 import (
 	"encoding/json"
 	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/schema"
 	"github.com/conductor-sdk/conductor-go/sdk/workflow/executor"
 	log "github.com/sirupsen/logrus"
 )
@@ -42,6 +43,10 @@ type ConductorWorkflow struct {
 	idempotencyKey                string
 	tags                          []model.TagObject
 	overwiteTags                  bool
+	inputSchema                   *model.SchemaDef
+	outputSchema                  *model.SchemaDef
+	enforceSchema                 bool
+	validateReferences            bool
 }
 
 func NewConductorWorkflow(executor *executor.WorkflowExecutor) *ConductorWorkflow {
@@ -158,6 +163,34 @@ func (workflow *ConductorWorkflow) OverwriteTags(overwrite bool) *ConductorWorkf
 	return workflow
 }
 
+// InputSchema the schema of the workflow input, see schema.NewJsonSchemaDef to generate it from a Go type.
+// Only the name and version of the schema are part of the definition, the schema must be registered separately.
+// When enforced, the inputs of the workflows started using the builder are validated before being sent
+func (workflow *ConductorWorkflow) InputSchema(inputSchema *model.SchemaDef) *ConductorWorkflow {
+	workflow.inputSchema = inputSchema
+	return workflow
+}
+
+// OutputSchema the schema of the workflow output
+func (workflow *ConductorWorkflow) OutputSchema(outputSchema *model.SchemaDef) *ConductorWorkflow {
+	workflow.outputSchema = outputSchema
+	return workflow
+}
+
+// EnforceSchema the server rejects the inputs and outputs that do not conform to the schemas
+func (workflow *ConductorWorkflow) EnforceSchema(enforceSchema bool) *ConductorWorkflow {
+	workflow.enforceSchema = enforceSchema
+	return workflow
+}
+
+// ValidateReferences when set, Register and the methods starting or executing the workflow return the error of Validate
+// without calling the server.  Not set by default, Validate does not know the tasks created at runtime, e.g. the forked
+// tasks of FORK_JOIN_DYNAMIC
+func (workflow *ConductorWorkflow) ValidateReferences(validate bool) *ConductorWorkflow {
+	workflow.validateReferences = validate
+	return workflow
+}
+
 func (workflow *ConductorWorkflow) GetName() (name string) {
 	return workflow.name
 }
@@ -177,8 +210,13 @@ func (workflow *ConductorWorkflow) Add(task TaskInterface) *ConductorWorkflow {
 
 // Register the workflow definition with the server. If overwrite is set, the definition on the server will be overwritten.
 // When not set, the call fails if there is any change in the workflow definition between the server and what is being registered.
+// The references to the tasks are validated first when enabled, see ValidateReferences
 func (workflow *ConductorWorkflow) Register(overwrite bool) error {
-	return workflow.executor.RegisterWorkflow(overwrite, workflow.ToWorkflowDef())
+	workflowDef, err := workflow.validWorkflowDef()
+	if err != nil {
+		return err
+	}
+	return workflow.executor.RegisterWorkflow(overwrite, workflowDef)
 }
 
 // Register the workflow definition with the server. If overwrite is set, the definition on the server will be overwritten.
@@ -190,13 +228,20 @@ func (workflow *ConductorWorkflow) UnRegister() error {
 // Start the workflow with specific input. The input struct MUST be serializable to JSON
 // Returns the workflow Id that can be used to monitor and get the status of the workflow execution.
 func (workflow *ConductorWorkflow) StartWorkflowWithInput(input interface{}) (workflowId string, err error) {
+	if err := workflow.validateInput(input); err != nil {
+		return "", err
+	}
+	workflowDef, err := workflow.validWorkflowDef()
+	if err != nil {
+		return "", err
+	}
 	version := workflow.GetVersion()
 	return workflow.executor.StartWorkflow(
 		&model.StartWorkflowRequest{
 			Name:        workflow.GetName(),
 			Version:     version,
 			Input:       getInputAsMap(input),
-			WorkflowDef: workflow.ToWorkflowDef(),
+			WorkflowDef: workflowDef,
 		},
 	)
 }
@@ -204,7 +249,14 @@ func (workflow *ConductorWorkflow) StartWorkflowWithInput(input interface{}) (wo
 // StartWorkflow starts the workflow execution with startWorkflowRequest that allows you to specify more details like task domains, correlationId etc.
 // Returns the ID of the newly created workflow
 func (workflow *ConductorWorkflow) StartWorkflow(startWorkflowRequest *model.StartWorkflowRequest) (workflowId string, err error) {
-	startWorkflowRequest.WorkflowDef = workflow.ToWorkflowDef()
+	if err := workflow.validateInput(startWorkflowRequest.Input); err != nil {
+		return "", err
+	}
+	workflowDef, err := workflow.validWorkflowDef()
+	if err != nil {
+		return "", err
+	}
+	startWorkflowRequest.WorkflowDef = workflowDef
 	return workflow.executor.StartWorkflow(startWorkflowRequest)
 }
 
@@ -222,13 +274,20 @@ func (workflow *ConductorWorkflow) StartWorkflow(startWorkflowRequest *model.Sta
 // - workflowRun: Contains the workflow execution output (if available).
 // - err: Error, if any occurred during execution or timeout.
 func (workflow *ConductorWorkflow) ExecuteWorkflowWithReturnStrategy(input interface{}, consistency model.WorkflowConsistency, returnStrategy model.ReturnStrategy, waitUntilTask []string, waitForSec int) (workflowRun *model.SignalResponse, err error) {
+	if err := workflow.validateInput(input); err != nil {
+		return nil, err
+	}
+	workflowDef, err := workflow.validWorkflowDef()
+	if err != nil {
+		return nil, err
+	}
 	version := workflow.GetVersion()
 	return workflow.executor.ExecuteWorkflowWithReturnStrategy(
 		&model.StartWorkflowRequest{
 			Name:        workflow.GetName(),
 			Version:     version,
 			Input:       getInputAsMap(input),
-			WorkflowDef: workflow.ToWorkflowDef(),
+			WorkflowDef: workflowDef,
 		},
 		consistency,
 		returnStrategy,
@@ -243,13 +302,20 @@ func (workflow *ConductorWorkflow) ExecuteWorkflowWithReturnStrategy(input inter
 // The input struct MUST be serializable to JSON
 // Returns the workflow output
 func (workflow *ConductorWorkflow) ExecuteWorkflowWithInput(input interface{}, waitUntilTask string) (workflowRun *model.WorkflowRun, err error) {
+	if err := workflow.validateInput(input); err != nil {
+		return nil, err
+	}
+	workflowDef, err := workflow.validWorkflowDef()
+	if err != nil {
+		return nil, err
+	}
 	version := workflow.GetVersion()
 	return workflow.executor.ExecuteWorkflow(
 		&model.StartWorkflowRequest{
 			Name:        workflow.GetName(),
 			Version:     version,
 			Input:       getInputAsMap(input),
-			WorkflowDef: workflow.ToWorkflowDef(),
+			WorkflowDef: workflowDef,
 		},
 		waitUntilTask,
 	)
@@ -265,6 +331,27 @@ func (workflow *ConductorWorkflow) StartWorkflowsAndMonitorExecution(startWorkfl
 	return workflow.executor.MonitorExecution(workflowId)
 }
 
+// validWorkflowDef the definition of the workflow, an error when it refers to unknown tasks and the references are
+// validated, see ValidateReferences
+func (workflow *ConductorWorkflow) validWorkflowDef() (*model.WorkflowDef, error) {
+	workflowDef := workflow.toWorkflowDef()
+	if !workflow.validateReferences {
+		return workflowDef, nil
+	}
+	if err := validateReferences(workflowDef); err != nil {
+		return nil, err
+	}
+	return workflowDef, nil
+}
+
+// validateInput validates the input against the enforced input schema, when the data of the schema is known
+func (workflow *ConductorWorkflow) validateInput(input interface{}) error {
+	if !workflow.enforceSchema {
+		return nil
+	}
+	return schema.ValidateInput(workflow.inputSchema, input)
+}
+
 func getInputAsMap(input interface{}) map[string]interface{} {
 	if input == nil {
 		return nil
@@ -297,8 +384,12 @@ func (workflow *ConductorWorkflow) GetTags() map[string]string {
 	return result
 }
 
-// ToWorkflowDef converts the workflow to the JSON serializable format
+// ToWorkflowDef converts the workflow to the JSON serializable format.  The references are not validated, see Validate
 func (workflow *ConductorWorkflow) ToWorkflowDef() *model.WorkflowDef {
+	return workflow.toWorkflowDef()
+}
+
+func (workflow *ConductorWorkflow) toWorkflowDef() *model.WorkflowDef {
 	return &model.WorkflowDef{
 		Name:                          workflow.name,
 		Description:                   workflow.description,
@@ -317,7 +408,21 @@ func (workflow *ConductorWorkflow) ToWorkflowDef() *model.WorkflowDef {
 		WorkflowStatusListenerEnabled: workflow.workflowStatusListenerEnabled,
 		Tags:                          workflow.tags,
 		OverwriteTags:                 workflow.overwiteTags,
+		InputSchema:                   schemaRef(workflow.inputSchema),
+		OutputSchema:                  schemaRef(workflow.outputSchema),
+		EnforceSchema:                 workflow.enforceSchema,
+	}
+}
+
+func schemaRef(schemaDef *model.SchemaDef) *model.SchemaDef {
+	if schemaDef == nil {
+		return nil
+	}
+	ref := model.NewSchemaRef(schemaDef.Name, schemaDef.Version)
+	if schemaDef.Type_ != "" {
+		ref.Type_ = schemaDef.Type_
 	}
+	return ref
 }
 
 func getWorkflowTasksFromConductorWorkflow(workflow *ConductorWorkflow) []model.WorkflowTask {
@@ -0,0 +1,327 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/antihax/optional"
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/internal"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+type WorkflowDefChangeType string
+
+const (
+	TaskAdded        WorkflowDefChangeType = "TASK_ADDED"
+	TaskRemoved      WorkflowDefChangeType = "TASK_REMOVED"
+	TaskMoved        WorkflowDefChangeType = "TASK_MOVED"
+	TaskModified     WorkflowDefChangeType = "TASK_MODIFIED"
+	WorkflowModified WorkflowDefChangeType = "WORKFLOW_MODIFIED"
+)
+
+// properties maintained by the server, not part of the definition
+var serverManagedProperties = []string{"createTime", "updateTime", "createdBy", "updatedBy", "ownerApp"}
+
+// WorkflowDefChange a single change between two workflow definitions.
+// For added, removed and moved tasks From and To are the locations of the task, e.g. tasks[2] or switch_ref.decisionCases[CARD][0].
+// For modified properties From and To are the values of the property, nil when the property is not set
+type WorkflowDefChange struct {
+	Type              WorkflowDefChangeType `json:"type"`
+	TaskReferenceName string                `json:"taskReferenceName,omitempty"`
+	TaskType          string                `json:"taskType,omitempty"`
+	Property          string                `json:"property,omitempty"`
+	From              interface{}           `json:"from,omitempty"`
+	To                interface{}           `json:"to,omitempty"`
+}
+
+// WorkflowDefDiff the changes required to turn one workflow definition into another.
+// Serializes to JSON, String returns the human-readable form
+type WorkflowDefDiff struct {
+	Name        string              `json:"name"`
+	FromVersion int32               `json:"fromVersion"`
+	ToVersion   int32               `json:"toVersion"`
+	Changes     []WorkflowDefChange `json:"changes"`
+}
+
+// DiffWorkflowDefs compares the definitions structurally.  Tasks are matched by their taskReferenceName rather than by
+// their position, so inserting a task reports a single added task instead of changes to all the tasks that follow it.
+// from may be nil, e.g. the workflow is not yet registered, in which case all the tasks are reported as added
+func DiffWorkflowDefs(from *model.WorkflowDef, to *model.WorkflowDef) *WorkflowDefDiff {
+	if from == nil {
+		from = &model.WorkflowDef{Name: to.Name}
+	}
+	diff := &WorkflowDefDiff{
+		Name:        to.Name,
+		FromVersion: from.Version,
+		ToVersion:   to.Version,
+		Changes:     make([]WorkflowDefChange, 0),
+	}
+	diff.diffWorkflowProperties(from, to)
+
+	fromTasks := newTaskLocations(from.Tasks)
+	toTasks := newTaskLocations(to.Tasks)
+	moved := movedTasks(fromTasks, toTasks)
+	for _, ref := range fromTasks.order {
+		if _, found := toTasks.locations[ref]; !found {
+			location := fromTasks.locations[ref]
+			diff.add(WorkflowDefChange{Type: TaskRemoved, TaskReferenceName: ref, TaskType: location.task.Type_, From: location.String()})
+		}
+	}
+	for _, ref := range toTasks.order {
+		location := toTasks.locations[ref]
+		fromLocation, found := fromTasks.locations[ref]
+		if !found {
+			diff.add(WorkflowDefChange{Type: TaskAdded, TaskReferenceName: ref, TaskType: location.task.Type_, To: location.String()})
+			continue
+		}
+		if moved[ref] {
+			diff.add(WorkflowDefChange{Type: TaskMoved, TaskReferenceName: ref, TaskType: location.task.Type_, From: fromLocation.String(), To: location.String()})
+		}
+		diff.diffTaskProperties(fromLocation.task, location.task)
+	}
+	return diff
+}
+
+// DiffWithServer compares the workflow with the definition of the same name and version registered on the server,
+// e.g. to review the changes before registering the workflow with overwrite
+func (workflow *ConductorWorkflow) DiffWithServer(ctx context.Context, metadataClient client.MetadataClient) (*WorkflowDefDiff, error) {
+	opts := &client.MetadataResourceApiGetOpts{}
+	if workflow.version > 0 {
+		opts.Version = optional.NewInt32(workflow.version)
+	}
+	registered, response, err := metadataClient.Get(ctx, workflow.name, opts)
+	if internal.IsNotFound(response, err) {
+		return DiffWorkflowDefs(nil, workflow.ToWorkflowDef()), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return DiffWorkflowDefs(&registered, workflow.ToWorkflowDef()), nil
+}
+
+// HasChanges returns true if the definitions are not equivalent
+func (diff *WorkflowDefDiff) HasChanges() bool {
+	return len(diff.Changes) > 0
+}
+
+// String the human-readable list of changes, one per line
+func (diff *WorkflowDefDiff) String() string {
+	var builder strings.Builder
+	fmt.Fprintf(&builder, "workflow %s version %d -> %d: %d changes\n", diff.Name, diff.FromVersion, diff.ToVersion, len(diff.Changes))
+	for _, change := range diff.Changes {
+		switch change.Type {
+		case TaskAdded:
+			fmt.Fprintf(&builder, "+ %s (%s) added at %s\n", change.TaskReferenceName, change.TaskType, change.To)
+		case TaskRemoved:
+			fmt.Fprintf(&builder, "- %s (%s) removed from %s\n", change.TaskReferenceName, change.TaskType, change.From)
+		case TaskMoved:
+			fmt.Fprintf(&builder, "> %s moved from %s to %s\n", change.TaskReferenceName, change.From, change.To)
+		case TaskModified:
+			fmt.Fprintf(&builder, "~ %s %s: %s -> %s\n", change.TaskReferenceName, change.Property, internal.FormatValue(change.From), internal.FormatValue(change.To))
+		case WorkflowModified:
+			fmt.Fprintf(&builder, "~ %s: %s -> %s\n", change.Property, internal.FormatValue(change.From), internal.FormatValue(change.To))
+		}
+	}
+	return builder.String()
+}
+
+func (diff *WorkflowDefDiff) add(change WorkflowDefChange) {
+	diff.Changes = append(diff.Changes, change)
+}
+
+func (diff *WorkflowDefDiff) diffWorkflowProperties(from *model.WorkflowDef, to *model.WorkflowDef) {
+	ignored := append([]string{"tasks", "version"}, serverManagedProperties...)
+	diffProperties("", comparableProperties(from, ignored...), comparableProperties(to, ignored...), func(property string, fromValue, toValue interface{}) {
+		diff.add(WorkflowDefChange{Type: WorkflowModified, Property: property, From: fromValue, To: toValue})
+	})
+}
+
+func (diff *WorkflowDefDiff) diffTaskProperties(from model.WorkflowTask, to model.WorkflowTask) {
+	onChange := func(property string, fromValue, toValue interface{}) {
+		diff.add(WorkflowDefChange{Type: TaskModified, TaskReferenceName: to.TaskReferenceName, TaskType: to.Type_, Property: property, From: fromValue, To: toValue})
+	}
+	// nested tasks are compared on their own
+	ignored := []string{"decisionCases", "defaultCase", "forkTasks", "loopOver", "inputParameters", "taskDefinition"}
+	diffProperties("", comparableProperties(from, ignored...), comparableProperties(to, ignored...), onChange)
+	diffProperties("inputParameters.", comparableMap(from.InputParameters), comparableMap(to.InputParameters), onChange)
+	diffProperties("taskDefinition.", comparableProperties(from.TaskDefinition, serverManagedProperties...),
+		comparableProperties(to.TaskDefinition, serverManagedProperties...), onChange)
+}
+
+// diffProperties calls onChange for each property with a different value, nested objects are compared property by property
+func diffProperties(prefix string, from map[string]interface{}, to map[string]interface{}, onChange func(property string, from, to interface{})) {
+	keys := make([]string, 0, len(from)+len(to))
+	for key := range from {
+		keys = append(keys, key)
+	}
+	for key := range to {
+		if _, found := from[key]; !found {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+	for _, key := range keys {
+		fromValue, toValue := from[key], to[key]
+		fromMap, fromIsMap := fromValue.(map[string]interface{})
+		toMap, toIsMap := toValue.(map[string]interface{})
+		if fromIsMap && toIsMap {
+			diffProperties(prefix+key+".", fromMap, toMap, onChange)
+		} else if !reflect.DeepEqual(fromValue, toValue) {
+			onChange(prefix+key, fromValue, toValue)
+		}
+	}
+}
+
+// comparableProperties the JSON properties of the value excluding the ignored ones.
+// Properties with zero values are dropped as the server fills in the defaults, e.g. "optional": false
+func comparableProperties(value interface{}, ignored ...string) map[string]interface{} {
+	properties := comparableMap(value)
+	for _, key := range ignored {
+		delete(properties, key)
+	}
+	for key, v := range properties {
+		if v == false || v == float64(0) || v == "" {
+			delete(properties, key)
+		}
+	}
+	return properties
+}
+
+func comparableMap(value interface{}) map[string]interface{} {
+	comparable, err := toComparableJson(value)
+	if err != nil {
+		return map[string]interface{}{}
+	}
+	properties, isMap := comparable.(map[string]interface{})
+	if !isMap {
+		return map[string]interface{}{}
+	}
+	return properties
+}
+
+type taskLocation struct {
+	task      model.WorkflowTask
+	container string
+	index     int
+}
+
+func (location *taskLocation) String() string {
+	return fmt.Sprintf("%s[%d]", location.container, location.index)
+}
+
+type taskLocations struct {
+	locations map[string]*taskLocation
+	// reference names in the order of appearance
+	order []string
+	// reference names of the tasks in each container
+	containers map[string][]string
+}
+
+func newTaskLocations(tasks []model.WorkflowTask) *taskLocations {
+	locations := &taskLocations{
+		locations:  map[string]*taskLocation{},
+		containers: map[string][]string{},
+	}
+	locations.collect("tasks", tasks)
+	return locations
+}
+
+func (locations *taskLocations) collect(container string, tasks []model.WorkflowTask) {
+	for i, task := range tasks {
+		ref := task.TaskReferenceName
+		locations.locations[ref] = &taskLocation{task: task, container: container, index: i}
+		locations.order = append(locations.order, ref)
+		locations.containers[container] = append(locations.containers[container], ref)
+
+		cases := make([]string, 0, len(task.DecisionCases))
+		for caseValue := range task.DecisionCases {
+			cases = append(cases, caseValue)
+		}
+		sort.Strings(cases)
+		for _, caseValue := range cases {
+			locations.collect(fmt.Sprintf("%s.decisionCases[%s]", ref, caseValue), task.DecisionCases[caseValue])
+		}
+		locations.collect(ref+".defaultCase", task.DefaultCase)
+		for branch, forkedTasks := range task.ForkTasks {
+			locations.collect(fmt.Sprintf("%s.forkTasks[%d]", ref, branch), forkedTasks)
+		}
+		locations.collect(ref+".loopOver", task.LoopOver)
+	}
+}
+
+// movedTasks returns the tasks moved to another container, or whose order changed relative to the other tasks in the
+// same container.  The longest sequence of tasks keeping their relative order is considered unchanged
+func movedTasks(from *taskLocations, to *taskLocations) map[string]bool {
+	moved := map[string]bool{}
+	for ref, location := range to.locations {
+		if fromLocation, found := from.locations[ref]; found && fromLocation.container != location.container {
+			moved[ref] = true
+		}
+	}
+	for container, toRefs := range to.containers {
+		fromRefs := commonTasks(from.containers[container], to, container)
+		toRefs = commonTasks(toRefs, from, container)
+		unchanged := longestCommonSubsequence(fromRefs, toRefs)
+		for _, ref := range toRefs {
+			if !unchanged[ref] {
+				moved[ref] = true
+			}
+		}
+	}
+	return moved
+}
+
+// commonTasks filters refs to the tasks that are in the same container of the other definition
+func commonTasks(refs []string, other *taskLocations, container string) []string {
+	common := make([]string, 0, len(refs))
+	for _, ref := range refs {
+		if location, found := other.locations[ref]; found && location.container == container {
+			common = append(common, ref)
+		}
+	}
+	return common
+}
+
+func longestCommonSubsequence(a []string, b []string) map[string]bool {
+	lengths := make([][]int, len(a)+1)
+	for i := range lengths {
+		lengths[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lengths[i][j] = lengths[i+1][j+1] + 1
+			} else if lengths[i+1][j] >= lengths[i][j+1] {
+				lengths[i][j] = lengths[i+1][j]
+			} else {
+				lengths[i][j] = lengths[i][j+1]
+			}
+		}
+	}
+	common := map[string]bool{}
+	for i, j := 0, 0; i < len(a) && j < len(b); {
+		if a[i] == b[j] {
+			common[a[i]] = true
+			i++
+			j++
+		} else if lengths[i+1][j] >= lengths[i][j+1] {
+			i++
+		} else {
+			j++
+		}
+	}
+	return common
+}
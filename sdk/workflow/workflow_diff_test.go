@@ -0,0 +1,116 @@
+package workflow
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffWorkflowDefsNoChanges(t *testing.T) {
+	diff := DiffWorkflowDefs(newTestWorkflow().ToWorkflowDef(), newTestWorkflow().ToWorkflowDef())
+	assert.False(t, diff.HasChanges(), diff.String())
+}
+
+func TestDiffWorkflowDefsIgnoresServerDefaults(t *testing.T) {
+	data, err := json.Marshal(newTestWorkflow().ToWorkflowDef())
+	assert.NoError(t, err)
+	var fromServer map[string]interface{}
+	assert.NoError(t, json.Unmarshal(data, &fromServer))
+	fromServer["createTime"] = 1700000000000
+	fromServer["updatedBy"] = "admin"
+	fromServer["tasks"].([]interface{})[0].(map[string]interface{})["asyncComplete"] = false
+	fromServer["tasks"].([]interface{})[0].(map[string]interface{})["loopOver"] = []interface{}{}
+	data, err = json.Marshal(fromServer)
+	assert.NoError(t, err)
+	var registered model.WorkflowDef
+	assert.NoError(t, json.Unmarshal(data, &registered))
+
+	diff := DiffWorkflowDefs(&registered, newTestWorkflow().ToWorkflowDef())
+	assert.False(t, diff.HasChanges(), diff.String())
+}
+
+func TestDiffWorkflowDefs(t *testing.T) {
+	from := newTestWorkflow().ToWorkflowDef()
+	to := NewConductorWorkflow(nil).
+		Name("order_fulfillment").
+		Version(3).
+		Description("fulfills the orders").
+		OwnerEmail("orders@example.com").
+		TimeoutPolicy(TimeOutWorkflow, 7200).
+		InputParameters("orderId").
+		OutputParameters(map[string]interface{}{"status": "${ship_ref.output.shipmentStatus}"}).
+		Add(NewDoWhileTask("ship_loop_ref", "$.ship_ref['done'] == true", NewSimpleTask("ship", "ship_ref"))).
+		Add(NewSimpleTask("get_order", "get_order_ref").
+			Input("orderId", "${workflow.input.id}").
+			Input("includeItems", true).
+			RetryPolicy(5, "FIXED", 10, 1)).
+		Add(NewSwitchTask("payment_ref", "${get_order_ref.output.paymentType}").
+			SwitchCase("CARD", NewSimpleTask("charge_card", "charge_card_ref"), NewSimpleTask("send_receipt", "send_receipt_ref")).
+			DefaultCase(NewTerminateTask("unsupported_ref", model.FailedWorkflow, "unsupported payment"))).
+		Add(NewForkTask("fork_ref",
+			[]TaskInterface{NewWaitTask("wait_ref")},
+			[]TaskInterface{NewHttpTask("notify_ref", &HttpInput{Uri: "https://example.com/notify", Method: POST})},
+		)).
+		ToWorkflowDef()
+
+	diff := DiffWorkflowDefs(from, to)
+	assert.Equal(t, int32(2), diff.FromVersion)
+	assert.Equal(t, int32(3), diff.ToVersion)
+	assert.ElementsMatch(t, []WorkflowDefChange{
+		{Type: WorkflowModified, Property: "outputParameters.status", From: "${ship_ref.output.status}", To: "${ship_ref.output.shipmentStatus}"},
+		{Type: WorkflowModified, Property: "timeoutSeconds", From: float64(3600), To: float64(7200)},
+		{Type: TaskRemoved, TaskReferenceName: "custom_ref", TaskType: "CUSTOM_TYPE", From: "tasks[5]"},
+		{Type: TaskMoved, TaskReferenceName: "ship_loop_ref", TaskType: "DO_WHILE", From: "tasks[4]", To: "tasks[0]"},
+		{Type: TaskModified, TaskReferenceName: "get_order_ref", TaskType: "SIMPLE", Property: "inputParameters.includeItems", To: true},
+		{Type: TaskModified, TaskReferenceName: "get_order_ref", TaskType: "SIMPLE", Property: "inputParameters.orderId", From: "${workflow.input.orderId}", To: "${workflow.input.id}"},
+		{Type: TaskModified, TaskReferenceName: "get_order_ref", TaskType: "SIMPLE", Property: "taskDefinition.retryCount", From: float64(3), To: float64(5)},
+		{Type: TaskAdded, TaskReferenceName: "send_receipt_ref", TaskType: "SIMPLE", To: "payment_ref.decisionCases[CARD][1]"},
+		{Type: TaskMoved, TaskReferenceName: "notify_ref", TaskType: "HTTP", From: "fork_ref.forkTasks[0][0]", To: "fork_ref.forkTasks[1][0]"},
+		{Type: TaskMoved, TaskReferenceName: "wait_ref", TaskType: "WAIT", From: "fork_ref.forkTasks[1][0]", To: "fork_ref.forkTasks[0][0]"},
+	}, diff.Changes)
+
+	text := diff.String()
+	assert.Contains(t, text, "workflow order_fulfillment version 2 -> 3: 10 changes")
+	assert.Contains(t, text, "+ send_receipt_ref (SIMPLE) added at payment_ref.decisionCases[CARD][1]")
+	assert.Contains(t, text, "- custom_ref (CUSTOM_TYPE) removed from tasks[5]")
+	assert.Contains(t, text, "> ship_loop_ref moved from tasks[4] to tasks[0]")
+	assert.Contains(t, text, `~ get_order_ref inputParameters.includeItems: (none) -> true`)
+	assert.Contains(t, text, `~ timeoutSeconds: 3600 -> 7200`)
+
+	data, err := json.Marshal(diff)
+	assert.NoError(t, err)
+	assert.Contains(t, string(data), `{"type":"TASK_ADDED","taskReferenceName":"send_receipt_ref","taskType":"SIMPLE","to":"payment_ref.decisionCases[CARD][1]"}`)
+}
+
+func TestDiffWithServer(t *testing.T) {
+	registered := newTestWorkflow().ToWorkflowDef()
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path == "/metadata/workflow/new_workflow" {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+		assert.Equal(t, "/metadata/workflow/order_fulfillment", r.URL.Path)
+		assert.Equal(t, "2", r.URL.Query().Get("version"))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(registered)
+	}))
+	defer server.Close()
+	metadataClient := client.NewMetadataClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+
+	wf := newTestWorkflow()
+	wf.Add(NewWaitTask("final_wait_ref"))
+	diff, err := wf.DiffWithServer(context.Background(), metadataClient)
+	assert.NoError(t, err)
+	assert.Equal(t, []WorkflowDefChange{{Type: TaskAdded, TaskReferenceName: "final_wait_ref", TaskType: "WAIT", To: "tasks[6]"}}, diff.Changes)
+
+	diff, err = NewConductorWorkflow(nil).Name("new_workflow").Version(1).Add(NewWaitTask("wait_ref")).DiffWithServer(context.Background(), metadataClient)
+	assert.NoError(t, err)
+	assert.Contains(t, diff.Changes, WorkflowDefChange{Type: TaskAdded, TaskReferenceName: "wait_ref", TaskType: "WAIT", To: "tasks[0]"})
+}
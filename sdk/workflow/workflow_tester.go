@@ -0,0 +1,229 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package workflow
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+)
+
+// WorkflowTest executes the workflow using the server's test endpoint.
+// Tasks are not scheduled to the workers, instead the mocked outputs are used as the result of the task execution.
+// Useful to verify the wiring of the workflow (SWITCH cases, DO_WHILE loops etc.) without deploying any workers.
+type WorkflowTest struct {
+	workflow      *ConductorWorkflow
+	input         map[string]interface{}
+	correlationId string
+	taskMocks     map[string][]model.TaskMock
+	subWorkflows  map[string]*WorkflowTest
+}
+
+// Test creates a new test run for the workflow
+func (workflow *ConductorWorkflow) Test() *WorkflowTest {
+	return &WorkflowTest{
+		workflow:     workflow,
+		taskMocks:    map[string][]model.TaskMock{},
+		subWorkflows: map[string]*WorkflowTest{},
+	}
+}
+
+// MockTask mocks the execution of the task with taskRefName.
+// When the task is executed multiple times (retries, DO_WHILE iterations) the mocks are used in the given order
+func (test *WorkflowTest) MockTask(taskRefName string, mocks ...model.TaskMock) *WorkflowTest {
+	test.taskMocks[taskRefName] = append(test.taskMocks[taskRefName], mocks...)
+	return test
+}
+
+// MockSubWorkflow mocks the tasks of the sub workflow started by the SUB_WORKFLOW task with taskRefName
+func (test *WorkflowTest) MockSubWorkflow(taskRefName string, subWorkflowTest *WorkflowTest) *WorkflowTest {
+	test.subWorkflows[taskRefName] = subWorkflowTest
+	return test
+}
+
+// WithInput input to the workflow.  The input struct MUST be serializable to JSON
+func (test *WorkflowTest) WithInput(input interface{}) *WorkflowTest {
+	test.input = getInputAsMap(input)
+	return test
+}
+
+// CorrelationId of the test workflow execution
+func (test *WorkflowTest) CorrelationId(correlationId string) *WorkflowTest {
+	test.correlationId = correlationId
+	return test
+}
+
+// ToWorkflowTestRequest converts the test to the request sent to the server
+func (test *WorkflowTest) ToWorkflowTestRequest() *model.WorkflowTestRequest {
+	request := &model.WorkflowTestRequest{
+		Name:                test.workflow.name,
+		Version:             test.workflow.version,
+		CorrelationId:       test.correlationId,
+		Input:               test.input,
+		TaskRefToMockOutput: test.taskMocks,
+	}
+	if len(test.workflow.tasks) > 0 {
+		request.WorkflowDef = test.workflow.ToWorkflowDef()
+	}
+	if len(test.subWorkflows) > 0 {
+		request.SubWorkflowTestRequest = make(map[string]model.WorkflowTestRequest, len(test.subWorkflows))
+		for taskRefName, subWorkflowTest := range test.subWorkflows {
+			request.SubWorkflowTestRequest[taskRefName] = *subWorkflowTest.ToWorkflowTestRequest()
+		}
+	}
+	return request
+}
+
+// Run executes the test and returns the resulting workflow execution
+func (test *WorkflowTest) Run() (*WorkflowTestResult, error) {
+	return test.RunWithContext(context.Background())
+}
+
+// RunWithContext executes the test and returns the resulting workflow execution
+func (test *WorkflowTest) RunWithContext(ctx context.Context) (*WorkflowTestResult, error) {
+	if test.workflow.executor == nil {
+		return nil, fmt.Errorf("workflow %s has no executor", test.workflow.name)
+	}
+	workflow, err := test.workflow.executor.TestWorkflowWithContext(ctx, test.ToWorkflowTestRequest())
+	if err != nil {
+		return nil, err
+	}
+	return NewWorkflowTestResult(workflow), nil
+}
+
+// WorkflowTestResult the workflow execution returned by the test run along with the matchers to verify it.
+// Matchers return a descriptive error when the expectation is not met, e.g. assert.NoError(t, result.ExpectPath("a", "b"))
+type WorkflowTestResult struct {
+	*model.Workflow
+}
+
+func NewWorkflowTestResult(workflow *model.Workflow) *WorkflowTestResult {
+	return &WorkflowTestResult{Workflow: workflow}
+}
+
+// TaskExecutions returns all the executions of the task with taskRefName, including retries and loop iterations
+func (result *WorkflowTestResult) TaskExecutions(taskRefName string) []model.Task {
+	executions := make([]model.Task, 0)
+	for _, task := range result.Tasks {
+		if matchesTaskRefName(task, taskRefName) {
+			executions = append(executions, task)
+		}
+	}
+	return executions
+}
+
+// Task returns the last execution of the task with taskRefName.  Returns nil if the task was not executed
+func (result *WorkflowTestResult) Task(taskRefName string) *model.Task {
+	executions := result.TaskExecutions(taskRefName)
+	if len(executions) == 0 {
+		return nil
+	}
+	return &executions[len(executions)-1]
+}
+
+// ExecutedTaskRefs returns the reference names of the executed tasks in the order of execution
+func (result *WorkflowTestResult) ExecutedTaskRefs() []string {
+	refs := make([]string, len(result.Tasks))
+	for i, task := range result.Tasks {
+		refs[i] = task.ReferenceTaskName
+	}
+	return refs
+}
+
+// ExpectStatus verifies the status of the workflow
+func (result *WorkflowTestResult) ExpectStatus(status model.WorkflowStatus) error {
+	if result.Status != status {
+		return fmt.Errorf("expected workflow status %s, got %s (%s)", status, result.Status, result.ReasonForIncompletion)
+	}
+	return nil
+}
+
+// ExpectTaskStatus verifies the status of the last execution of the task
+func (result *WorkflowTestResult) ExpectTaskStatus(taskRefName string, status model.TaskResultStatus) error {
+	task := result.Task(taskRefName)
+	if task == nil {
+		return fmt.Errorf("expected task %s to be executed with status %s, but it was not executed", taskRefName, status)
+	}
+	if task.Status != status {
+		return fmt.Errorf("expected task %s status %s, got %s", taskRefName, status, task.Status)
+	}
+	return nil
+}
+
+// ExpectTaskOutput verifies the value of key in the output of the last execution of the task.
+// The expected value is compared with its JSON representation, so an int matches the float64 decoded from the response
+func (result *WorkflowTestResult) ExpectTaskOutput(taskRefName string, key string, expected interface{}) error {
+	task := result.Task(taskRefName)
+	if task == nil {
+		return fmt.Errorf("expected task %s to be executed, but it was not executed", taskRefName)
+	}
+	actual, ok := task.OutputData[key]
+	if !ok {
+		return fmt.Errorf("expected output of task %s to contain %s", taskRefName, key)
+	}
+	normalized, err := model.ConvertToMap(map[string]interface{}{key: expected})
+	if err != nil {
+		return err
+	}
+	if !reflect.DeepEqual(normalized[key], actual) {
+		return fmt.Errorf("expected output %s of task %s to be %v, got %v", key, taskRefName, expected, actual)
+	}
+	return nil
+}
+
+// ExpectTaskExecutions verifies the number of times the task was executed, e.g. the iterations of a task inside DO_WHILE
+func (result *WorkflowTestResult) ExpectTaskExecutions(taskRefName string, count int) error {
+	executions := len(result.TaskExecutions(taskRefName))
+	if executions != count {
+		return fmt.Errorf("expected task %s to be executed %d times, got %d", taskRefName, count, executions)
+	}
+	return nil
+}
+
+// ExpectTaskNotExecuted verifies the task was never scheduled, e.g. a task in a SWITCH case that was not taken
+func (result *WorkflowTestResult) ExpectTaskNotExecuted(taskRefName string) error {
+	if task := result.Task(taskRefName); task != nil {
+		return fmt.Errorf("expected task %s not to be executed, got status %s", taskRefName, task.Status)
+	}
+	return nil
+}
+
+// ExpectPath verifies the tasks were executed in the given order.
+// Other tasks may be executed in between, only the relative order of the given tasks is verified
+func (result *WorkflowTestResult) ExpectPath(taskRefNames ...string) error {
+	next := 0
+	for _, task := range result.Tasks {
+		if next == len(taskRefNames) {
+			break
+		}
+		if matchesTaskRefName(task, taskRefNames[next]) {
+			next++
+		}
+	}
+	if next < len(taskRefNames) {
+		return fmt.Errorf("expected path %v, task %s was not executed after %v; executed tasks: %v",
+			taskRefNames, taskRefNames[next], taskRefNames[:next], result.ExecutedTaskRefs())
+	}
+	return nil
+}
+
+// tasks inside DO_WHILE loops get the iteration appended to the reference name, e.g. task_ref__2.
+// Only the suffix of the iteration copies is ignored, a task declared as task_ref__2 matches only itself
+func matchesTaskRefName(task model.Task, taskRefName string) bool {
+	if task.ReferenceTaskName == taskRefName {
+		return true
+	}
+	if task.Iteration <= 0 {
+		return false
+	}
+	return task.ReferenceTaskName == fmt.Sprintf("%s__%d", taskRefName, task.Iteration)
+}
@@ -0,0 +1,97 @@
+package workflow
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/workflow/executor"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestWorkflowTestRequest(t *testing.T) {
+	wf := NewConductorWorkflow(nil).
+		Name("notification").
+		Version(2).
+		Add(NewSimpleTask("get_user_info", "get_user_info")).
+		Add(NewSwitchTask("emailorsms", "${get_user_info.output.pref}").
+			SwitchCase("EMAIL", createSendEmailTask()).
+			SwitchCase("SMS", createSendSMSTask()))
+
+	request := wf.Test().
+		WithInput(map[string]interface{}{"userId": "u1"}).
+		MockTask("get_user_info", model.NewTaskMock(model.CompletedTask, map[string]interface{}{"pref": "EMAIL"})).
+		MockTask("send_email", model.NewTaskMock(model.FailedTask, nil), model.NewTaskMock(model.CompletedTask, nil)).
+		ToWorkflowTestRequest()
+
+	assert.Equal(t, "notification", request.Name)
+	assert.Equal(t, int32(2), request.Version)
+	assert.Equal(t, "u1", request.Input["userId"])
+	assert.NotNil(t, request.WorkflowDef)
+	assert.Len(t, request.WorkflowDef.Tasks, 2)
+	assert.Len(t, request.TaskRefToMockOutput["send_email"], 2)
+	assert.Equal(t, "FAILED", request.TaskRefToMockOutput["send_email"][0].Status)
+	assert.Equal(t, "EMAIL", request.TaskRefToMockOutput["get_user_info"][0].Output["pref"])
+}
+
+func TestWorkflowTestRun(t *testing.T) {
+	var received model.WorkflowTestRequest
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/workflow/test", r.URL.Path)
+		assert.NoError(t, json.NewDecoder(r.Body).Decode(&received))
+		w.Header().Set("Content-Type", "application/json")
+		json.NewEncoder(w).Encode(model.Workflow{
+			Status: model.CompletedWorkflow,
+			Tasks: []model.Task{
+				{ReferenceTaskName: "get_user_info", Status: model.CompletedTask, OutputData: map[string]interface{}{"pref": "EMAIL", "retries": 2}},
+				{ReferenceTaskName: "emailorsms", Status: model.CompletedTask},
+				{ReferenceTaskName: "send_email", Status: model.CompletedTask},
+			},
+		})
+	}))
+	defer server.Close()
+
+	apiClient := client.NewAPIClient(nil, settings.NewHttpSettings(server.URL))
+	wf := NewConductorWorkflow(executor.NewWorkflowExecutor(apiClient)).
+		Name("notification").
+		Version(1).
+		Add(NewSimpleTask("get_user_info", "get_user_info"))
+
+	result, err := wf.Test().
+		MockTask("get_user_info", model.NewTaskMock(model.CompletedTask, map[string]interface{}{"pref": "EMAIL"})).
+		Run()
+
+	assert.NoError(t, err)
+	assert.Equal(t, "notification", received.Name)
+	assert.NoError(t, result.ExpectStatus(model.CompletedWorkflow))
+	assert.NoError(t, result.ExpectTaskStatus("send_email", model.CompletedTask))
+	assert.NoError(t, result.ExpectTaskOutput("get_user_info", "retries", 2))
+	assert.NoError(t, result.ExpectPath("get_user_info", "send_email"))
+	assert.NoError(t, result.ExpectTaskNotExecuted("send_sms"))
+	assert.Error(t, result.ExpectPath("send_email", "get_user_info"))
+	assert.Error(t, result.ExpectTaskStatus("send_sms", model.CompletedTask))
+}
+
+func TestWorkflowTestResultLoopIterations(t *testing.T) {
+	result := NewWorkflowTestResult(&model.Workflow{
+		Status: model.CompletedWorkflow,
+		Tasks: []model.Task{
+			{ReferenceTaskName: "loop", Status: model.CompletedTask},
+			{ReferenceTaskName: "step__1", Status: model.CompletedTask, Iteration: 1},
+			{ReferenceTaskName: "step__2", Status: model.FailedTask, Iteration: 2},
+			{ReferenceTaskName: "notify__3", Status: model.CompletedTask},
+		},
+	})
+
+	assert.NoError(t, result.ExpectTaskExecutions("step", 2))
+	assert.NoError(t, result.ExpectTaskStatus("step", model.FailedTask))
+	assert.NoError(t, result.ExpectTaskStatus("step__1", model.CompletedTask))
+	assert.NoError(t, result.ExpectPath("loop", "step", "step"))
+	assert.Error(t, result.ExpectPath("loop", "step", "step", "step"))
+	assert.NoError(t, result.ExpectTaskExecutions("notify__3", 1))
+	assert.NoError(t, result.ExpectTaskNotExecuted("notify"))
+}
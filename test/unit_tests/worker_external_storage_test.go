@@ -0,0 +1,202 @@
+//  Licensed under the Apache License, Version 2.0 (the "License"); you may not use this file except in compliance with
+//  the License. You may obtain a copy of the License at
+//
+//  http://www.apache.org/licenses/LICENSE-2.0
+//
+//  Unless required by applicable law or agreed to in writing, software distributed under the License is distributed on
+//  an "AS IS" BASIS, WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied. See the License for the
+//  specific language governing permissions and limitations under the License.
+
+package unit_tests
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/conductor-sdk/conductor-go/sdk/client"
+	"github.com/conductor-sdk/conductor-go/sdk/metrics"
+	"github.com/conductor-sdk/conductor-go/sdk/model"
+	"github.com/conductor-sdk/conductor-go/sdk/settings"
+	"github.com/conductor-sdk/conductor-go/sdk/worker"
+	"github.com/stretchr/testify/assert"
+)
+
+// stubPayloadStore keeps the payloads in memory by location, failing the uploads when uploadErr is set
+type stubPayloadStore struct {
+	mutex     sync.Mutex
+	payloads  map[string][]byte
+	uploadErr error
+}
+
+func (s *stubPayloadStore) Upload(ctx context.Context, uri string, payload []byte) error {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	if s.uploadErr != nil {
+		return s.uploadErr
+	}
+	s.payloads[uri] = payload
+	return nil
+}
+
+func (s *stubPayloadStore) Download(ctx context.Context, uri string) ([]byte, error) {
+	s.mutex.Lock()
+	defer s.mutex.Unlock()
+	payload, found := s.payloads[uri]
+	if !found {
+		return nil, fmt.Errorf("no payload at %s", uri)
+	}
+	return payload, nil
+}
+
+// runTask polls the task once from a fake server and returns the result the runner sends to update it
+func runTask(t *testing.T, store *stubPayloadStore, task model.Task, executeFunction model.ExecuteTaskFunction) *model.TaskResult {
+	results := make(chan *model.TaskResult, 1)
+	var polled sync.Once
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch {
+		case strings.HasPrefix(r.URL.Path, "/tasks/poll/batch/"):
+			tasks := []model.Task{}
+			polled.Do(func() { tasks = append(tasks, task) })
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(tasks)
+		case r.URL.Path == "/tasks/externalstoragelocation":
+			path := r.URL.Query().Get("path")
+			if path == "" {
+				path = strings.ToLower(r.URL.Query().Get("payloadType")) + ".json"
+			}
+			w.Header().Set("Content-Type", "application/json")
+			json.NewEncoder(w).Encode(model.ExternalStorageLocation{Path: path, Uri: "stub://" + path})
+		case r.URL.Path == "/tasks" && r.Method == http.MethodPost:
+			var taskResult model.TaskResult
+			json.NewDecoder(r.Body).Decode(&taskResult)
+			results <- &taskResult
+			w.Header().Set("Content-Type", "text/plain")
+			w.Write([]byte(task.TaskId))
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer server.Close()
+
+	taskRunner := worker.NewTaskRunnerWithApiClient(client.NewAPIClient(nil, settings.NewHttpSettings(server.URL)))
+	taskRunner.SetExternalStorageSettings(settings.NewExternalPayloadStorageSettings(1, 4, store))
+	taskRunner.StartWorker(task.TaskDefName, executeFunction, 1, 10*time.Millisecond)
+	defer taskRunner.Shutdown(task.TaskDefName)
+	select {
+	case taskResult := <-results:
+		return taskResult
+	case <-time.After(5 * time.Second):
+		t.Fatal("the task was not updated")
+		return nil
+	}
+}
+
+var (
+	metricsOnce sync.Once
+	metricsURL  string
+)
+
+// startMetrics serves the metrics on a free port, once as the metrics are registered globally.  The runner counts the
+// external payloads once they are collected
+func startMetrics(t *testing.T) string {
+	metricsOnce.Do(func() {
+		listener, err := net.Listen("tcp", "localhost:0")
+		assert.NoError(t, err)
+		port := listener.Addr().(*net.TCPAddr).Port
+		listener.Close()
+		metricsSettings := settings.NewMetricsSettings("/metrics", port)
+		go metrics.ProvideMetrics(metricsSettings)
+		metricsURL = fmt.Sprintf("http://localhost:%d%s", port, metricsSettings.ApiEndpoint)
+	})
+	assert.Eventually(t, func() bool {
+		_, err := http.Get(metricsURL)
+		return err == nil
+	}, 5*time.Second, 10*time.Millisecond)
+	return metricsURL
+}
+
+// externalPayloadUsed the value of the external_payload_used counter of the entity, 0 before it is counted
+func externalPayloadUsed(t *testing.T, entityName string, operation metrics.Operation, payloadType metrics.PayloadType) string {
+	response, err := http.Get(metricsURL)
+	assert.NoError(t, err)
+	defer response.Body.Close()
+	body, err := io.ReadAll(response.Body)
+	assert.NoError(t, err)
+	series := fmt.Sprintf(`external_payload_used{entityName="%s",operation="%s",payload_type="%s"} `, entityName, operation, payloadType)
+	for _, line := range strings.Split(string(body), "\n") {
+		if strings.HasPrefix(line, series) {
+			return strings.TrimPrefix(line, series)
+		}
+	}
+	return "0"
+}
+
+func largeOutput(sizeKB int) map[string]interface{} {
+	return map[string]interface{}{"data": strings.Repeat("x", sizeKB*1024)}
+}
+
+func TestTaskRunnerExternalStorage(t *testing.T) {
+	startMetrics(t)
+	store := &stubPayloadStore{payloads: map[string][]byte{
+		"stub://orders/input.json": []byte(`{"orderId":"order_1"}`),
+	}}
+	task := model.Task{
+		TaskId:                          "task_1",
+		TaskDefName:                     fmt.Sprintf("external_storage_task_%d", time.Now().UnixNano()),
+		WorkflowInstanceId:              "workflow_1",
+		ExternalInputPayloadStoragePath: "orders/input.json",
+	}
+
+	var input map[string]interface{}
+	taskResult := runTask(t, store, task, func(t *model.Task) (interface{}, error) {
+		input = t.InputData
+		return largeOutput(2), nil
+	})
+	assert.Equal(t, map[string]interface{}{"orderId": "order_1"}, input)
+	assert.Equal(t, model.CompletedTask, taskResult.Status)
+	assert.Nil(t, taskResult.OutputData)
+	assert.Equal(t, "task_output.json", taskResult.ExternalOutputPayloadStoragePath)
+	assert.Contains(t, string(store.payloads["stub://task_output.json"]), "xxxx")
+
+	assert.Equal(t, "1", externalPayloadUsed(t, task.TaskDefName, metrics.READ, metrics.TASK_INPUT))
+	assert.Equal(t, "1", externalPayloadUsed(t, task.TaskDefName, metrics.WRITE, metrics.TASK_OUTPUT))
+}
+
+func TestTaskRunnerExternalStorageFailures(t *testing.T) {
+	task := model.Task{TaskId: "task_1", TaskDefName: "external_storage_failure_task", WorkflowInstanceId: "workflow_1"}
+
+	store := &stubPayloadStore{payloads: map[string][]byte{}}
+	taskResult := runTask(t, store, task, func(t *model.Task) (interface{}, error) {
+		return largeOutput(5), nil
+	})
+	assert.Equal(t, model.FailedWithTerminalErrorTask, taskResult.Status)
+	assert.Equal(t, "the TASK_OUTPUT payload of external_storage_failure_task is 5 KB, larger than the max of 4 KB", taskResult.ReasonForIncompletion)
+	assert.Nil(t, taskResult.OutputData)
+
+	store = &stubPayloadStore{payloads: map[string][]byte{}, uploadErr: fmt.Errorf("store unavailable")}
+	taskResult = runTask(t, store, task, func(t *model.Task) (interface{}, error) {
+		return largeOutput(2), nil
+	})
+	assert.Equal(t, model.FailedTask, taskResult.Status)
+	assert.Contains(t, taskResult.ReasonForIncompletion, "store unavailable")
+	assert.Nil(t, taskResult.OutputData)
+
+	task.ExternalInputPayloadStoragePath = "missing.json"
+	executed := false
+	taskResult = runTask(t, store, task, func(t *model.Task) (interface{}, error) {
+		executed = true
+		return nil, nil
+	})
+	assert.False(t, executed)
+	assert.Equal(t, model.FailedTask, taskResult.Status)
+	assert.Contains(t, taskResult.ReasonForIncompletion, "no payload at stub://missing.json")
+}